@@ -0,0 +1,400 @@
+// Command catalog checks message catalogs and generates code from them
+//
+// Usage:
+//
+//	go run ./cmd/catalog lint [-catalog name] [-format text|json] [-warnings-as-errors]
+//	go run ./cmd/catalog gen [-dir catalog] [-package name] [-catalog name] [-language en-US] [-out codes_gen.go] [-check]
+//	go run ./cmd/catalog export -catalog name -target lang [-source lang] [-format xliff|po|csv] [-out file]
+//	go run ./cmd/catalog import -catalog name -file file [-format xliff|po|csv] [-include-fuzzy] [-dry-run]
+//
+// lint reads the catalogs configured under message_catalog in configs/config.json
+// from their paths on disk (embedded catalogs are compiled from the same files) and
+// reports missing or extra codes, placeholder mismatches, invalid severities and
+// categories, and schema violations. It exits with 1 when errors are found and
+// with 2 on usage or configuration problems.
+//
+// gen writes Go constants and parameter structs for a catalog directory (see
+// pkg/catalogcodegen). It is meant for go:generate, which runs it in the package
+// directory with $GOPACKAGE set. With -check it writes nothing and exits with 1
+// when the generated file is stale.
+//
+// export writes a catalog's texts in the source language (default_language by
+// default) with their target language translations as a translation vendor file.
+// import writes a translated file back into the per-language JSON files, recording
+// one version per changed message (see pkg/catalogexchange). It prints untranslated,
+// fuzzy, outdated and rejected units and exits with 1 when units were rejected.
+// Both work on filesystem catalogs.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"tushartemplategin/internal/domains/messagecatalog"
+	"tushartemplategin/pkg/catalogcodegen"
+	"tushartemplategin/pkg/catalogexchange"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/interfaces"
+	"tushartemplategin/pkg/logger"
+)
+
+// Exit codes
+const (
+	exitOK     = 0
+	exitIssues = 1
+	exitUsage  = 2
+)
+
+// usage lists the subcommands
+const usage = `usage: catalog lint [-catalog name] [-format text|json] [-warnings-as-errors]
+       catalog gen [-dir catalog] [-package name] [-catalog name] [-language en-US] [-out codes_gen.go] [-check]
+       catalog export -catalog name -target lang [-source lang] [-format xliff|po|csv] [-out file]
+       catalog import -catalog name -file file [-format xliff|po|csv] [-include-fuzzy] [-dry-run]`
+
+// lintOptions are the flags of the lint subcommand
+type lintOptions struct {
+	catalog          string
+	format           string
+	warningsAsErrors bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes a subcommand and returns the process exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, usage)
+		return exitUsage
+	}
+	switch args[0] {
+	case "lint":
+		return runLint(args[1:], stdout, stderr)
+	case "gen":
+		return runGen(args[1:], stdout, stderr)
+	case "export":
+		return runExport(args[1:], stdout, stderr)
+	case "import":
+		return runImport(args[1:], stdout, stderr)
+	default:
+		fmt.Fprintln(stderr, usage)
+		return exitUsage
+	}
+}
+
+// runLint parses the lint flags and lints the configured catalogs
+func runLint(args []string, stdout, stderr io.Writer) int {
+	var options lintOptions
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&options.catalog, "catalog", "", "lint only this catalog")
+	flags.StringVar(&options.format, "format", "text", "output format: text or json")
+	flags.BoolVar(&options.warningsAsErrors, "warnings-as-errors", false, "exit with 1 on warnings too")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if options.format != "text" && options.format != "json" {
+		fmt.Fprintf(stderr, "unknown format %q\n", options.format)
+		return exitUsage
+	}
+
+	catalogConfig, appLogger, ok := loadCatalogConfig(stderr)
+	if !ok {
+		return exitUsage
+	}
+	return lint(context.Background(), catalogConfig, options, stdout, stderr, appLogger)
+}
+
+// loadCatalogConfig loads the message catalog configuration and a logger that only prints errors
+func loadCatalogConfig(stderr io.Writer) (config.MessageCatalogConfig, interfaces.Logger, bool) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to load configuration: %v\n", err)
+		return config.MessageCatalogConfig{}, nil, false
+	}
+
+	// Only errors reach the console; the report is the output
+	appLogger, err := logger.NewLogger(&logger.Config{Level: "error", Format: "console", Output: "stdout"})
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to initialize logger: %v\n", err)
+		return config.MessageCatalogConfig{}, nil, false
+	}
+	return cfg.GetMessageCatalog(), appLogger, true
+}
+
+// lint lints the configured catalogs from disk and prints the report
+func lint(ctx context.Context, catalogConfig config.MessageCatalogConfig, options lintOptions, stdout, stderr io.Writer, log interfaces.Logger) int {
+	var catalogs []config.CatalogConfig
+	var names []string
+	for _, catalog := range catalogConfig.Catalogs {
+		if options.catalog != "" && catalog.Name != options.catalog {
+			continue
+		}
+		// Database catalogs have no files; they are checked by the server's validation_mode
+		if catalog.Source == messagecatalog.SourceDatabase {
+			fmt.Fprintf(stderr, "skipping catalog %q: database source\n", catalog.Name)
+			continue
+		}
+		catalogs = append(catalogs, catalog)
+		names = append(names, catalog.Name)
+	}
+	if options.catalog != "" && len(names) == 0 {
+		fmt.Fprintf(stderr, "catalog %q is not configured\n", options.catalog)
+		return exitUsage
+	}
+
+	loader := messagecatalog.NewFileSystemLoader(catalogs, log)
+	report := messagecatalog.NewLinter(loader, catalogConfig.DefaultLanguage).Lint(ctx, names)
+
+	if options.format == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			fmt.Fprintf(stderr, "failed to write report: %v\n", err)
+			return exitUsage
+		}
+	} else {
+		for _, issue := range report.Issues {
+			fmt.Fprintln(stdout, issue.String())
+		}
+		fmt.Fprintf(stdout, "%d catalogs checked: %d errors, %d warnings\n", len(names), report.Errors, report.Warnings)
+	}
+
+	if report.HasErrors() || (options.warningsAsErrors && report.Warnings > 0) {
+		return exitIssues
+	}
+	return exitOK
+}
+
+// runGen generates, or with -check verifies, the code of one catalog directory
+func runGen(args []string, stdout, stderr io.Writer) int {
+	var dir, language, pattern, out string
+	var check bool
+	var options catalogcodegen.Options
+
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&dir, "dir", "catalog", "catalog directory")
+	flags.StringVar(&options.Package, "package", os.Getenv("GOPACKAGE"), "Go package name (default $GOPACKAGE)")
+	flags.StringVar(&options.Catalog, "catalog", "", "catalog name used in comments (default the package name)")
+	flags.StringVar(&options.StructureFile, "structure", "messagecatelog.json", "structure file name")
+	flags.StringVar(&pattern, "pattern", "messagecatelog-{lang}.json", "language file name pattern")
+	flags.StringVar(&language, "language", "en-US", "language whose placeholders define the parameter structs")
+	flags.StringVar(&out, "out", "codes_gen.go", "generated file")
+	flags.BoolVar(&check, "check", false, "fail if the generated file is stale instead of writing it")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if options.Package == "" {
+		fmt.Fprintln(stderr, "-package is required outside go generate")
+		return exitUsage
+	}
+	if options.Catalog == "" {
+		options.Catalog = options.Package
+	}
+	options.LanguageFile = strings.ReplaceAll(pattern, "{lang}", language)
+
+	fsys := os.DirFS(dir)
+	if check {
+		if err := catalogcodegen.Check(fsys, options, out); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitIssues
+		}
+		fmt.Fprintf(stdout, "%s is up to date\n", out)
+		return exitOK
+	}
+
+	source, err := catalogcodegen.Generate(fsys, options)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitIssues
+	}
+	if err := os.WriteFile(out, source, 0o644); err != nil {
+		fmt.Fprintf(stderr, "failed to write %s: %v\n", out, err)
+		return exitUsage
+	}
+	fmt.Fprintf(stdout, "wrote %s\n", out)
+	return exitOK
+}
+
+// exchangeOptions are the flags of the export and import subcommands
+type exchangeOptions struct {
+	catalog        string
+	sourceLanguage string
+	targetLanguage string
+	format         string
+	file           string
+	includeFuzzy   bool
+	dryRun         bool
+}
+
+// runExport parses the export flags and exports a catalog
+func runExport(args []string, stdout, stderr io.Writer) int {
+	var options exchangeOptions
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&options.catalog, "catalog", "", "catalog to export")
+	flags.StringVar(&options.targetLanguage, "target", "", "target language, e.g. fr-FR")
+	flags.StringVar(&options.sourceLanguage, "source", "", "source language (default the default_language)")
+	flags.StringVar(&options.format, "format", "", "xliff, po or csv (default from the -out extension, else xliff)")
+	flags.StringVar(&options.file, "out", "", "output file (default stdout)")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if options.catalog == "" || options.targetLanguage == "" {
+		fmt.Fprintln(stderr, "-catalog and -target are required")
+		return exitUsage
+	}
+
+	catalogConfig, appLogger, ok := loadCatalogConfig(stderr)
+	if !ok {
+		return exitUsage
+	}
+	return export(context.Background(), catalogConfig, options, stdout, stderr, appLogger)
+}
+
+// export writes a catalog in one target language as a vendor file
+func export(ctx context.Context, catalogConfig config.MessageCatalogConfig, options exchangeOptions, stdout, stderr io.Writer, log interfaces.Logger) int {
+	format, ok := exchangeFormat(options, stderr)
+	if !ok {
+		return exitUsage
+	}
+
+	document, err := newEditor(catalogConfig, log).ExportTranslations(ctx, options.catalog, options.sourceLanguage, options.targetLanguage)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitIssues
+	}
+
+	var out bytes.Buffer
+	if err := catalogexchange.Encode(&out, format, document); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitIssues
+	}
+	if options.file == "" {
+		_, err = stdout.Write(out.Bytes())
+	} else {
+		err = os.WriteFile(options.file, out.Bytes(), 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to write export: %v\n", err)
+		return exitUsage
+	}
+	if options.file != "" {
+		fmt.Fprintf(stdout, "wrote %d units to %s\n", len(document.Units), options.file)
+	}
+	return exitOK
+}
+
+// runImport parses the import flags and imports a translated file
+func runImport(args []string, stdout, stderr io.Writer) int {
+	var options exchangeOptions
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&options.catalog, "catalog", "", "catalog to import into")
+	flags.StringVar(&options.file, "file", "", "translated XLIFF, PO or CSV file")
+	flags.StringVar(&options.format, "format", "", "xliff, po or csv (default from the file extension)")
+	flags.BoolVar(&options.includeFuzzy, "include-fuzzy", false, "import translations marked fuzzy")
+	flags.BoolVar(&options.dryRun, "dry-run", false, "report without writing the catalog")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if options.catalog == "" || options.file == "" {
+		fmt.Fprintln(stderr, "-catalog and -file are required")
+		return exitUsage
+	}
+
+	catalogConfig, appLogger, ok := loadCatalogConfig(stderr)
+	if !ok {
+		return exitUsage
+	}
+	return importFile(context.Background(), catalogConfig, options, stdout, stderr, appLogger)
+}
+
+// importFile imports a translated vendor file and prints the report
+func importFile(ctx context.Context, catalogConfig config.MessageCatalogConfig, options exchangeOptions, stdout, stderr io.Writer, log interfaces.Logger) int {
+	format, ok := exchangeFormat(options, stderr)
+	if !ok {
+		return exitUsage
+	}
+
+	file, err := os.Open(options.file)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	defer file.Close()
+
+	document, err := catalogexchange.Decode(file, format)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitIssues
+	}
+
+	report, err := newEditor(catalogConfig, log).ImportTranslations(ctx, options.catalog, document,
+		messagecatalog.ImportOptions{IncludeFuzzy: options.includeFuzzy, DryRun: options.dryRun})
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitIssues
+	}
+
+	for _, unit := range report.Untranslated {
+		fmt.Fprintf(stdout, "untranslated %s\n", unit)
+	}
+	for _, unit := range report.Fuzzy {
+		fmt.Fprintf(stdout, "fuzzy        %s\n", unit)
+	}
+	for _, unit := range report.Outdated {
+		fmt.Fprintf(stdout, "outdated     %s\n", unit)
+	}
+	for _, problem := range report.Rejected {
+		fmt.Fprintf(stdout, "rejected     %s: %s\n", problem.Unit, problem.Reason)
+	}
+	verb := "updated"
+	if report.DryRun {
+		verb = "would update"
+	}
+	fmt.Fprintf(stdout, "%s/%s: %d units imported, %s %d messages, %d untranslated, %d fuzzy, %d rejected\n",
+		report.CatalogName, report.Language, report.Imported, verb, len(report.Updated),
+		len(report.Untranslated), len(report.Fuzzy), len(report.Rejected))
+
+	if report.HasRejected() {
+		return exitIssues
+	}
+	return exitOK
+}
+
+// exchangeFormat returns the -format flag or the format of the file extension
+func exchangeFormat(options exchangeOptions, stderr io.Writer) (string, bool) {
+	format := options.format
+	if format == "" {
+		if detected, ok := catalogexchange.FormatFromFileName(options.file); ok {
+			format = detected
+		} else {
+			format = catalogexchange.FormatXLIFF
+		}
+	}
+	if !slices.Contains(catalogexchange.Formats, format) {
+		fmt.Fprintf(stderr, "unknown format %q\n", format)
+		return "", false
+	}
+	return format, true
+}
+
+// newEditor creates a catalog editor over the catalog files on disk
+func newEditor(catalogConfig config.MessageCatalogConfig, log interfaces.Logger) messagecatalog.Editor {
+	service := messagecatalog.NewMessageCatalogService(catalogConfig,
+		messagecatalog.NewFileSystemLoader(catalogConfig.Catalogs, log),
+		messagecatalog.NewLRUCacheManager(catalogConfig.CacheMaxEntries, catalogConfig.CacheMaxBytes), log)
+	return messagecatalog.NewCatalogEditor(catalogConfig, map[string]messagecatalog.CatalogStore{
+		messagecatalog.SourceFilesystem: messagecatalog.NewFileStore(catalogConfig.Catalogs, log),
+	}, service, log)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/internal/domains/messagecatalog"
+	"tushartemplategin/mocks"
+	"tushartemplategin/pkg/config"
+)
+
+func TestRun_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitUsage, run(nil, &stdout, &stderr))
+	assert.Equal(t, exitUsage, run([]string{"lint", "-format", "xml"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "usage: catalog lint")
+}
+
+func TestLint_ReportsIssues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog.json"),
+		[]byte(`{"TST0001": {"message_code": "TST0001", "category": "Test", "severity": "LOW", "component": "Test"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog-en-US.json"),
+		[]byte(`{"TST0001": {"message": "Hello {user}"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog-fr-FR.json"),
+		[]byte(`{"TST0001": {"message": "Bonjour {name}"}}`), 0o644))
+
+	catalogConfig := config.MessageCatalogConfig{
+		DefaultLanguage: "en-US",
+		Catalogs: []config.CatalogConfig{
+			{Name: "test", Path: dir, Enabled: true, StructureFile: "messagecatelog.json", LanguageFilePattern: "messagecatelog-{lang}.json"},
+			{Name: "remote", Enabled: true, Source: messagecatalog.SourceDatabase},
+		},
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := lint(context.Background(), catalogConfig, lintOptions{format: "text"}, &stdout, &stderr, mockLogger)
+	assert.Equal(t, exitIssues, code)
+	assert.Contains(t, stdout.String(), "test/fr-FR TST0001 message: [error] placeholder_mismatch")
+	assert.Contains(t, stdout.String(), "1 catalogs checked: 1 errors, 0 warnings")
+	assert.Contains(t, stderr.String(), `skipping catalog "remote"`)
+
+	stdout.Reset()
+	code = lint(context.Background(), catalogConfig, lintOptions{catalog: "test", format: "json"}, &stdout, &stderr, mockLogger)
+	assert.Equal(t, exitIssues, code)
+	var report messagecatalog.LintReport
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+	assert.Equal(t, 1, report.Errors)
+
+	code = lint(context.Background(), catalogConfig, lintOptions{catalog: "missing", format: "text"}, &stdout, &stderr, mockLogger)
+	assert.Equal(t, exitUsage, code)
+}
+
+func TestRunGen_WritesAndChecks(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog.json"),
+		[]byte(`{"TST0001": {"message_code": "TST0001", "category": "Test", "severity": "LOW", "component": "Test"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog-en-US.json"),
+		[]byte(`{"TST0001": {"message": "Hello {user}"}}`), 0o644))
+	out := filepath.Join(dir, "codes_gen.go")
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"gen", "-dir", dir, "-package", "sample", "-out", out}
+	assert.Equal(t, exitUsage, run([]string{"gen", "-dir", dir, "-package", ""}, &stdout, &stderr))
+	assert.Equal(t, exitIssues, run(append(args, "-check"), &stdout, &stderr))
+	assert.Equal(t, exitOK, run(args, &stdout, &stderr))
+	assert.Equal(t, exitOK, run(append(args, "-check"), &stdout, &stderr))
+
+	source, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(source), "type TST0001Params struct")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog-en-US.json"),
+		[]byte(`{"TST0001": {"message": "Hello {name}"}}`), 0o644))
+	stderr.Reset()
+	assert.Equal(t, exitIssues, run(append(args, "-check"), &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "is stale")
+}
+
+func TestExportAndImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog.json"),
+		[]byte(`{"TST0001": {"message_code": "TST0001", "category": "Test", "severity": "LOW", "component": "Test"},
+		         "TST0002": {"message_code": "TST0002", "category": "Test", "severity": "LOW", "component": "Test"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog-en-US.json"),
+		[]byte(`{"TST0001": {"message": "Hello {user}"}, "TST0002": {"message": "Bye {user}"}}`), 0o644))
+
+	catalogConfig := config.MessageCatalogConfig{
+		DefaultLanguage: "en-US",
+		Catalogs: []config.CatalogConfig{
+			{Name: "test", Path: dir, Enabled: true, StructureFile: "messagecatelog.json", LanguageFilePattern: "messagecatelog-{lang}.json"},
+		},
+	}
+	file := filepath.Join(dir, "test-fr-FR.csv")
+
+	var stdout, stderr bytes.Buffer
+	code := export(context.Background(), catalogConfig, exchangeOptions{catalog: "test", targetLanguage: "fr-FR", file: file}, &stdout, &stderr, mockLogger)
+	require.Equal(t, exitOK, code, stderr.String())
+	assert.Contains(t, stdout.String(), "wrote 2 units to")
+
+	exported, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(exported), "id:test,context,source:en-US,target:fr-FR,status")
+
+	translated := strings.Replace(string(exported), "Hello {user},,untranslated", "Hello {user},Bonjour {user},translated", 1)
+	translated = strings.Replace(translated, "Bye {user},,untranslated", "Bye {user},Au revoir {nom},translated", 1)
+	require.NoError(t, os.WriteFile(file, []byte(translated), 0o644))
+
+	stdout.Reset()
+	code = importFile(context.Background(), catalogConfig, exchangeOptions{catalog: "test", file: file}, &stdout, &stderr, mockLogger)
+	assert.Equal(t, exitIssues, code)
+	assert.Contains(t, stdout.String(), "rejected     TST0002.message: placeholders differ")
+	assert.Contains(t, stdout.String(), "test/fr-FR: 1 units imported, updated 1 messages, 0 untranslated, 0 fuzzy, 1 rejected")
+
+	translations, err := os.ReadFile(filepath.Join(dir, "messagecatelog-fr-FR.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(translations), "Bonjour {user}")
+
+	assert.Equal(t, exitUsage, run([]string{"export", "-catalog", "test"}, &stdout, &stderr))
+	assert.Equal(t, exitUsage, run([]string{"import", "-catalog", "test"}, &stdout, &stderr))
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"io/fs"
 	"log"
 	"net/http"
 	"os"
@@ -20,9 +21,18 @@ import (
 	// Internal packages for message catalog API
 	"tushartemplategin/internal/domains/messagecatalog"
 
+	// Internal packages for catalog-driven audit events API
+	auditdomain "tushartemplategin/internal/domains/audit"
+
+	// Internal packages for alerting API
+	"tushartemplategin/internal/domains/alert"
+
 	// External packages for configuration, logging, and server
+	alertcatalog "tushartemplategin/pkg/alert"
+	"tushartemplategin/pkg/audit"
 	"tushartemplategin/pkg/config"
 	"tushartemplategin/pkg/database"
+	"tushartemplategin/pkg/errors"
 	"tushartemplategin/pkg/interfaces"
 	"tushartemplategin/pkg/logger"
 	"tushartemplategin/pkg/middleware"
@@ -103,13 +113,16 @@ func main() {
 
 	// ===== DOMAIN SETUP =====
 	// Step 7: Setup domains and middleware
-	router = setupDomainsAndMiddleware(router, appLogger, db, cfg)
+	// Background jobs (e.g. retention purges) run until backgroundCtx is cancelled at shutdown
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	router = setupDomainsAndMiddleware(backgroundCtx, router, appLogger, db, cfg)
 
 	// Step 8: Setup API routes using module-level route registration
 	api := router.Group("/api/v1") // API version 1 group
 
 	// Register all domain routes in a clean, organized way
-	registerAllRoutes(api, appLogger)
+	registerAllRoutes(api, appLogger, cfg)
 
 	// ===== SERVER LIFECYCLE =====
 	// Step 9: Create server instance with our router and SSL configuration
@@ -182,10 +195,11 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel() // Ensure context is cancelled when function exits
 
-	// Step 14: Attempt graceful shutdown
+	// Step 14: Attempt graceful shutdown and stop background jobs
 	if err := srv.Shutdown(ctx); err != nil {
 		appLogger.Fatal(context.Background(), "Server forced to shutdown", err, interfaces.Fields{})
 	}
+	stopBackground()
 
 	// Step 15: Disconnect from database
 	if err := db.Disconnect(ctx); err != nil {
@@ -199,9 +213,21 @@ func main() {
 }
 
 // setupDomainsAndMiddleware initializes domain-specific components and middleware
-func setupDomainsAndMiddleware(router *gin.Engine, appLogger logger.Logger, db interfaces.Database, cfg *config.Config) *gin.Engine {
+// Background jobs started here stop when background is cancelled
+func setupDomainsAndMiddleware(background context.Context, router *gin.Engine, appLogger logger.Logger, db interfaces.Database, cfg *config.Config) *gin.Engine {
 	ctx := context.Background()
 
+	// ===== ACCESS LOG MIDDLEWARE =====
+	// Registered first so it observes the final status written by the error handler
+	if cfg.Server.AccessLog.Enabled {
+		appLogger.Info(ctx, "Setting up access log middleware", interfaces.Fields{})
+		router.Use(middleware.AccessLogMiddleware(appLogger, middleware.AccessLogConfig{
+			SkipPaths:     cfg.Server.AccessLog.SkipPaths,
+			SlowThreshold: cfg.Server.AccessLog.SlowThreshold,
+		}))
+		appLogger.Info(ctx, "Access log middleware setup complete", interfaces.Fields{})
+	}
+
 	// ===== ERROR HANDLING MIDDLEWARE =====
 	appLogger.Info(ctx, "Setting up error handling middleware", interfaces.Fields{})
 	router.Use(middleware.ErrorHandlerMiddleware(appLogger))
@@ -209,7 +235,7 @@ func setupDomainsAndMiddleware(router *gin.Engine, appLogger logger.Logger, db i
 
 	// ===== CORRELATION ID MIDDLEWARE =====
 	appLogger.Info(ctx, "Setting up correlation ID middleware", interfaces.Fields{})
-	router.Use(middleware.CorrelationIDMiddleware())
+	router.Use(middleware.CorrelationIDMiddleware(appLogger))
 	appLogger.Info(ctx, "Correlation ID middleware setup complete", interfaces.Fields{})
 
 	// ===== SECURITY MIDDLEWARE =====
@@ -236,11 +262,19 @@ func setupDomainsAndMiddleware(router *gin.Engine, appLogger logger.Logger, db i
 	// ===== PRODUCT REGISTRATION DOMAIN =====
 	appLogger.Info(ctx, "Setting up product registration domain", interfaces.Fields{})
 
+	// Create audit recorder so product mutations are recorded in the same transaction
+	auditRecorder := audit.NewRecorder(db, appLogger)
+
 	// Create product repository (data access layer) - REQUIRES DATABASE
-	productRepo := productregistration.NewProductRepository(db, appLogger)
+	// Full-text product search uses PostgreSQL text search; other backends fall back to LIKE matching
+	fullTextSearch := cfg.Database.Type == database.DatabaseTypePostgreSQL.String()
+	productRepo := productregistration.NewProductRepository(db, auditRecorder, fullTextSearch, appLogger)
 
 	// Create product service (business logic layer)
-	productService := productregistration.NewProductService(productRepo, appLogger)
+	productService := productregistration.NewProductService(productRepo, cfg.Products, appLogger)
+
+	// Purge products deleted longer than the retention period ago in the background
+	productService.StartRetention(background)
 
 	// Add product service to context so routes can access it
 	router.Use(func(c *gin.Context) {
@@ -252,24 +286,103 @@ func setupDomainsAndMiddleware(router *gin.Engine, appLogger logger.Logger, db i
 	// ===== MESSAGE CATALOG DOMAIN =====
 	appLogger.Info(ctx, "Setting up message catalog domain", interfaces.Fields{})
 
-	// Create message catalog service (no database required)
-	messageCatalogService := messagecatalog.NewMessageCatalogService(cfg.GetMessageCatalog(), appLogger)
+	// Each catalog is read from its configured source: files on disk (default),
+	// the copies compiled into the binary, or the message_catalog_* tables
+	catalogConfig := cfg.GetMessageCatalog()
+	catalogLoader := messagecatalog.NewSourceLoader(catalogConfig.Catalogs, map[string]messagecatalog.CatalogLoader{
+		messagecatalog.SourceFilesystem: messagecatalog.NewFileSystemLoader(catalogConfig.Catalogs, appLogger),
+		messagecatalog.SourceEmbed: messagecatalog.NewEmbedLoader(catalogConfig.Catalogs, map[string]fs.FS{
+			"alert":                         alertcatalog.CatalogFS(),
+			"audit":                         audit.CatalogFS(),
+			messagecatalog.ErrorCatalogName: errors.CatalogFS(),
+		}, appLogger),
+		messagecatalog.SourceDatabase: messagecatalog.NewDatabaseLoader(db, appLogger),
+	})
+
+	// Create message catalog service with an LRU cache of catalog languages
+	messageCatalogService := messagecatalog.NewMessageCatalogService(catalogConfig, catalogLoader,
+		messagecatalog.NewLRUCacheManager(catalogConfig.CacheMaxEntries, catalogConfig.CacheMaxBytes), appLogger)
+
+	// Lint catalogs at startup: "warn" logs issues, "strict" refuses to start on errors
+	if messagecatalog.ValidationMode(&catalogConfig) != messagecatalog.ValidationOff {
+		if _, err := messageCatalogService.ValidateCatalogs(ctx); err != nil {
+			log.Fatalf("Message catalog validation failed: %v", err)
+		}
+	}
+
+	// Reload catalogs every reload_interval_seconds so edits are picked up without a restart
+	messageCatalogService.StartAutoReload(background)
+
+	// Reload catalogs as soon as translators edit their files
+	if err := messageCatalogService.StartWatching(background); err != nil {
+		appLogger.Error(ctx, "Failed to watch message catalog files", interfaces.Fields{"error": err.Error()})
+	}
 
-	// Add message catalog service to context so other services can access it
+	// Catalog management API: filesystem catalogs are edited in their JSON files,
+	// database catalogs in their tables; embedded catalogs are read-only
+	catalogEditor := messagecatalog.NewCatalogEditor(catalogConfig, map[string]messagecatalog.CatalogStore{
+		messagecatalog.SourceFilesystem: messagecatalog.NewFileStore(catalogConfig.Catalogs, appLogger),
+		messagecatalog.SourceDatabase:   messagecatalog.NewDatabaseStore(db, appLogger),
+	}, messageCatalogService, appLogger)
+
+	// Add message catalog service and editor to context so other services can access them
 	router.Use(func(c *gin.Context) {
 		c.Set("messageCatalogService", messageCatalogService)
+		c.Set("catalogEditor", catalogEditor)
 		c.Next()
 	})
+
+	// Translate error responses from the errors catalog using Accept-Language
+	router.Use(middleware.ErrorLocalizationMiddleware(
+		messagecatalog.NewErrorLocalizer(messageCatalogService, messagecatalog.ErrorCatalogName, appLogger)))
 	appLogger.Info(ctx, "Message catalog domain setup complete", interfaces.Fields{})
 
+	// ===== AUDIT DOMAIN =====
+	appLogger.Info(ctx, "Setting up audit domain", interfaces.Fields{})
+
+	// Create audit repository (data access layer) - REQUIRES DATABASE
+	auditRepo := auditdomain.NewAuditRepository(db, appLogger)
+
+	// Create audit service (business logic layer) backed by the audit catalog
+	auditService := auditdomain.NewAuditService(auditRepo, messageCatalogService, cfg.Audit, appLogger)
+
+	// Purge events past the retention period in the background
+	auditService.StartRetention(background)
+
+	// Add audit service to context so routes can access it
+	router.Use(func(c *gin.Context) {
+		c.Set("auditService", auditService)
+		c.Next()
+	})
+	appLogger.Info(ctx, "Audit domain setup complete", interfaces.Fields{})
+
+	// ===== ALERT DOMAIN =====
+	appLogger.Info(ctx, "Setting up alert domain", interfaces.Fields{})
+
+	// Create alert repository (data access layer) - REQUIRES DATABASE
+	alertRepo := alert.NewAlertRepository(db, appLogger)
+
+	// Create alert service with the notifiers enabled in configuration
+	alertService := alert.NewAlertService(alertRepo, messageCatalogService, cfg.Alert, alert.NewNotifiers(cfg.Alert, appLogger), appLogger)
+
+	// Add alert service to context so routes can access it
+	router.Use(func(c *gin.Context) {
+		c.Set("alertService", alertService)
+		c.Next()
+	})
+	appLogger.Info(ctx, "Alert domain setup complete", interfaces.Fields{})
+
 	appLogger.Info(ctx, "All domain setup complete", interfaces.Fields{})
 	return router
 }
 
 // registerAllRoutes handles all domain route registrations in one organized place
-func registerAllRoutes(api *gin.RouterGroup, appLogger logger.Logger) {
+func registerAllRoutes(api *gin.RouterGroup, appLogger logger.Logger, cfg *config.Config) {
 	ctx := context.Background()
 
+	// Administrative endpoints require an API key from server.auth.apiKeys
+	adminAuth := middleware.APIKeyAuth(cfg.Server.Auth.APIKeys, appLogger)
+
 	// ===== CURRENT DOMAINS =====
 	appLogger.Info(ctx, "Registering health domain routes", interfaces.Fields{})
 	health.RegisterRoutes(api)
@@ -277,8 +390,23 @@ func registerAllRoutes(api *gin.RouterGroup, appLogger logger.Logger) {
 
 	// ===== PRODUCT REGISTRATION DOMAIN =====
 	appLogger.Info(ctx, "Registering product registration domain routes", interfaces.Fields{})
-	productregistration.RegisterRoutes(api)
+	productregistration.RegisterRoutes(api, adminAuth)
 	appLogger.Info(ctx, "Product registration domain routes registered successfully", interfaces.Fields{})
 
+	// ===== AUDIT DOMAIN =====
+	appLogger.Info(ctx, "Registering audit domain routes", interfaces.Fields{})
+	auditdomain.RegisterRoutes(api, adminAuth)
+	appLogger.Info(ctx, "Audit domain routes registered successfully", interfaces.Fields{})
+
+	// ===== ALERT DOMAIN =====
+	appLogger.Info(ctx, "Registering alert domain routes", interfaces.Fields{})
+	alert.RegisterRoutes(api, adminAuth)
+	appLogger.Info(ctx, "Alert domain routes registered successfully", interfaces.Fields{})
+
+	// ===== MESSAGE CATALOG DOMAIN =====
+	appLogger.Info(ctx, "Registering message catalog domain routes", interfaces.Fields{})
+	messagecatalog.RegisterRoutes(api, adminAuth)
+	appLogger.Info(ctx, "Message catalog domain routes registered successfully", interfaces.Fields{})
+
 	appLogger.Info(ctx, "All domain routes registered successfully", interfaces.Fields{})
 }
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"tushartemplategin/internal/domains/messagecatalog"
+	"tushartemplategin/pkg/alert"
+	"tushartemplategin/pkg/audit"
 	"tushartemplategin/pkg/config"
 	"tushartemplategin/pkg/logger"
 )
@@ -38,7 +40,10 @@ func main() {
 	}
 
 	// Create message catalog service
-	messageCatalogService := messagecatalog.NewMessageCatalogService(cfg.GetMessageCatalog(), appLogger)
+	catalogConfig := cfg.GetMessageCatalog()
+	messageCatalogService := messagecatalog.NewMessageCatalogService(catalogConfig,
+		messagecatalog.NewFileSystemLoader(catalogConfig.Catalogs, appLogger),
+		messagecatalog.NewLRUCacheManager(catalogConfig.CacheMaxEntries, catalogConfig.CacheMaxBytes), appLogger)
 
 	ctx := context.Background()
 
@@ -47,12 +52,10 @@ func main() {
 	// Example 1: Get a single message
 	fmt.Println("1. Getting a single message:")
 	req := &messagecatalog.MessageRequest{
-		MessageCode: "ABC0001",
+		MessageCode: alert.CodeABC0001,
 		CatalogName: "alert",
 		Language:    "en-US",
-		Parameters: map[string]interface{}{
-			"exp_date": "2024-12-31",
-		},
+		Parameters:  alert.ABC0001Params{ExpDate: "2024-12-31"}.Parameters(),
 	}
 
 	message, err := messageCatalogService.GetMessage(ctx, req)
@@ -71,12 +74,10 @@ func main() {
 	// Example 2: Get message in French
 	fmt.Println("2. Getting message in French:")
 	reqFr := &messagecatalog.MessageRequest{
-		MessageCode: "ABC0001",
+		MessageCode: alert.CodeABC0001,
 		CatalogName: "alert",
 		Language:    "fr-FR",
-		Parameters: map[string]interface{}{
-			"exp_date": "2024-12-31",
-		},
+		Parameters:  alert.ABC0001Params{ExpDate: "2024-12-31"}.Parameters(),
 	}
 
 	messageFr, err := messageCatalogService.GetMessage(ctx, reqFr)
@@ -115,16 +116,16 @@ func main() {
 	// Example 5: Get audit message
 	fmt.Println("5. Getting audit message:")
 	auditReq := &messagecatalog.MessageRequest{
-		MessageCode: "AUD0001",
+		MessageCode: audit.CodeAUD0001,
 		CatalogName: "audit",
 		Language:    "en-US",
-		Parameters: map[string]interface{}{
-			"username":         "john.doe",
-			"action":           "logged in",
-			"timestamp":        time.Now().Format("2006-01-02 15:04:05"),
-			"ip_address":       "192.168.1.100",
-			"session_duration": "2h 30m",
-		},
+		Parameters: audit.AUD0001Params{
+			Username:        "john.doe",
+			Action:          "logged in",
+			Timestamp:       time.Now().Format("2006-01-02 15:04:05"),
+			IPAddress:       "192.168.1.100",
+			SessionDuration: "2h 30m",
+		}.Parameters(),
 	}
 
 	auditMessage, err := messageCatalogService.GetMessage(ctx, auditReq)
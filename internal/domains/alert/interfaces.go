@@ -0,0 +1,37 @@
+package alert
+
+import (
+	"context"
+	"time"
+)
+
+// Service defines the interface for alert business logic
+type Service interface {
+	// Alert operations
+	RaiseAlert(ctx context.Context, code string, parameters map[string]interface{}) (*RaiseAlertResponse, error)
+	GetAlert(ctx context.Context, id int64, language string) (*Alert, error)
+	ListAlerts(ctx context.Context, filter *AlertFilter) (*AlertListResponse, error)
+
+	// Lifecycle operations
+	AcknowledgeAlert(ctx context.Context, id int64) (*Alert, error)
+	ResolveAlert(ctx context.Context, id int64) (*Alert, error)
+}
+
+// Repository defines the interface for alert data access
+type Repository interface {
+	Create(ctx context.Context, alert *Alert) (*Alert, error)
+	GetByID(ctx context.Context, id int64) (*Alert, error)
+	List(ctx context.Context, filter *AlertFilter) ([]*Alert, int64, error)
+
+	// CreateOrRecordOccurrence stores alert unless an unresolved alert with the same
+	// fingerprint was seen at or after since, in which case that alert's occurrence count
+	// is incremented instead; the boolean reports whether the alert was deduplicated
+	CreateOrRecordOccurrence(ctx context.Context, alert *Alert, since time.Time) (*Alert, bool, error)
+	UpdateStatus(ctx context.Context, id int64, from []AlertStatus, to AlertStatus, actor string, at time.Time) (*Alert, error)
+}
+
+// Notifier delivers a newly raised alert to an external channel
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, alert *Alert) error
+}
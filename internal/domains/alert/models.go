@@ -0,0 +1,79 @@
+package alert
+
+import (
+	"time"
+)
+
+// AlertStatus represents the lifecycle state of an alert
+type AlertStatus string
+
+// Alert status constants
+const (
+	StatusOpen         AlertStatus = "open"
+	StatusAcknowledged AlertStatus = "acknowledged"
+	StatusResolved     AlertStatus = "resolved"
+)
+
+// Alert represents a raised alert defined in the alert catalog
+// Category, severity and component are copied from the catalog when the alert is raised
+type Alert struct {
+	ID          int64                  `json:"id" db:"id"`
+	Code        string                 `json:"code" db:"code"`
+	Category    string                 `json:"category" db:"category"`
+	Severity    string                 `json:"severity" db:"severity"`
+	Component   string                 `json:"component" db:"component"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty" db:"parameters"`
+	Fingerprint string                 `json:"fingerprint" db:"fingerprint"`
+	Status      AlertStatus            `json:"status" db:"status"`
+	Occurrences int                    `json:"occurrences" db:"occurrences"`
+	RaisedBy    string                 `json:"raised_by" db:"raised_by"`
+	FirstSeenAt time.Time              `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt  time.Time              `json:"last_seen_at" db:"last_seen_at"`
+
+	AcknowledgedBy string     `json:"acknowledged_by,omitempty" db:"acknowledged_by"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty" db:"acknowledged_at"`
+	ResolvedBy     string     `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+
+	// Rendered from the catalog in the requested language
+	Message     string `json:"message" db:"-"`
+	Description string `json:"description" db:"-"`
+	Action      string `json:"action" db:"-"`
+	Language    string `json:"language" db:"-"`
+}
+
+// RaiseAlertRequest represents the request payload for raising an alert
+type RaiseAlertRequest struct {
+	Code       string                 `json:"code" binding:"required,min=1,max=50"`
+	Parameters map[string]interface{} `json:"parameters"`
+}
+
+// AlertFilter represents the query parameters for listing alerts
+type AlertFilter struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Status   string `form:"status" binding:"omitempty,oneof=open acknowledged resolved"`
+	Severity string `form:"severity" binding:"omitempty"`
+	Code     string `form:"code" binding:"omitempty"`
+	Language string `form:"language" binding:"omitempty"`
+}
+
+// AlertListResponse represents the response for listing alerts
+type AlertListResponse struct {
+	Alerts []*Alert `json:"alerts"`
+	Total  int64    `json:"total"`
+	Page   int      `json:"page"`
+	Limit  int      `json:"limit"`
+}
+
+// AlertResponse represents the response for a single alert
+type AlertResponse struct {
+	Alert *Alert `json:"alert"`
+}
+
+// RaiseAlertResponse represents the response for raising an alert
+// Deduplicated is true when the alert was folded into an existing open alert
+type RaiseAlertResponse struct {
+	Alert        *Alert `json:"alert"`
+	Deduplicated bool   `json:"deduplicated"`
+}
@@ -0,0 +1,252 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/interfaces"
+)
+
+// Notifier names used in the severity routing configuration
+const (
+	NotifierLog     = "log"
+	NotifierWebhook = "webhook"
+	NotifierSMTP    = "smtp"
+)
+
+// NewNotifiers builds the notifiers enabled in the alert configuration
+// The log notifier is always available
+func NewNotifiers(cfg config.AlertConfig, log interfaces.Logger) []Notifier {
+	notifiers := []Notifier{NewLogNotifier(log)}
+	if cfg.Webhook.Enabled {
+		notifiers = append(notifiers, NewWebhookNotifier(cfg.Webhook))
+	}
+	if cfg.SMTP.Enabled {
+		notifiers = append(notifiers, NewSMTPNotifier(cfg.SMTP))
+	}
+	return notifiers
+}
+
+// LogNotifier writes alerts to the application log
+type LogNotifier struct {
+	logger interfaces.Logger
+}
+
+// NewLogNotifier creates a notifier that logs alerts at Warn level
+func NewLogNotifier(log interfaces.Logger) Notifier {
+	return &LogNotifier{logger: log}
+}
+
+// Name returns the notifier name used in routing
+func (n *LogNotifier) Name() string { return NotifierLog }
+
+// Notify logs the alert
+func (n *LogNotifier) Notify(ctx context.Context, alert *Alert) error {
+	n.logger.Warn(ctx, "Alert raised", interfaces.Fields{
+		"alert_id":  alert.ID,
+		"code":      alert.Code,
+		"severity":  alert.Severity,
+		"category":  alert.Category,
+		"component": alert.Component,
+		"message":   alert.Message,
+	})
+	return nil
+}
+
+// WebhookNotifier posts alerts as JSON to an HTTP endpoint
+type WebhookNotifier struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewWebhookNotifier creates a notifier that posts alerts to the configured URL
+func NewWebhookNotifier(cfg config.WebhookConfig) Notifier {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &WebhookNotifier{
+		url:     cfg.URL,
+		headers: cfg.Headers,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// Name returns the notifier name used in routing
+func (n *WebhookNotifier) Name() string { return NotifierWebhook }
+
+// Notify posts the alert and treats any non-2xx response as a failure
+func (n *WebhookNotifier) Notify(ctx context.Context, alert *Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range n.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SMTPNotifier emails alerts to the configured recipients
+type SMTPNotifier struct {
+	config   config.SMTPConfig
+	timeout  time.Duration
+	sendMail func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPNotifier creates a notifier that emails alerts through the configured SMTP server
+func NewSMTPNotifier(cfg config.SMTPConfig) Notifier {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &SMTPNotifier{
+		config:   cfg,
+		timeout:  timeout,
+		sendMail: sendMailContext,
+	}
+}
+
+// Name returns the notifier name used in routing
+func (n *SMTPNotifier) Name() string { return NotifierSMTP }
+
+// Notify sends a plain-text email describing the alert
+// Delivery is bounded by the request context and the configured timeout
+func (n *SMTPNotifier) Notify(ctx context.Context, alert *Alert) error {
+	if len(n.config.To) == 0 {
+		return fmt.Errorf("no SMTP recipients configured")
+	}
+
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+	if err := n.sendMail(ctx, addr, auth, n.config.From, n.config.To, n.buildMessage(alert)); err != nil {
+		return fmt.Errorf("failed to send alert email: %w", err)
+	}
+	return nil
+}
+
+// sendMailContext is smtp.SendMail with the connection bound to ctx
+// The SMTP client has no context support, so the connection deadline follows the
+// context deadline and cancelling the context closes the connection
+func sendMailContext(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if a != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("smtp server does not support AUTH")
+		}
+		if err := client.Auth(a); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// buildMessage renders the RFC 5322 message for an alert
+// The subject carries the rendered alert message, built from caller-supplied
+// parameters, so every header value is stripped of line breaks and the subject
+// is Q-encoded; the body is never parsed as headers
+func (n *SMTPNotifier) buildMessage(alert *Alert) []byte {
+	subject := fmt.Sprintf("[%s] %s %s", alert.Severity, alert.Code, alert.Message)
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", headerValue(n.config.From))
+	fmt.Fprintf(&msg, "To: %s\r\n", headerValue(strings.Join(n.config.To, ", ")))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", headerValue(subject)))
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&msg, "%s\r\n\r\n", alert.Description)
+	fmt.Fprintf(&msg, "Action: %s\r\n", alert.Action)
+	fmt.Fprintf(&msg, "Category: %s\r\nComponent: %s\r\nAlert ID: %d\r\nFirst seen: %s\r\n",
+		alert.Category, alert.Component, alert.ID, alert.FirstSeenAt.Format(time.RFC3339))
+	return []byte(msg.String())
+}
+
+// headerLineBreaks replaces the characters that would end a header line
+var headerLineBreaks = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// headerValue makes value safe to write as a single header line
+func headerValue(value string) string {
+	return headerLineBreaks.Replace(value)
+}
@@ -0,0 +1,116 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"tushartemplategin/pkg/config"
+)
+
+func TestWebhookNotifier_PostsAlert(t *testing.T) {
+	var received Alert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(config.WebhookConfig{URL: server.URL, Headers: map[string]string{"X-Token": "secret"}})
+
+	err := notifier.Notify(context.Background(), &Alert{ID: 7, Code: "ABC0001", Severity: "CRITICAL"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), received.ID)
+	assert.Equal(t, "ABC0001", received.Code)
+}
+
+func TestWebhookNotifier_FailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(config.WebhookConfig{URL: server.URL})
+
+	assert.Error(t, notifier.Notify(context.Background(), &Alert{Code: "ABC0001"}))
+}
+
+func TestSMTPNotifier_SendsToRecipients(t *testing.T) {
+	notifier := NewSMTPNotifier(config.SMTPConfig{Host: "mail.local", Port: 25, From: "alerts@example.com", To: []string{"oncall@example.com"}}).(*SMTPNotifier)
+
+	var addr string
+	var body []byte
+	var hasDeadline bool
+	notifier.sendMail = func(ctx context.Context, a string, _ smtp.Auth, _ string, _ []string, msg []byte) error {
+		addr, body = a, msg
+		_, hasDeadline = ctx.Deadline()
+		return nil
+	}
+
+	err := notifier.Notify(context.Background(), &Alert{Code: "ABC0005", Severity: "CRITICAL", Message: "Security breach"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mail.local:25", addr)
+	assert.True(t, hasDeadline)
+	assert.Contains(t, string(body), "Subject: [CRITICAL] ABC0005 Security breach")
+}
+
+func TestSMTPNotifier_TimesOutOnUnresponsiveServer(t *testing.T) {
+	// Accept connections but never send the SMTP greeting
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	notifier := NewSMTPNotifier(config.SMTPConfig{Host: "127.0.0.1", Port: addr.Port, From: "alerts@example.com", To: []string{"oncall@example.com"}, Timeout: 100 * time.Millisecond})
+
+	started := time.Now()
+	err = notifier.Notify(context.Background(), &Alert{Code: "ABC0005", Severity: "CRITICAL"})
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(started), 2*time.Second)
+}
+
+func TestSMTPNotifier_BuildMessageRejectsHeaderInjection(t *testing.T) {
+	notifier := NewSMTPNotifier(config.SMTPConfig{From: "alerts@example.com", To: []string{"oncall@example.com"}}).(*SMTPNotifier)
+
+	tests := []struct {
+		name    string
+		message string
+		subject string
+	}{
+		{"plain", "Disk full", "Subject: [HIGH] ABC0001 Disk full\r\n"},
+		{"crlf", "x\r\nBcc: attacker@example.com", "Subject: [HIGH] ABC0001 x Bcc: attacker@example.com\r\n"},
+		{"lone lf", "x\nBcc: attacker@example.com", "Subject: [HIGH] ABC0001 x Bcc: attacker@example.com\r\n"},
+		{"non-ascii", "Disque plein à 95%", "Subject: =?utf-8?q?[HIGH]_ABC0001_Disque_plein_=C3=A0_95%?=\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := string(notifier.buildMessage(&Alert{Code: "ABC0001", Severity: "HIGH", Message: tt.message}))
+
+			headers, _, _ := strings.Cut(msg, "\r\n\r\n")
+			assert.Contains(t, msg, tt.subject)
+			assert.NotContains(t, headers, "\r\nBcc:")
+			assert.NotContains(t, headers, "\nBcc:")
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"tushartemplategin/pkg/interfaces"
+)
+
+// alertColumns is the column list shared by every alert SELECT
+const alertColumns = `id, code, category, severity, component, parameters, fingerprint, status, occurrences,
+		raised_by, first_seen_at, last_seen_at, acknowledged_by, acknowledged_at, resolved_by, resolved_at`
+
+// AlertRepository implements the Repository interface for alert data access
+type AlertRepository struct {
+	db     interfaces.Database
+	logger interfaces.Logger
+}
+
+// NewAlertRepository creates a new alert repository
+func NewAlertRepository(db interfaces.Database, log interfaces.Logger) Repository {
+	return &AlertRepository{
+		db:     db,
+		logger: log,
+	}
+}
+
+// Create stores a newly raised alert
+func (r *AlertRepository) Create(ctx context.Context, alert *Alert) (*Alert, error) {
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		return insertAlert(ctx, tx, alert)
+	}); err != nil {
+		r.logger.Error(ctx, "Failed to create alert", interfaces.Fields{
+			"error": err.Error(),
+			"code":  alert.Code,
+		})
+		return nil, fmt.Errorf("failed to create alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+// GetByID retrieves an alert by its ID, returning nil if it does not exist
+func (r *AlertRepository) GetByID(ctx context.Context, id int64) (*Alert, error) {
+	query := fmt.Sprintf(`SELECT %s FROM alerts WHERE id = $1`, alertColumns)
+
+	alert, err := r.queryOne(ctx, query, id)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to get alert by ID", interfaces.Fields{
+			"error": err.Error(),
+			"id":    id,
+		})
+		return nil, fmt.Errorf("failed to get alert: %w", err)
+	}
+	return alert, nil
+}
+
+// List retrieves alerts with pagination and filtering, most recently seen first
+func (r *AlertRepository) List(ctx context.Context, filter *AlertFilter) ([]*Alert, int64, error) {
+	// Set default values
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+
+	// Build WHERE clause
+	whereConditions := []string{}
+	args := []interface{}{}
+	argIndex := 1
+
+	if filter.Status != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, filter.Status)
+		argIndex++
+	}
+	if filter.Severity != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("severity = $%d", argIndex))
+		args = append(args, strings.ToUpper(filter.Severity))
+		argIndex++
+	}
+	if filter.Code != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("code = $%d", argIndex))
+		args = append(args, filter.Code)
+		argIndex++
+	}
+
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM alerts %s", whereClause)
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM alerts
+		%s
+		ORDER BY last_seen_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, alertColumns, whereClause, argIndex, argIndex+1)
+
+	var (
+		total  int64
+		alerts []*Alert
+	)
+
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if err := tx.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+			return err
+		}
+		rows, err := tx.QueryContext(ctx, query, append(args, filter.Limit, offset)...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			alert, err := scanAlert(rows)
+			if err != nil {
+				return err
+			}
+			alerts = append(alerts, alert)
+		}
+		return rows.Err()
+	}); err != nil {
+		r.logger.Error(ctx, "Failed to list alerts", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, 0, fmt.Errorf("failed to list alerts: %w", err)
+	}
+
+	return alerts, total, nil
+}
+
+// CreateOrRecordOccurrence stores alert or folds it into the newest unresolved alert with
+// the same fingerprint seen since the given time
+// Raises of one fingerprint are serialized with a transaction-scoped advisory lock, since
+// FOR UPDATE cannot lock a row that does not exist yet; the lock on the matched row keeps
+// a concurrent resolve from interleaving with the occurrence update
+func (r *AlertRepository) CreateOrRecordOccurrence(ctx context.Context, alert *Alert, since time.Time) (*Alert, bool, error) {
+	findQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM alerts
+		WHERE fingerprint = $1 AND status <> $2 AND last_seen_at >= $3
+		ORDER BY last_seen_at DESC
+		LIMIT 1
+		FOR UPDATE
+	`, alertColumns)
+	occurrenceQuery := fmt.Sprintf(`
+		UPDATE alerts
+		SET occurrences = occurrences + 1, last_seen_at = $2
+		WHERE id = $1 AND status <> $3
+		RETURNING %s
+	`, alertColumns)
+
+	var (
+		stored       *Alert
+		deduplicated bool
+	)
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		stored, deduplicated = nil, false
+
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, alert.Fingerprint); err != nil {
+			return err
+		}
+
+		existing, err := scanAlert(tx.QueryRowContext(ctx, findQuery, alert.Fingerprint, StatusResolved, since))
+		switch {
+		case err == sql.ErrNoRows:
+			if err := insertAlert(ctx, tx, alert); err != nil {
+				return err
+			}
+			stored = alert
+			return nil
+		case err != nil:
+			return err
+		}
+
+		// The status check is repeated so an occurrence never reopens a resolved alert
+		stored, err = scanAlert(tx.QueryRowContext(ctx, occurrenceQuery, existing.ID, alert.LastSeenAt, StatusResolved))
+		if err != nil {
+			return err
+		}
+		deduplicated = true
+		return nil
+	}); err != nil {
+		r.logger.Error(ctx, "Failed to raise alert", interfaces.Fields{
+			"error":       err.Error(),
+			"code":        alert.Code,
+			"fingerprint": alert.Fingerprint,
+		})
+		return nil, false, fmt.Errorf("failed to raise alert: %w", err)
+	}
+
+	return stored, deduplicated, nil
+}
+
+// UpdateStatus moves an alert to a new status if it is currently in one of the from statuses
+// Returns nil when the alert does not exist or is not in an allowed status
+func (r *AlertRepository) UpdateStatus(ctx context.Context, id int64, from []AlertStatus, to AlertStatus, actor string, at time.Time) (*Alert, error) {
+	var setClause string
+	switch to {
+	case StatusAcknowledged:
+		setClause = "status = $2, acknowledged_by = $3, acknowledged_at = $4"
+	case StatusResolved:
+		setClause = "status = $2, resolved_by = $3, resolved_at = $4"
+	default:
+		return nil, fmt.Errorf("unsupported alert status transition to %q", to)
+	}
+
+	args := []interface{}{id, to, actor, at}
+	placeholders := make([]string, len(from))
+	for i, status := range from {
+		args = append(args, status)
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE alerts
+		SET %s
+		WHERE id = $1 AND status IN (%s)
+		RETURNING %s
+	`, setClause, strings.Join(placeholders, ", "), alertColumns)
+
+	alert, err := r.queryOne(ctx, query, args...)
+	if err != nil {
+		r.logger.Error(ctx, "Failed to update alert status", interfaces.Fields{
+			"error":  err.Error(),
+			"id":     id,
+			"status": to,
+		})
+		return nil, fmt.Errorf("failed to update alert status: %w", err)
+	}
+	return alert, nil
+}
+
+// insertAlert inserts alert inside tx and sets its ID
+func insertAlert(ctx context.Context, tx *sql.Tx, alert *Alert) error {
+	query := `
+		INSERT INTO alerts (code, category, severity, component, parameters, fingerprint, status, occurrences, raised_by, first_seen_at, last_seen_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id
+	`
+
+	parameters, err := json.Marshal(alert.Parameters)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert parameters: %w", err)
+	}
+
+	return tx.QueryRowContext(ctx, query,
+		alert.Code, alert.Category, alert.Severity, alert.Component, parameters, alert.Fingerprint,
+		alert.Status, alert.Occurrences, alert.RaisedBy, alert.FirstSeenAt, alert.LastSeenAt,
+	).Scan(&alert.ID)
+}
+
+// queryOne runs a single-row query in a transaction, returning nil when no row matches
+func (r *AlertRepository) queryOne(ctx context.Context, query string, args ...interface{}) (*Alert, error) {
+	var alert *Alert
+	err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		scanned, err := scanAlert(tx.QueryRowContext(ctx, query, args...))
+		if err != nil {
+			return err
+		}
+		alert = scanned
+		return nil
+	})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return alert, err
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAlert scans a single alert row selected with alertColumns
+func scanAlert(row rowScanner) (*Alert, error) {
+	alert := &Alert{}
+	var (
+		parameters     []byte
+		acknowledgedAt sql.NullTime
+		resolvedAt     sql.NullTime
+	)
+	if err := row.Scan(
+		&alert.ID, &alert.Code, &alert.Category, &alert.Severity, &alert.Component, &parameters,
+		&alert.Fingerprint, &alert.Status, &alert.Occurrences, &alert.RaisedBy,
+		&alert.FirstSeenAt, &alert.LastSeenAt,
+		&alert.AcknowledgedBy, &acknowledgedAt, &alert.ResolvedBy, &resolvedAt,
+	); err != nil {
+		return nil, err
+	}
+	if len(parameters) > 0 {
+		if err := json.Unmarshal(parameters, &alert.Parameters); err != nil {
+			return nil, fmt.Errorf("failed to decode alert parameters: %w", err)
+		}
+	}
+	if acknowledgedAt.Valid {
+		alert.AcknowledgedAt = &acknowledgedAt.Time
+	}
+	if resolvedAt.Valid {
+		alert.ResolvedAt = &resolvedAt.Time
+	}
+	return alert, nil
+}
@@ -0,0 +1,170 @@
+package alert
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/middleware"
+)
+
+// RegisterRoutes registers all alert-related routes to the given router group
+// adminAuth guards raising alerts and changing their status; the principal it
+// authenticates is recorded as raised_by, acknowledged_by and resolved_by
+func RegisterRoutes(router *gin.RouterGroup, adminAuth gin.HandlerFunc) {
+	// Create an alert group under the main API group
+	// This will create routes like /api/v1/alerts, /api/v1/alerts/:id, etc.
+	alertGroup := router.Group("/alerts")
+	{
+		// POST /alerts - Raise an alert (deduplicated and routed to notifiers; authenticated)
+		alertGroup.POST("", adminAuth, raiseAlertHandler)
+
+		// GET /alerts - List alerts filtered by status, severity and code
+		alertGroup.GET("", listAlertsHandler)
+
+		// GET /alerts/:id - Get a specific alert
+		alertGroup.GET("/:id", getAlertHandler)
+
+		// POST /alerts/:id/acknowledge - Acknowledge an open alert (authenticated)
+		alertGroup.POST("/:id/acknowledge", adminAuth, acknowledgeAlertHandler)
+
+		// POST /alerts/:id/resolve - Resolve an open or acknowledged alert (authenticated)
+		alertGroup.POST("/:id/resolve", adminAuth, resolveAlertHandler)
+	}
+}
+
+// raiseAlertHandler handles alert raise requests
+func raiseAlertHandler(c *gin.Context) {
+	// Get the alert service from the context
+	alertService := c.MustGet("alertService").(Service)
+
+	ctx := c.Request.Context()
+
+	// Parse request body
+	var req RaiseAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid request body", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	// Raise alert through service layer
+	response, err := alertService.RaiseAlert(ctx, req.Code, req.Parameters)
+	if err != nil {
+		handleServiceError(c, err, "Failed to raise alert")
+		return
+	}
+
+	// New alerts return 201 Created, folded repeats return 200 OK
+	status := http.StatusCreated
+	if response.Deduplicated {
+		status = http.StatusOK
+	}
+	c.JSON(status, response)
+}
+
+// listAlertsHandler handles listing alerts with filtering
+func listAlertsHandler(c *gin.Context) {
+	// Get the alert service from the context
+	alertService := c.MustGet("alertService").(Service)
+
+	ctx := c.Request.Context()
+
+	// Parse query parameters
+	var filter AlertFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid query parameters", err.Error(), http.StatusBadRequest))
+		return
+	}
+	filter.Language = middleware.PreferredLanguage(c)
+
+	// List alerts through service layer
+	response, err := alertService.ListAlerts(ctx, &filter)
+	if err != nil {
+		handleServiceError(c, err, "Failed to list alerts")
+		return
+	}
+
+	// Return alerts with 200 OK
+	c.JSON(http.StatusOK, response)
+}
+
+// getAlertHandler handles getting a specific alert by ID
+func getAlertHandler(c *gin.Context) {
+	// Get the alert service from the context
+	alertService := c.MustGet("alertService").(Service)
+
+	id, ok := parseAlertID(c)
+	if !ok {
+		return
+	}
+
+	// Get alert through service layer
+	alert, err := alertService.GetAlert(c.Request.Context(), id, middleware.PreferredLanguage(c))
+	if err != nil {
+		handleServiceError(c, err, "Failed to get alert")
+		return
+	}
+
+	// Return alert with 200 OK
+	c.JSON(http.StatusOK, AlertResponse{Alert: alert})
+}
+
+// acknowledgeAlertHandler handles alert acknowledgement requests
+func acknowledgeAlertHandler(c *gin.Context) {
+	// Get the alert service from the context
+	alertService := c.MustGet("alertService").(Service)
+
+	id, ok := parseAlertID(c)
+	if !ok {
+		return
+	}
+
+	alert, err := alertService.AcknowledgeAlert(c.Request.Context(), id)
+	if err != nil {
+		handleServiceError(c, err, "Failed to acknowledge alert")
+		return
+	}
+
+	// Return updated alert with 200 OK
+	c.JSON(http.StatusOK, AlertResponse{Alert: alert})
+}
+
+// resolveAlertHandler handles alert resolution requests
+func resolveAlertHandler(c *gin.Context) {
+	// Get the alert service from the context
+	alertService := c.MustGet("alertService").(Service)
+
+	id, ok := parseAlertID(c)
+	if !ok {
+		return
+	}
+
+	alert, err := alertService.ResolveAlert(c.Request.Context(), id)
+	if err != nil {
+		handleServiceError(c, err, "Failed to resolve alert")
+		return
+	}
+
+	// Return updated alert with 200 OK
+	c.JSON(http.StatusOK, AlertResponse{Alert: alert})
+}
+
+// parseAlertID parses the alert ID URL parameter, responding with 400 when invalid
+func parseAlertID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid alert ID", "Alert ID must be a valid integer", http.StatusBadRequest))
+		return 0, false
+	}
+	return id, true
+}
+
+// handleServiceError responds with the AppError from the service or wraps unknown errors
+func handleServiceError(c *gin.Context, err error, message string) {
+	if appErr := errors.GetAppError(err); appErr != nil {
+		middleware.HandleAppError(c, appErr)
+		return
+	}
+	middleware.HandleAppError(c, errors.NewWithError(errors.ErrCodeInternalServer, message, http.StatusInternalServerError, err))
+}
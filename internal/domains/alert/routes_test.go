@@ -0,0 +1,82 @@
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/mocks"
+	"tushartemplategin/pkg/middleware"
+)
+
+func newTestRouter(t *testing.T, notifiers ...Notifier) *gin.Engine {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	service, _ := newTestService(t, notifiers...)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandlerMiddleware(mockLogger))
+	router.Use(func(c *gin.Context) {
+		c.Set("alertService", service)
+		c.Next()
+	})
+	RegisterRoutes(router.Group("/api/v1"), middleware.APIKeyAuth(map[string]string{"ops": "key"}, mockLogger))
+	return router
+}
+
+// serveAlert sends a request, with the API key when key is set, and decodes the alert in the response
+func serveAlert(t *testing.T, router *gin.Engine, method, path, body, key string) (int, *Alert) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	var response AlertResponse
+	if recorder.Code < http.StatusBadRequest {
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	}
+	return recorder.Code, response.Alert
+}
+
+func TestRegisterRoutes_MutationsRequireAPIKey(t *testing.T) {
+	pager := &recordingNotifier{name: "pager"}
+	router := newTestRouter(t, pager)
+	raise := `{"code":"ABC0001","parameters":{"exp_date":"2026-12-31"}}`
+
+	status, _ := serveAlert(t, router, http.MethodPost, "/api/v1/alerts", raise, "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Empty(t, pager.received)
+
+	status, raised := serveAlert(t, router, http.MethodPost, "/api/v1/alerts", raise, "key")
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "ops", raised.RaisedBy)
+	assert.Len(t, pager.received, 1)
+
+	for _, action := range []string{"acknowledge", "resolve"} {
+		status, _ = serveAlert(t, router, http.MethodPost, "/api/v1/alerts/1/"+action, "", "")
+		assert.Equal(t, http.StatusUnauthorized, status, action)
+	}
+
+	status, acknowledged := serveAlert(t, router, http.MethodPost, "/api/v1/alerts/1/acknowledge", "", "key")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ops", acknowledged.AcknowledgedBy)
+
+	status, resolved := serveAlert(t, router, http.MethodPost, "/api/v1/alerts/1/resolve", "", "key")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ops", resolved.ResolvedBy)
+}
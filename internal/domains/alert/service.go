@@ -0,0 +1,283 @@
+package alert
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"tushartemplategin/internal/domains/messagecatalog"
+	"tushartemplategin/pkg/audit"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/interfaces"
+	"tushartemplategin/pkg/logger"
+)
+
+// catalogName is the message catalog that defines every alert code
+const catalogName = "alert"
+
+// AlertService implements the Service interface for alert business logic
+type AlertService struct {
+	repo           Repository
+	messageCatalog messagecatalog.Service
+	dedupWindow    time.Duration
+	notifiers      map[string]Notifier
+	routes         map[string][]string
+	logger         interfaces.Logger
+}
+
+// NewAlertService creates a new alert service
+// Routes map severities to notifier names; routes naming a notifier that is not
+// in notifiers (e.g. a disabled webhook) are skipped
+func NewAlertService(repo Repository, messageCatalog messagecatalog.Service, cfg config.AlertConfig, notifiers []Notifier, log interfaces.Logger) Service {
+	byName := make(map[string]Notifier, len(notifiers))
+	for _, notifier := range notifiers {
+		byName[notifier.Name()] = notifier
+	}
+
+	// Viper lower-cases map keys, so normalise severities to the catalog's upper case
+	routes := make(map[string][]string, len(cfg.Routes))
+	for severity, names := range cfg.Routes {
+		routes[strings.ToUpper(severity)] = names
+	}
+
+	return &AlertService{
+		repo:           repo,
+		messageCatalog: messageCatalog,
+		dedupWindow:    cfg.DedupWindow,
+		notifiers:      byName,
+		routes:         routes,
+		logger:         log,
+	}
+}
+
+// RaiseAlert records an alert for a catalog code and notifies the channels routed for its severity
+// A repeat of an unresolved alert with the same code and parameters inside the dedup window
+// only increments its occurrence count and does not notify again
+func (s *AlertService) RaiseAlert(ctx context.Context, code string, parameters map[string]interface{}) (*RaiseAlertResponse, error) {
+	log := logger.FromContext(ctx, s.logger).With(interfaces.Fields{"alert_code": code})
+
+	log.Info(ctx, "Raising alert", interfaces.Fields{})
+
+	// Resolve the alert in the default language; this also validates the code
+	message, err := s.messageCatalog.GetMessage(ctx, &messagecatalog.MessageRequest{
+		MessageCode: code,
+		CatalogName: catalogName,
+		Parameters:  parameters,
+	})
+	if err != nil {
+		log.Warn(ctx, "Unknown alert code", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, errors.NewWithDetails(errors.ErrCodeUnknownAlert, "Unknown alert code", fmt.Sprintf("Alert code '%s' is not defined in the alert catalog", code), http.StatusBadRequest).WithField("code", code)
+	}
+
+	now := time.Now()
+	fingerprint, err := fingerprintFor(code, parameters)
+	if err != nil {
+		return nil, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid alert parameters", err.Error(), http.StatusBadRequest)
+	}
+
+	alert := &Alert{
+		Code:        message.MessageCode,
+		Category:    message.Category,
+		Severity:    message.Severity,
+		Component:   message.Component,
+		Parameters:  parameters,
+		Fingerprint: fingerprint,
+		Status:      StatusOpen,
+		Occurrences: 1,
+		RaisedBy:    audit.ActorFromContext(ctx),
+		FirstSeenAt: now,
+		LastSeenAt:  now,
+	}
+
+	// Fold repeats into the existing alert
+	var (
+		created      *Alert
+		deduplicated bool
+	)
+	if s.dedupWindow > 0 {
+		created, deduplicated, err = s.repo.CreateOrRecordOccurrence(ctx, alert, now.Add(-s.dedupWindow))
+	} else {
+		created, err = s.repo.Create(ctx, alert)
+	}
+	if err != nil {
+		log.Error(ctx, "Failed to persist alert", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "create alert")
+	}
+	applyRendering(created, message)
+
+	if deduplicated {
+		log.Info(ctx, "Alert deduplicated", interfaces.Fields{
+			"alert_id":    created.ID,
+			"occurrences": created.Occurrences,
+		})
+		return &RaiseAlertResponse{Alert: created, Deduplicated: true}, nil
+	}
+
+	s.notify(ctx, log, created)
+
+	log.Info(ctx, "Alert raised successfully", interfaces.Fields{
+		"alert_id": created.ID,
+		"severity": created.Severity,
+	})
+
+	return &RaiseAlertResponse{Alert: created}, nil
+}
+
+// GetAlert retrieves an alert rendered in the requested language
+func (s *AlertService) GetAlert(ctx context.Context, id int64, language string) (*Alert, error) {
+	alert, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "get alert")
+	}
+	if alert == nil {
+		return nil, alertNotFound(id)
+	}
+
+	s.render(ctx, alert, language)
+	return alert, nil
+}
+
+// ListAlerts retrieves filtered alerts rendered in the requested language
+func (s *AlertService) ListAlerts(ctx context.Context, filter *AlertFilter) (*AlertListResponse, error) {
+	alerts, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "list alerts")
+	}
+
+	for _, alert := range alerts {
+		s.render(ctx, alert, filter.Language)
+	}
+
+	if alerts == nil {
+		alerts = []*Alert{}
+	}
+
+	return &AlertListResponse{
+		Alerts: alerts,
+		Total:  total,
+		Page:   filter.Page,
+		Limit:  filter.Limit,
+	}, nil
+}
+
+// AcknowledgeAlert marks an open alert as acknowledged by the current principal
+func (s *AlertService) AcknowledgeAlert(ctx context.Context, id int64) (*Alert, error) {
+	return s.transition(ctx, id, []AlertStatus{StatusOpen}, StatusAcknowledged)
+}
+
+// ResolveAlert marks an open or acknowledged alert as resolved by the current principal
+func (s *AlertService) ResolveAlert(ctx context.Context, id int64) (*Alert, error) {
+	return s.transition(ctx, id, []AlertStatus{StatusOpen, StatusAcknowledged}, StatusResolved)
+}
+
+// transition moves an alert between lifecycle states
+func (s *AlertService) transition(ctx context.Context, id int64, from []AlertStatus, to AlertStatus) (*Alert, error) {
+	log := logger.FromContext(ctx, s.logger).With(interfaces.Fields{"alert_id": id})
+
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "get alert")
+	}
+	if current == nil {
+		return nil, alertNotFound(id)
+	}
+
+	actor := audit.ActorFromContext(ctx)
+	updated, err := s.repo.UpdateStatus(ctx, id, from, to, actor, time.Now())
+	if err != nil {
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "update alert status")
+	}
+	if updated == nil {
+		// Either the status was already wrong or a concurrent request changed it
+		log.Warn(ctx, "Alert status transition rejected", interfaces.Fields{
+			"status": current.Status,
+			"target": to,
+		})
+		return nil, errors.NewWithDetails(errors.ErrCodeInvalidAlertState, "Invalid alert state", fmt.Sprintf("Alert %d is %s and cannot be %s", id, current.Status, to), http.StatusConflict).
+			WithField("alert_id", id).
+			WithField("status", current.Status)
+	}
+
+	s.render(ctx, updated, "")
+
+	log.Info(ctx, "Alert status updated", interfaces.Fields{
+		"status": to,
+		"actor":  actor,
+	})
+	return updated, nil
+}
+
+// notify delivers an alert to every notifier routed for its severity
+// Each notifier bounds its delivery by ctx and its own timeout; failures are
+// logged and do not fail the request
+func (s *AlertService) notify(ctx context.Context, log interfaces.Logger, alert *Alert) {
+	for _, name := range s.routes[strings.ToUpper(alert.Severity)] {
+		notifier, ok := s.notifiers[name]
+		if !ok {
+			continue
+		}
+		if err := notifier.Notify(ctx, alert); err != nil {
+			log.Error(ctx, "Alert notification failed", interfaces.Fields{
+				"alert_id": alert.ID,
+				"notifier": name,
+				"error":    err.Error(),
+			})
+		}
+	}
+}
+
+// render fills the localized fields of an alert from the alert catalog
+// The catalog's language fallback chain covers languages without a translation
+func (s *AlertService) render(ctx context.Context, alert *Alert, language string) {
+	message, err := s.messageCatalog.GetMessage(ctx, &messagecatalog.MessageRequest{
+		MessageCode: alert.Code,
+		CatalogName: catalogName,
+		Language:    language,
+		Parameters:  alert.Parameters,
+	})
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to render alert", interfaces.Fields{
+			"alert_id": alert.ID,
+			"code":     alert.Code,
+			"language": language,
+			"error":    err.Error(),
+		})
+		return
+	}
+
+	applyRendering(alert, message)
+}
+
+// applyRendering copies the localized catalog content onto an alert
+func applyRendering(alert *Alert, message *messagecatalog.MessageResponse) {
+	alert.Message = message.FormattedMessage
+	alert.Description = message.DetailedDescription
+	alert.Action = message.ResponseAction
+	alert.Language = message.Language
+}
+
+// fingerprintFor identifies alerts with the same code and parameters
+// encoding/json sorts map keys, so equal parameter sets hash identically
+func fingerprintFor(code string, parameters map[string]interface{}) (string, error) {
+	encoded, err := json.Marshal(parameters)
+	if err != nil {
+		return "", fmt.Errorf("parameters are not serializable: %w", err)
+	}
+	sum := sha256.Sum256(append([]byte(code+"\x00"), encoded...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// alertNotFound builds the not found error for an alert ID
+func alertNotFound(id int64) error {
+	return errors.NewWithDetails(errors.ErrCodeAlertNotFound, "Alert not found", fmt.Sprintf("Alert with ID %d not found", id), http.StatusNotFound).WithField("alert_id", id)
+}
@@ -0,0 +1,221 @@
+package alert
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"tushartemplategin/internal/domains/messagecatalog"
+	"tushartemplategin/mocks"
+	alertcatalog "tushartemplategin/pkg/alert"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+)
+
+// memoryRepository is an in-memory Repository used by the service tests
+type memoryRepository struct {
+	alerts []*Alert
+}
+
+func (r *memoryRepository) Create(ctx context.Context, alert *Alert) (*Alert, error) {
+	alert.ID = int64(len(r.alerts) + 1)
+	r.alerts = append(r.alerts, alert)
+	copied := *alert
+	return &copied, nil
+}
+
+func (r *memoryRepository) GetByID(ctx context.Context, id int64) (*Alert, error) {
+	for _, alert := range r.alerts {
+		if alert.ID == id {
+			copied := *alert
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRepository) List(ctx context.Context, filter *AlertFilter) ([]*Alert, int64, error) {
+	return r.alerts, int64(len(r.alerts)), nil
+}
+
+func (r *memoryRepository) CreateOrRecordOccurrence(ctx context.Context, alert *Alert, since time.Time) (*Alert, bool, error) {
+	for _, existing := range r.alerts {
+		if existing.Fingerprint == alert.Fingerprint && existing.Status != StatusResolved && !existing.LastSeenAt.Before(since) {
+			existing.Occurrences++
+			existing.LastSeenAt = alert.LastSeenAt
+			copied := *existing
+			return &copied, true, nil
+		}
+	}
+	created, err := r.Create(ctx, alert)
+	return created, false, err
+}
+
+func (r *memoryRepository) UpdateStatus(ctx context.Context, id int64, from []AlertStatus, to AlertStatus, actor string, at time.Time) (*Alert, error) {
+	for _, alert := range r.alerts {
+		if alert.ID != id {
+			continue
+		}
+		for _, status := range from {
+			if alert.Status == status {
+				alert.Status = to
+				switch to {
+				case StatusAcknowledged:
+					alert.AcknowledgedBy = actor
+				case StatusResolved:
+					alert.ResolvedBy = actor
+				}
+				copied := *alert
+				return &copied, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// recordingNotifier remembers the alerts it was asked to deliver
+type recordingNotifier struct {
+	name     string
+	received []*Alert
+}
+
+func (n *recordingNotifier) Name() string { return n.name }
+
+func (n *recordingNotifier) Notify(ctx context.Context, alert *Alert) error {
+	n.received = append(n.received, alert)
+	return nil
+}
+
+func newTestService(t *testing.T, notifiers ...Notifier) (Service, *memoryRepository) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().With(gomock.Any()).Return(mockLogger).AnyTimes()
+
+	catalogConfig := config.MessageCatalogConfig{
+		DefaultLanguage: "en-US",
+		CacheEnabled:    true,
+		Catalogs: []config.CatalogConfig{{
+			Name:                "alert",
+			Enabled:             true,
+			StructureFile:       "messagecatelog.json",
+			LanguageFilePattern: "messagecatelog-{lang}.json",
+			Source:              messagecatalog.SourceEmbed,
+		}},
+	}
+	loader := messagecatalog.NewEmbedLoader(catalogConfig.Catalogs,
+		map[string]fs.FS{"alert": alertcatalog.CatalogFS()}, mockLogger)
+	catalog := messagecatalog.NewMessageCatalogService(catalogConfig, loader, messagecatalog.NewLRUCacheManager(0, 0), mockLogger)
+
+	cfg := config.AlertConfig{
+		DedupWindow: time.Minute,
+		Routes: map[string][]string{
+			"critical": {"pager", "webhook"},
+			"low":      {"webhook"},
+		},
+	}
+
+	repo := &memoryRepository{}
+	return NewAlertService(repo, catalog, cfg, notifiers, mockLogger), repo
+}
+
+func TestAlertService_RaiseAlertRoutesBySeverity(t *testing.T) {
+	pager := &recordingNotifier{name: "pager"}
+	webhook := &recordingNotifier{name: "webhook"}
+	service, _ := newTestService(t, pager, webhook)
+	ctx := context.Background()
+
+	// ABC0001 is CRITICAL, ABC0004 is LOW
+	_, err := service.RaiseAlert(ctx, "ABC0001", map[string]interface{}{"exp_date": "2026-12-31"})
+	assert.NoError(t, err)
+	_, err = service.RaiseAlert(ctx, "ABC0004", nil)
+	assert.NoError(t, err)
+
+	assert.Len(t, pager.received, 1)
+	assert.Len(t, webhook.received, 2)
+	assert.Contains(t, pager.received[0].Description, "2026-12-31")
+}
+
+func TestAlertService_RaiseAlertDeduplicates(t *testing.T) {
+	webhook := &recordingNotifier{name: "webhook"}
+	service, repo := newTestService(t, webhook)
+	ctx := context.Background()
+	params := map[string]interface{}{"exp_date": "2026-12-31"}
+
+	first, err := service.RaiseAlert(ctx, "ABC0001", params)
+	assert.NoError(t, err)
+	second, err := service.RaiseAlert(ctx, "ABC0001", map[string]interface{}{"exp_date": "2026-12-31"})
+	assert.NoError(t, err)
+
+	assert.False(t, first.Deduplicated)
+	assert.True(t, second.Deduplicated)
+	assert.Equal(t, first.Alert.ID, second.Alert.ID)
+	assert.Equal(t, 2, second.Alert.Occurrences)
+	assert.Len(t, repo.alerts, 1)
+	assert.Len(t, webhook.received, 1)
+
+	// Different parameters raise a separate alert
+	third, err := service.RaiseAlert(ctx, "ABC0001", map[string]interface{}{"exp_date": "2027-01-31"})
+	assert.NoError(t, err)
+	assert.False(t, third.Deduplicated)
+	assert.Len(t, repo.alerts, 2)
+}
+
+func TestAlertService_Lifecycle(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+
+	raised, err := service.RaiseAlert(ctx, "ABC0003", nil)
+	assert.NoError(t, err)
+
+	acked, err := service.AcknowledgeAlert(ctx, raised.Alert.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusAcknowledged, acked.Status)
+
+	_, err = service.AcknowledgeAlert(ctx, raised.Alert.ID)
+	assert.Equal(t, errors.ErrCodeInvalidAlertState, errors.GetAppError(err).Code)
+
+	resolved, err := service.ResolveAlert(ctx, raised.Alert.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusResolved, resolved.Status)
+
+	// A resolved alert is not reused by deduplication
+	again, err := service.RaiseAlert(ctx, "ABC0003", nil)
+	assert.NoError(t, err)
+	assert.False(t, again.Deduplicated)
+
+	_, err = service.ResolveAlert(ctx, 99)
+	assert.Equal(t, errors.ErrCodeAlertNotFound, errors.GetAppError(err).Code)
+}
+
+func TestAlertService_RaiseAlertRejectsUnknownCode(t *testing.T) {
+	service, repo := newTestService(t)
+
+	_, err := service.RaiseAlert(context.Background(), "ZZZ0000", nil)
+
+	assert.Equal(t, errors.ErrCodeUnknownAlert, errors.GetAppError(err).Code)
+	assert.Empty(t, repo.alerts)
+}
+
+func TestAlertService_GetAlertFallsBackToDefaultLanguage(t *testing.T) {
+	service, _ := newTestService(t)
+	ctx := context.Background()
+
+	raised, err := service.RaiseAlert(ctx, "ABC0003", nil)
+	assert.NoError(t, err)
+
+	alert, err := service.GetAlert(ctx, raised.Alert.ID, "de-DE")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "en-US", alert.Language)
+	assert.Equal(t, raised.Alert.Message, alert.Message)
+	assert.NotEmpty(t, alert.Message)
+}
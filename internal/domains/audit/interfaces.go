@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Service defines the interface for audit business logic
+type Service interface {
+	// Event operations
+	LogEvent(ctx context.Context, eventCode string, parameters map[string]interface{}) (*AuditEvent, error)
+	GetEvent(ctx context.Context, id int64, language string) (*AuditEvent, error)
+	ListEvents(ctx context.Context, filter *AuditEventFilter) (*AuditEventListResponse, error)
+
+	// Retention management
+	PurgeExpired(ctx context.Context) (*PurgeResponse, error)
+	StartRetention(ctx context.Context)
+	StopRetention()
+}
+
+// Repository defines the interface for audit data access
+type Repository interface {
+	Create(ctx context.Context, event *AuditEvent) (*AuditEvent, error)
+	GetByID(ctx context.Context, id int64) (*AuditEvent, error)
+	List(ctx context.Context, filter *AuditEventFilter) ([]*AuditEvent, int64, error)
+	DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
+}
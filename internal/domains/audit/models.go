@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"time"
+)
+
+// AuditEvent represents a persisted audit event raised from the audit catalog
+// Category, severity and component are copied from the catalog at write time
+// so events can be filtered in SQL even if the catalog changes later
+type AuditEvent struct {
+	ID            int64                  `json:"id" db:"id"`
+	EventCode     string                 `json:"event_code" db:"event_code"`
+	EventCategory string                 `json:"event_category" db:"event_category"`
+	RiskLevel     string                 `json:"risk_level" db:"risk_level"`
+	Component     string                 `json:"component" db:"component"`
+	Parameters    map[string]interface{} `json:"parameters,omitempty" db:"parameters"`
+	Actor         string                 `json:"actor" db:"actor"`
+	CorrelationID string                 `json:"correlation_id,omitempty" db:"correlation_id"`
+	CreatedAt     time.Time              `json:"created_at" db:"created_at"`
+
+	// Rendered from the catalog at read time in the requested language
+	Description string `json:"description" db:"-"`
+	Details     string `json:"details" db:"-"`
+	Action      string `json:"action" db:"-"`
+	Language    string `json:"language" db:"-"`
+}
+
+// LogEventRequest represents the request payload for recording an audit event
+type LogEventRequest struct {
+	EventCode  string                 `json:"event_code" binding:"required,min=1,max=50"`
+	Parameters map[string]interface{} `json:"parameters"`
+}
+
+// AuditEventFilter represents the query parameters for listing audit events
+type AuditEventFilter struct {
+	Page      int       `form:"page" binding:"omitempty,min=1"`
+	Limit     int       `form:"limit" binding:"omitempty,min=1,max=100"`
+	EventCode string    `form:"code" binding:"omitempty"`
+	Category  string    `form:"category" binding:"omitempty"`
+	RiskLevel string    `form:"severity" binding:"omitempty"`
+	Actor     string    `form:"actor" binding:"omitempty"`
+	From      time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Language  string    `form:"language" binding:"omitempty"`
+}
+
+// AuditEventListResponse represents the response for listing audit events
+type AuditEventListResponse struct {
+	Events []*AuditEvent `json:"events"`
+	Total  int64         `json:"total"`
+	Page   int           `json:"page"`
+	Limit  int           `json:"limit"`
+}
+
+// AuditEventResponse represents the response for a single audit event
+type AuditEventResponse struct {
+	Event *AuditEvent `json:"event"`
+}
+
+// PurgeResponse represents the result of a retention purge
+type PurgeResponse struct {
+	Deleted int64     `json:"deleted"`
+	Cutoff  time.Time `json:"cutoff"`
+}
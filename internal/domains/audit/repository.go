@@ -0,0 +1,225 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"tushartemplategin/pkg/interfaces"
+)
+
+// AuditRepository implements the Repository interface for audit event data access
+type AuditRepository struct {
+	db     interfaces.Database
+	logger interfaces.Logger
+}
+
+// NewAuditRepository creates a new audit repository
+func NewAuditRepository(db interfaces.Database, log interfaces.Logger) Repository {
+	return &AuditRepository{
+		db:     db,
+		logger: log,
+	}
+}
+
+// Create stores a new audit event
+func (r *AuditRepository) Create(ctx context.Context, event *AuditEvent) (*AuditEvent, error) {
+	query := `
+		INSERT INTO catalog_audit_events (event_code, event_category, risk_level, component, parameters, actor, correlation_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	parameters, err := json.Marshal(event.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit parameters: %w", err)
+	}
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		return tx.QueryRowContext(ctx, query,
+			event.EventCode, event.EventCategory, event.RiskLevel, event.Component,
+			parameters, event.Actor, event.CorrelationID, event.CreatedAt,
+		).Scan(&event.ID)
+	}); err != nil {
+		r.logger.Error(ctx, "Failed to create audit event", interfaces.Fields{
+			"error":      err.Error(),
+			"event_code": event.EventCode,
+		})
+		return nil, fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	return event, nil
+}
+
+// GetByID retrieves an audit event by its ID, returning nil if it does not exist
+func (r *AuditRepository) GetByID(ctx context.Context, id int64) (*AuditEvent, error) {
+	query := `
+		SELECT id, event_code, event_category, risk_level, component, parameters, actor, correlation_id, created_at
+		FROM catalog_audit_events
+		WHERE id = $1
+	`
+
+	var event *AuditEvent
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		scanned, err := scanAuditEvent(tx.QueryRowContext(ctx, query, id))
+		if err != nil {
+			return err
+		}
+		event = scanned
+		return nil
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		r.logger.Error(ctx, "Failed to get audit event by ID", interfaces.Fields{
+			"error": err.Error(),
+			"id":    id,
+		})
+		return nil, fmt.Errorf("failed to get audit event: %w", err)
+	}
+
+	return event, nil
+}
+
+// List retrieves audit events with pagination and filtering, newest first
+func (r *AuditRepository) List(ctx context.Context, filter *AuditEventFilter) ([]*AuditEvent, int64, error) {
+	// Set default values
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+
+	// Build WHERE clause
+	whereConditions := []string{}
+	args := []interface{}{}
+	argIndex := 1
+
+	addCondition := func(condition string, value interface{}) {
+		whereConditions = append(whereConditions, fmt.Sprintf(condition, argIndex))
+		args = append(args, value)
+		argIndex++
+	}
+
+	if filter.EventCode != "" {
+		addCondition("event_code = $%d", filter.EventCode)
+	}
+	if filter.Category != "" {
+		addCondition("event_category = $%d", filter.Category)
+	}
+	if filter.RiskLevel != "" {
+		addCondition("risk_level = $%d", filter.RiskLevel)
+	}
+	if filter.Actor != "" {
+		addCondition("actor = $%d", filter.Actor)
+	}
+	if !filter.From.IsZero() {
+		addCondition("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition("created_at < $%d", filter.To)
+	}
+
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM catalog_audit_events %s", whereClause)
+	query := fmt.Sprintf(`
+		SELECT id, event_code, event_category, risk_level, component, parameters, actor, correlation_id, created_at
+		FROM catalog_audit_events
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argIndex, argIndex+1)
+
+	var (
+		total  int64
+		events []*AuditEvent
+	)
+
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if err := tx.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+			return err
+		}
+		rows, err := tx.QueryContext(ctx, query, append(args, filter.Limit, offset)...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			event, err := scanAuditEvent(rows)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+		return rows.Err()
+	}); err != nil {
+		r.logger.Error(ctx, "Failed to list audit events", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, 0, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, total, nil
+}
+
+// DeleteOlderThan removes audit events created before the cutoff
+func (r *AuditRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	query := `DELETE FROM catalog_audit_events WHERE created_at < $1`
+
+	var rowsAffected int64
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		result, execErr := tx.ExecContext(ctx, query, cutoff)
+		if execErr != nil {
+			return execErr
+		}
+		var raErr error
+		rowsAffected, raErr = result.RowsAffected()
+		return raErr
+	}); err != nil {
+		r.logger.Error(ctx, "Failed to purge audit events", interfaces.Fields{
+			"error":  err.Error(),
+			"cutoff": cutoff,
+		})
+		return 0, fmt.Errorf("failed to purge audit events: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAuditEvent scans a single audit event row
+func scanAuditEvent(row rowScanner) (*AuditEvent, error) {
+	event := &AuditEvent{}
+	var parameters []byte
+	if err := row.Scan(
+		&event.ID, &event.EventCode, &event.EventCategory, &event.RiskLevel,
+		&event.Component, &parameters, &event.Actor, &event.CorrelationID, &event.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if len(parameters) > 0 {
+		if err := json.Unmarshal(parameters, &event.Parameters); err != nil {
+			return nil, fmt.Errorf("failed to decode audit parameters: %w", err)
+		}
+	}
+	return event, nil
+}
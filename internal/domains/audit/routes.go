@@ -0,0 +1,131 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/middleware"
+)
+
+// RegisterRoutes registers all audit-related routes to the given router group
+// adminAuth guards recording and purging events; reading the trail is public
+func RegisterRoutes(router *gin.RouterGroup, adminAuth gin.HandlerFunc) {
+	// Create an audit group under the main API group
+	// This will create routes like /api/v1/audit/events, /api/v1/audit/events/:id, etc.
+	auditGroup := router.Group("/audit")
+	{
+		// POST /audit/events - Record a new audit event (authenticated)
+		auditGroup.POST("/events", adminAuth, logEventHandler)
+
+		// GET /audit/events - List audit events filtered by code, category, severity and time range
+		auditGroup.GET("/events", listEventsHandler)
+
+		// GET /audit/events/:id - Get a specific audit event
+		auditGroup.GET("/events/:id", getEventHandler)
+
+		// POST /audit/purge - Delete events older than the retention period (authenticated)
+		auditGroup.POST("/purge", adminAuth, purgeEventsHandler)
+	}
+}
+
+// logEventHandler handles audit event creation requests
+func logEventHandler(c *gin.Context) {
+	// Get the audit service from the context
+	auditService := c.MustGet("auditService").(Service)
+
+	ctx := c.Request.Context()
+
+	// Parse request body
+	var req LogEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid request body", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	// Record event through service layer
+	event, err := auditService.LogEvent(ctx, req.EventCode, req.Parameters)
+	if err != nil {
+		handleServiceError(c, err, "Failed to log audit event")
+		return
+	}
+
+	// Return created event with 201 Created
+	c.JSON(http.StatusCreated, AuditEventResponse{Event: event})
+}
+
+// listEventsHandler handles listing audit events with filtering
+func listEventsHandler(c *gin.Context) {
+	// Get the audit service from the context
+	auditService := c.MustGet("auditService").(Service)
+
+	ctx := c.Request.Context()
+
+	// Parse query parameters
+	var filter AuditEventFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid query parameters", err.Error(), http.StatusBadRequest))
+		return
+	}
+	filter.Language = middleware.PreferredLanguage(c)
+
+	// List events through service layer
+	response, err := auditService.ListEvents(ctx, &filter)
+	if err != nil {
+		handleServiceError(c, err, "Failed to list audit events")
+		return
+	}
+
+	// Return events with 200 OK
+	c.JSON(http.StatusOK, response)
+}
+
+// getEventHandler handles getting a specific audit event by ID
+func getEventHandler(c *gin.Context) {
+	// Get the audit service from the context
+	auditService := c.MustGet("auditService").(Service)
+
+	ctx := c.Request.Context()
+
+	// Parse event ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid audit event ID", "Audit event ID must be a valid integer", http.StatusBadRequest))
+		return
+	}
+
+	// Get event through service layer
+	event, err := auditService.GetEvent(ctx, id, middleware.PreferredLanguage(c))
+	if err != nil {
+		handleServiceError(c, err, "Failed to get audit event")
+		return
+	}
+
+	// Return event with 200 OK
+	c.JSON(http.StatusOK, AuditEventResponse{Event: event})
+}
+
+// purgeEventsHandler handles manual retention purge requests
+func purgeEventsHandler(c *gin.Context) {
+	// Get the audit service from the context
+	auditService := c.MustGet("auditService").(Service)
+
+	response, err := auditService.PurgeExpired(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err, "Failed to purge audit events")
+		return
+	}
+
+	// Return purge result with 200 OK
+	c.JSON(http.StatusOK, response)
+}
+
+// handleServiceError responds with the AppError from the service or wraps unknown errors
+func handleServiceError(c *gin.Context, err error, message string) {
+	if appErr := errors.GetAppError(err); appErr != nil {
+		middleware.HandleAppError(c, appErr)
+		return
+	}
+	middleware.HandleAppError(c, errors.NewWithError(errors.ErrCodeInternalServer, message, http.StatusInternalServerError, err))
+}
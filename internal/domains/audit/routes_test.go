@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/mocks"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/middleware"
+)
+
+func newTestRouter(t *testing.T) (*gin.Engine, *memoryRepository) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	service, repo := newTestService(t, config.AuditConfig{RetentionDays: 30})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandlerMiddleware(mockLogger))
+	router.Use(func(c *gin.Context) {
+		c.Set("auditService", service)
+		c.Next()
+	})
+	RegisterRoutes(router.Group("/api/v1"), middleware.APIKeyAuth(map[string]string{"ops": "key"}, mockLogger))
+	return router, repo
+}
+
+func TestRegisterRoutes_WritesRequireAPIKey(t *testing.T) {
+	router, repo := newTestRouter(t)
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodPost, "/api/v1/audit/events", `{"event_code":"AUD0002","parameters":{"table_name":"products"}}`},
+		{http.MethodPost, "/api/v1/audit/purge", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		})
+	}
+	assert.Empty(t, repo.events)
+
+	t.Run("event recorded by the key's principal", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/audit/events", strings.NewReader(tests[0].body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-API-Key", "key")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		require.Equal(t, http.StatusCreated, recorder.Code)
+		var response AuditEventResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, "ops", response.Event.Actor)
+	})
+}
@@ -0,0 +1,227 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"tushartemplategin/internal/domains/messagecatalog"
+	audittrail "tushartemplategin/pkg/audit"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/interfaces"
+	"tushartemplategin/pkg/logger"
+)
+
+// catalogName is the message catalog that defines every audit event code
+const catalogName = "audit"
+
+// AuditService implements the Service interface for audit business logic
+type AuditService struct {
+	repo           Repository
+	messageCatalog messagecatalog.Service
+	config         config.AuditConfig
+	logger         interfaces.Logger
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// NewAuditService creates a new audit service
+func NewAuditService(repo Repository, messageCatalog messagecatalog.Service, cfg config.AuditConfig, log interfaces.Logger) Service {
+	return &AuditService{
+		repo:           repo,
+		messageCatalog: messageCatalog,
+		config:         cfg,
+		logger:         log,
+		stopCh:         make(chan struct{}),
+	}
+}
+
+// LogEvent validates the event code against the audit catalog and persists the event
+// Actor and correlation ID are taken from the request context
+func (s *AuditService) LogEvent(ctx context.Context, eventCode string, parameters map[string]interface{}) (*AuditEvent, error) {
+	log := logger.FromContext(ctx, s.logger).With(interfaces.Fields{"event_code": eventCode})
+
+	log.Info(ctx, "Logging audit event", interfaces.Fields{})
+
+	// Resolve the event in the default language; this also validates the code
+	message, err := s.messageCatalog.GetMessage(ctx, &messagecatalog.MessageRequest{
+		MessageCode: eventCode,
+		CatalogName: catalogName,
+		Parameters:  parameters,
+	})
+	if err != nil {
+		log.Warn(ctx, "Unknown audit event code", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, errors.NewWithDetails(errors.ErrCodeUnknownAuditEvent, "Unknown audit event code", fmt.Sprintf("Event code '%s' is not defined in the audit catalog", eventCode), http.StatusBadRequest).WithField("event_code", eventCode)
+	}
+
+	event := &AuditEvent{
+		EventCode:     message.MessageCode,
+		EventCategory: message.Category,
+		RiskLevel:     message.Severity,
+		Component:     message.Component,
+		Parameters:    parameters,
+		Actor:         audittrail.ActorFromContext(ctx),
+		CorrelationID: audittrail.CorrelationIDFromContext(ctx),
+		CreatedAt:     time.Now(),
+	}
+
+	createdEvent, err := s.repo.Create(ctx, event)
+	if err != nil {
+		log.Error(ctx, "Failed to persist audit event", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "create audit event")
+	}
+
+	applyRendering(createdEvent, message)
+
+	log.Info(ctx, "Audit event logged successfully", interfaces.Fields{
+		"event_id":   createdEvent.ID,
+		"category":   createdEvent.EventCategory,
+		"risk_level": createdEvent.RiskLevel,
+	})
+
+	return createdEvent, nil
+}
+
+// GetEvent retrieves an audit event rendered in the requested language
+func (s *AuditService) GetEvent(ctx context.Context, id int64, language string) (*AuditEvent, error) {
+	event, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "get audit event")
+	}
+	if event == nil {
+		return nil, errors.NewWithDetails(errors.ErrCodeAuditEventNotFound, "Audit event not found", fmt.Sprintf("Audit event with ID %d not found", id), http.StatusNotFound).WithField("event_id", id)
+	}
+
+	s.render(ctx, event, language)
+	return event, nil
+}
+
+// ListEvents retrieves filtered audit events rendered in the requested language
+func (s *AuditService) ListEvents(ctx context.Context, filter *AuditEventFilter) (*AuditEventListResponse, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return nil, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid time range", "'to' must not be before 'from'", http.StatusBadRequest)
+	}
+
+	events, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "list audit events")
+	}
+
+	for _, event := range events {
+		s.render(ctx, event, filter.Language)
+	}
+
+	if events == nil {
+		events = []*AuditEvent{}
+	}
+
+	return &AuditEventListResponse{
+		Events: events,
+		Total:  total,
+		Page:   filter.Page,
+		Limit:  filter.Limit,
+	}, nil
+}
+
+// PurgeExpired deletes events older than the configured retention period
+// A retention of zero days keeps events forever
+func (s *AuditService) PurgeExpired(ctx context.Context) (*PurgeResponse, error) {
+	if s.config.RetentionDays <= 0 {
+		return &PurgeResponse{}, nil
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -s.config.RetentionDays)
+	deleted, err := s.repo.DeleteOlderThan(ctx, cutoff)
+	if err != nil {
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "purge audit events")
+	}
+
+	s.logger.Info(ctx, "Purged expired audit events", interfaces.Fields{
+		"deleted":        deleted,
+		"cutoff":         cutoff,
+		"retention_days": s.config.RetentionDays,
+	})
+
+	return &PurgeResponse{Deleted: deleted, Cutoff: cutoff}, nil
+}
+
+// StartRetention runs PurgeExpired periodically until StopRetention is called
+func (s *AuditService) StartRetention(ctx context.Context) {
+	if s.config.RetentionDays <= 0 || s.config.PurgeInterval <= 0 {
+		s.logger.Info(ctx, "Audit retention purge disabled", interfaces.Fields{
+			"retention_days": s.config.RetentionDays,
+			"purge_interval": s.config.PurgeInterval.String(),
+		})
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.config.PurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.PurgeExpired(ctx); err != nil {
+					s.logger.Error(ctx, "Scheduled audit purge failed", interfaces.Fields{
+						"error": err.Error(),
+					})
+				}
+			case <-s.stopCh:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// StopRetention stops the background retention purge
+func (s *AuditService) StopRetention() {
+	s.stopOnce.Do(func() { close(s.stopCh) })
+}
+
+// render fills the localized fields of an event from the audit catalog
+// Falls back to the default language when the requested one is unavailable
+func (s *AuditService) render(ctx context.Context, event *AuditEvent, language string) {
+	req := &messagecatalog.MessageRequest{
+		MessageCode: event.EventCode,
+		CatalogName: catalogName,
+		Language:    language,
+		Parameters:  event.Parameters,
+	}
+
+	// The catalog returns structure-only messages for languages without a translation
+	message, err := s.messageCatalog.GetMessage(ctx, req)
+	if (err != nil || message.Message == "") && language != "" {
+		req.Language = ""
+		message, err = s.messageCatalog.GetMessage(ctx, req)
+	}
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to render audit event", interfaces.Fields{
+			"event_id":   event.ID,
+			"event_code": event.EventCode,
+			"language":   language,
+			"error":      err.Error(),
+		})
+		return
+	}
+
+	applyRendering(event, message)
+}
+
+// applyRendering copies the localized catalog content onto an event
+func applyRendering(event *AuditEvent, message *messagecatalog.MessageResponse) {
+	event.Description = message.FormattedMessage
+	event.Details = message.DetailedDescription
+	event.Action = message.ResponseAction
+	event.Language = message.Language
+}
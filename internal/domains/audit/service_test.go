@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"tushartemplategin/internal/domains/messagecatalog"
+	"tushartemplategin/mocks"
+	audittrail "tushartemplategin/pkg/audit"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+)
+
+// memoryRepository is an in-memory Repository used by the service tests
+type memoryRepository struct {
+	events []*AuditEvent
+}
+
+func (r *memoryRepository) Create(ctx context.Context, event *AuditEvent) (*AuditEvent, error) {
+	event.ID = int64(len(r.events) + 1)
+	r.events = append(r.events, event)
+	return event, nil
+}
+
+func (r *memoryRepository) GetByID(ctx context.Context, id int64) (*AuditEvent, error) {
+	for _, event := range r.events {
+		if event.ID == id {
+			copied := *event
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRepository) List(ctx context.Context, filter *AuditEventFilter) ([]*AuditEvent, int64, error) {
+	var result []*AuditEvent
+	for _, event := range r.events {
+		if filter.Category == "" || event.EventCategory == filter.Category {
+			copied := *event
+			result = append(result, &copied)
+		}
+	}
+	return result, int64(len(result)), nil
+}
+
+func (r *memoryRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	var kept []*AuditEvent
+	for _, event := range r.events {
+		if !event.CreatedAt.Before(cutoff) {
+			kept = append(kept, event)
+		}
+	}
+	deleted := int64(len(r.events) - len(kept))
+	r.events = kept
+	return deleted, nil
+}
+
+func newTestService(t *testing.T, cfg config.AuditConfig) (Service, *memoryRepository) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().With(gomock.Any()).Return(mockLogger).AnyTimes()
+
+	catalogConfig := config.MessageCatalogConfig{
+		DefaultLanguage: "en-US",
+		CacheEnabled:    true,
+		Catalogs: []config.CatalogConfig{{
+			Name:                "audit",
+			Enabled:             true,
+			StructureFile:       "messagecatelog.json",
+			LanguageFilePattern: "messagecatelog-{lang}.json",
+			Source:              messagecatalog.SourceEmbed,
+		}},
+	}
+	loader := messagecatalog.NewEmbedLoader(catalogConfig.Catalogs,
+		map[string]fs.FS{"audit": audittrail.CatalogFS()}, mockLogger)
+	catalog := messagecatalog.NewMessageCatalogService(catalogConfig, loader, messagecatalog.NewLRUCacheManager(0, 0), mockLogger)
+
+	repo := &memoryRepository{}
+	return NewAuditService(repo, catalog, cfg, mockLogger), repo
+}
+
+func TestAuditService_LogEventCopiesCatalogMetadata(t *testing.T) {
+	service, repo := newTestService(t, config.AuditConfig{})
+
+	event, err := service.LogEvent(context.Background(), "AUD0002", map[string]interface{}{"table_name": "products"})
+
+	assert.NoError(t, err)
+	assert.Len(t, repo.events, 1)
+	assert.Equal(t, "DataModification", event.EventCategory)
+	assert.Equal(t, "CRITICAL", event.RiskLevel)
+	assert.Equal(t, "en-US", event.Language)
+	assert.Contains(t, event.Details, "products")
+}
+
+func TestAuditService_LogEventRejectsUnknownCode(t *testing.T) {
+	service, repo := newTestService(t, config.AuditConfig{})
+
+	_, err := service.LogEvent(context.Background(), "AUD9999", nil)
+
+	appErr := errors.GetAppError(err)
+	if assert.NotNil(t, appErr) {
+		assert.Equal(t, errors.ErrCodeUnknownAuditEvent, appErr.Code)
+	}
+	assert.Empty(t, repo.events)
+}
+
+func TestAuditService_GetEventRendersRequestedLanguage(t *testing.T) {
+	service, _ := newTestService(t, config.AuditConfig{})
+	ctx := context.Background()
+
+	created, err := service.LogEvent(ctx, "AUD0001", map[string]interface{}{"username": "alice"})
+	assert.NoError(t, err)
+
+	event, err := service.GetEvent(ctx, created.ID, "fr-FR")
+	assert.NoError(t, err)
+	assert.Equal(t, "fr-FR", event.Language)
+	assert.Contains(t, event.Details, "alice")
+
+	// Unknown languages fall back to the catalog default
+	event, err = service.GetEvent(ctx, created.ID, "xx-XX")
+	assert.NoError(t, err)
+	assert.Equal(t, "en-US", event.Language)
+
+	_, err = service.GetEvent(ctx, 42, "")
+	assert.Equal(t, errors.ErrCodeAuditEventNotFound, errors.GetAppError(err).Code)
+}
+
+func TestAuditService_PurgeExpired(t *testing.T) {
+	service, repo := newTestService(t, config.AuditConfig{RetentionDays: 30})
+	repo.events = []*AuditEvent{
+		{ID: 1, CreatedAt: time.Now().AddDate(0, 0, -31)},
+		{ID: 2, CreatedAt: time.Now()},
+	}
+
+	result, err := service.PurgeExpired(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.Deleted)
+	assert.Len(t, repo.events, 1)
+}
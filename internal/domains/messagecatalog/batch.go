@@ -0,0 +1,86 @@
+package messagecatalog
+
+import (
+	"context"
+	"fmt"
+
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/interfaces"
+)
+
+// batchLookup is a message resolved once for every batch item that asks for it
+type batchLookup struct {
+	message        *Message
+	fieldLanguages map[string]string
+	err            error
+}
+
+// RenderMessages renders many messages in one call
+// Each distinct (catalog, code, language) is resolved once and rendered for every
+// item with its own parameters. A failing item is reported in its result with the
+// error it would have had on its own; only an oversized batch fails as a whole
+func (s *MessageCatalogService) RenderMessages(ctx context.Context, req *BatchRenderRequest) (*BatchRenderResponse, error) {
+	if limit := s.config.RenderBatchLimit; limit > 0 && len(req.Items) > limit {
+		return nil, errors.NewWithDetails(errors.ErrCodeBadRequest, "Too many messages",
+			fmt.Sprintf("A batch renders at most %d messages, got %d", limit, len(req.Items)), 400).
+			WithField("limit", limit)
+	}
+
+	type lookupKey struct{ catalogName, messageCode, language string }
+	lookups := make(map[lookupKey]*batchLookup)
+
+	response := &BatchRenderResponse{Results: make([]RenderResult, len(req.Items)), Count: len(req.Items)}
+	for i, item := range req.Items {
+		catalogName := item.CatalogName
+		if catalogName == "" {
+			catalogName = req.CatalogName
+		}
+		requested := item.Language
+		if requested == "" {
+			requested = req.Language
+		}
+		if requested == "" {
+			requested = s.config.DefaultLanguage
+		}
+
+		key := lookupKey{catalogName, item.MessageCode, requested}
+		lookup, exists := lookups[key]
+		if !exists {
+			lookup = &batchLookup{}
+			if catalogName == "" || item.MessageCode == "" {
+				lookup.err = errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid batch item",
+					"Every item needs a message_code and a catalog_name (or the batch catalog_name)", 400)
+			} else {
+				lookup.message, lookup.fieldLanguages, lookup.err = s.findMessage(ctx, catalogName, item.MessageCode, requested)
+			}
+			lookups[key] = lookup
+		}
+
+		result := RenderResult{Index: i, MessageCode: item.MessageCode, CatalogName: catalogName, Status: 200}
+		err := lookup.err
+		if err == nil {
+			result.Message, err = s.renderMessage(ctx, lookup.message, lookup.fieldLanguages, requested, item.Parameters)
+		}
+		if err != nil {
+			result.Error = batchItemError(err)
+			result.Status = result.Error.HTTPStatus
+			response.Failed++
+		}
+		response.Results[i] = result
+	}
+
+	s.logger.Debug(ctx, "Rendered message batch", interfaces.Fields{
+		"items":   len(req.Items),
+		"lookups": len(lookups),
+		"failed":  response.Failed,
+	})
+	return response, nil
+}
+
+// batchItemError returns the AppError reported for a failed batch item
+func batchItemError(err error) *errors.AppError {
+	if appErr := errors.GetAppError(err); appErr != nil {
+		return appErr
+	}
+	return errors.NewWithError(errors.ErrCodeInternalServer, "Failed to render message", 500, err)
+}
@@ -0,0 +1,63 @@
+package messagecatalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/pkg/errors"
+)
+
+// countingLoader counts structure loads, one per uncached message lookup
+type countingLoader struct {
+	CatalogLoader
+	structureLoads int
+}
+
+func (l *countingLoader) LoadCatalogStructure(ctx context.Context, catalogName string) (map[string]interface{}, error) {
+	l.structureLoads++
+	return l.CatalogLoader.LoadCatalogStructure(ctx, catalogName)
+}
+
+func TestRenderMessages_DeduplicatesLookupsAndReportsItemErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "{count, plural, one {# alert} other {# alerts}} for {user}")
+	service := newTestService(t, dir, 60)
+	service.config.CacheEnabled = false
+	service.config.StrictParameters = true
+	loader := &countingLoader{CatalogLoader: service.loader}
+	service.loader = loader
+
+	response, err := service.RenderMessages(context.Background(), &BatchRenderRequest{
+		CatalogName: "test",
+		Items: []RenderItem{
+			{MessageCode: "TST0001", Parameters: map[string]interface{}{"count": 1, "user": "ada"}},
+			{MessageCode: "TST0001", Parameters: map[string]interface{}{"count": 3, "user": "bob"}},
+			{MessageCode: "TST0001", Parameters: map[string]interface{}{"count": 2}},
+			{MessageCode: "TST9999"},
+			{MessageCode: "TST0001", CatalogName: "missing"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, response.Count)
+	assert.Equal(t, 3, response.Failed)
+	assert.Equal(t, 2, loader.structureLoads, "TST0001 and TST9999 are each looked up once")
+
+	results := response.Results
+	assert.Equal(t, "1 alert for ada", results[0].Message.FormattedMessage)
+	assert.Equal(t, "3 alerts for bob", results[1].Message.FormattedMessage)
+	assert.Equal(t, 200, results[1].Status)
+	assert.Nil(t, results[2].Message)
+	assert.Equal(t, 400, results[2].Status)
+	assert.Equal(t, errors.ErrCodeBadRequest, results[2].Error.Code)
+	assert.Equal(t, 404, results[3].Status)
+	assert.Equal(t, "Message not found", results[3].Error.Message)
+	assert.Equal(t, 4, results[4].Index)
+	assert.Equal(t, "missing", results[4].CatalogName)
+	assert.Equal(t, "Catalog not found", results[4].Error.Message)
+
+	service.config.RenderBatchLimit = 2
+	_, err = service.RenderMessages(context.Background(), &BatchRenderRequest{CatalogName: "test", Items: make([]RenderItem, 3)})
+	assert.Equal(t, errors.ErrCodeBadRequest, appErrorCode(err))
+}
@@ -0,0 +1,206 @@
+package messagecatalog
+
+import (
+	"container/list"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SizedValue is implemented by cached values that know their approximate memory use
+// Values without it count as zero bytes against the memory budget
+type SizedValue interface {
+	CacheSize() int64
+}
+
+// LRUCacheManager is an in-memory CacheManager bounded by entry count and memory
+// Once maxEntries or maxBytes is exceeded the least recently used entries are evicted
+type LRUCacheManager struct {
+	mutex      sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	bytes      int64
+	entries    map[string]*list.Element
+	order      *list.List // Front is the most recently used entry
+
+	hits        int64
+	misses      int64
+	evictions   int64
+	expirations int64
+}
+
+// lruEntry is one cached value
+type lruEntry struct {
+	key       string
+	value     interface{}
+	size      int64
+	hits      int64
+	lastUsed  time.Time
+	expiresAt time.Time // Zero means no expiry
+}
+
+// NewLRUCacheManager creates an LRU cache holding at most maxEntries values and
+// maxBytes of SizedValue memory (0 means unbounded for either)
+func NewLRUCacheManager(maxEntries int, maxBytes int64) CacheManager {
+	return &LRUCacheManager{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get returns a cached value and marks it as recently used
+func (c *LRUCacheManager) Get(ctx context.Context, key string) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		c.misses++
+		return nil, false
+	}
+
+	entry := element.Value.(*lruEntry)
+	if entry.expired(time.Now()) {
+		c.removeElement(element)
+		c.expirations++
+		c.misses++
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	c.hits++
+	entry.hits++
+	entry.lastUsed = time.Now()
+	return entry.value, true
+}
+
+// Set stores a value for ttl seconds (0 keeps it until deleted or evicted)
+func (c *LRUCacheManager) Set(ctx context.Context, key string, value interface{}, ttl int) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(time.Duration(ttl) * time.Second)
+	}
+
+	size := int64(0)
+	if sized, ok := value.(SizedValue); ok {
+		size = sized.CacheSize()
+	}
+
+	if element, exists := c.entries[key]; exists {
+		entry := element.Value.(*lruEntry)
+		c.bytes += size - entry.size
+		entry.value = value
+		entry.size = size
+		entry.lastUsed = time.Now()
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+	} else {
+		c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, size: size, lastUsed: time.Now(), expiresAt: expiresAt})
+		c.bytes += size
+	}
+
+	// Evict from the least recently used end; the value just stored is kept even
+	// when it alone exceeds the memory budget
+	for c.order.Len() > 1 &&
+		((c.maxEntries > 0 && c.order.Len() > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes)) {
+		c.removeElement(c.order.Back())
+		c.evictions++
+	}
+
+	return nil
+}
+
+// Delete removes a value; deleting a missing key is not an error
+func (c *LRUCacheManager) Delete(ctx context.Context, key string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, exists := c.entries[key]; exists {
+		c.removeElement(element)
+	}
+	return nil
+}
+
+// Clear removes every value; statistics are kept
+func (c *LRUCacheManager) Clear(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.bytes = 0
+	return nil
+}
+
+// Keys returns the keys of all unexpired values, sorted, without affecting recency
+func (c *LRUCacheManager) Keys(ctx context.Context) []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	keys := make([]string, 0, len(c.entries))
+	for key, element := range c.entries {
+		if !element.Value.(*lruEntry).expired(now) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// GetStats returns entry counts, memory use and hit/miss/eviction counters
+// "usage" lists every entry with its size and hits, most recently used first
+func (c *LRUCacheManager) GetStats(ctx context.Context) map[string]interface{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	hitRatio := 0.0
+	if lookups := c.hits + c.misses; lookups > 0 {
+		hitRatio = float64(c.hits) / float64(lookups)
+	}
+
+	usage := make([]map[string]interface{}, 0, c.order.Len())
+	for element := c.order.Front(); element != nil; element = element.Next() {
+		entry := element.Value.(*lruEntry)
+		usage = append(usage, map[string]interface{}{
+			"key":       entry.key,
+			"bytes":     entry.size,
+			"hits":      entry.hits,
+			"last_used": entry.lastUsed,
+		})
+	}
+
+	return map[string]interface{}{
+		"type":        "lru",
+		"entries":     c.order.Len(),
+		"max_entries": c.maxEntries,
+		"bytes":       c.bytes,
+		"max_bytes":   c.maxBytes,
+		"hits":        c.hits,
+		"misses":      c.misses,
+		"hit_ratio":   hitRatio,
+		"evictions":   c.evictions,
+		"expirations": c.expirations,
+		"usage":       usage,
+	}
+}
+
+// removeElement unlinks an entry; the caller must hold mutex
+func (c *LRUCacheManager) removeElement(element *list.Element) {
+	entry := element.Value.(*lruEntry)
+	c.order.Remove(element)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+}
+
+// expired reports whether the entry's TTL has passed
+func (e *lruEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
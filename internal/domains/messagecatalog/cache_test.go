@@ -0,0 +1,98 @@
+package messagecatalog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCacheManager_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCacheManager(2, 0)
+
+	assert.NoError(t, cache.Set(ctx, "a", 1, 0))
+	assert.NoError(t, cache.Set(ctx, "b", 2, 0))
+
+	// Touch "a" so "b" becomes the least recently used entry
+	_, found := cache.Get(ctx, "a")
+	assert.True(t, found)
+
+	assert.NoError(t, cache.Set(ctx, "c", 3, 0))
+
+	assert.Equal(t, []string{"a", "c"}, cache.Keys(ctx))
+	_, found = cache.Get(ctx, "b")
+	assert.False(t, found)
+
+	stats := cache.GetStats(ctx)
+	assert.Equal(t, 2, stats["entries"])
+	assert.Equal(t, int64(1), stats["evictions"])
+	assert.Equal(t, int64(1), stats["hits"])
+	assert.Equal(t, int64(1), stats["misses"])
+	assert.Equal(t, 0.5, stats["hit_ratio"])
+}
+
+func TestLRUCacheManager_ExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCacheManager(0, 0).(*LRUCacheManager)
+
+	assert.NoError(t, cache.Set(ctx, "a", 1, 60))
+	cache.entries["a"].Value.(*lruEntry).expiresAt = time.Now().Add(-time.Second)
+
+	assert.Empty(t, cache.Keys(ctx))
+	_, found := cache.Get(ctx, "a")
+	assert.False(t, found)
+	assert.Equal(t, int64(1), cache.GetStats(ctx)["expirations"])
+}
+
+func TestLRUCacheManager_DeleteAndClear(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCacheManager(0, 0)
+
+	assert.NoError(t, cache.Set(ctx, "a", 1, 0))
+	assert.NoError(t, cache.Set(ctx, "b", 2, 0))
+
+	assert.NoError(t, cache.Delete(ctx, "a"))
+	assert.NoError(t, cache.Delete(ctx, "missing"))
+	assert.Equal(t, []string{"b"}, cache.Keys(ctx))
+
+	assert.NoError(t, cache.Clear(ctx))
+	assert.Empty(t, cache.Keys(ctx))
+}
+
+// sizedValue is a cached value with a fixed size
+type sizedValue int64
+
+func (v sizedValue) CacheSize() int64 { return int64(v) }
+
+func TestLRUCacheManager_EvictsToMemoryBudget(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCacheManager(0, 100)
+
+	assert.NoError(t, cache.Set(ctx, "a", sizedValue(40), 0))
+	assert.NoError(t, cache.Set(ctx, "b", sizedValue(40), 0))
+	_, found := cache.Get(ctx, "a")
+	assert.True(t, found)
+
+	// "b" is the least recently used and goes first
+	assert.NoError(t, cache.Set(ctx, "c", sizedValue(40), 0))
+	assert.Equal(t, []string{"a", "c"}, cache.Keys(ctx))
+
+	// Replacing a value accounts for its new size
+	assert.NoError(t, cache.Set(ctx, "c", sizedValue(10), 0))
+	stats := cache.GetStats(ctx)
+	assert.Equal(t, int64(50), stats["bytes"])
+	assert.Equal(t, int64(100), stats["max_bytes"])
+	usage := stats["usage"].([]map[string]interface{})
+	assert.Equal(t, "c", usage[0]["key"])
+	assert.Equal(t, int64(1), usage[1]["hits"])
+
+	// A value larger than the budget evicts everything else but stays cached
+	assert.NoError(t, cache.Set(ctx, "big", sizedValue(500), 0))
+	assert.Equal(t, []string{"big"}, cache.Keys(ctx))
+	assert.Equal(t, int64(3), cache.GetStats(ctx)["evictions"])
+
+	assert.NoError(t, cache.Delete(ctx, "big"))
+	assert.Equal(t, int64(0), cache.GetStats(ctx)["bytes"])
+}
@@ -0,0 +1,440 @@
+package messagecatalog
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"golang.org/x/text/language"
+	"tushartemplategin/pkg/audit"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/interfaces"
+	"tushartemplategin/pkg/messageformat"
+)
+
+// messageCodePattern restricts codes to names that are safe as file names
+var messageCodePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// CatalogEditor implements Editor on top of one CatalogStore per catalog source
+type CatalogEditor struct {
+	config  config.MessageCatalogConfig
+	stores  map[string]CatalogStore // source -> store
+	service Service
+	logger  interfaces.Logger
+}
+
+// NewCatalogEditor creates a catalog editor
+// stores maps a catalog source to its store; catalogs whose source has no store
+// (e.g. embed) are read-only. Edits are published with service.ReloadCatalog.
+func NewCatalogEditor(cfg config.MessageCatalogConfig, stores map[string]CatalogStore, service Service, log interfaces.Logger) Editor {
+	return &CatalogEditor{
+		config:  cfg,
+		stores:  stores,
+		service: service,
+		logger:  log,
+	}
+}
+
+// ListEntries returns every message of a catalog with all its translations
+func (e *CatalogEditor) ListEntries(ctx context.Context, catalogName string) ([]*CatalogEntry, error) {
+	store, err := e.storeFor(catalogName)
+	if err != nil {
+		return nil, err
+	}
+	return store.ListEntries(ctx, catalogName)
+}
+
+// GetEntry returns one message with all its translations
+func (e *CatalogEditor) GetEntry(ctx context.Context, catalogName, messageCode string) (*CatalogEntry, error) {
+	store, err := e.storeFor(catalogName)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := store.GetEntry(ctx, catalogName, messageCode)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, messageNotFound(catalogName, messageCode)
+	}
+	return entry, nil
+}
+
+// CreateEntry adds a message; req.Version must be 0
+func (e *CatalogEditor) CreateEntry(ctx context.Context, catalogName, messageCode string, req *EntryRequest) (*CatalogEntry, error) {
+	if !messageCodePattern.MatchString(messageCode) {
+		return nil, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid message code",
+			"Message codes may only contain letters, digits and underscores", 400)
+	}
+	return e.edit(ctx, catalogName, messageCode, req.Version, VersionCreate, func(current *CatalogEntry) (*CatalogEntry, error) {
+		if current != nil {
+			return nil, errors.NewWithDetails(errors.ErrCodeConflict, "Message already exists",
+				fmt.Sprintf("Message '%s' already exists in catalog '%s'", messageCode, catalogName), 409).
+				WithField("current_version", current.Version)
+		}
+		return &CatalogEntry{
+			Category:     req.Category,
+			Severity:     req.Severity,
+			Component:    req.Component,
+			Translations: req.Translations,
+		}, nil
+	})
+}
+
+// UpdateEntry replaces the structure of a message and the translations in req
+func (e *CatalogEditor) UpdateEntry(ctx context.Context, catalogName, messageCode string, req *EntryRequest) (*CatalogEntry, error) {
+	return e.edit(ctx, catalogName, messageCode, req.Version, VersionUpdate, func(current *CatalogEntry) (*CatalogEntry, error) {
+		current.Category = req.Category
+		current.Severity = req.Severity
+		current.Component = req.Component
+		for lang, translation := range req.Translations {
+			current.Translations[lang] = translation
+		}
+		return current, nil
+	})
+}
+
+// DeleteEntry removes a message and all its translations; its history is kept
+func (e *CatalogEditor) DeleteEntry(ctx context.Context, catalogName, messageCode string, version int) error {
+	_, err := e.edit(ctx, catalogName, messageCode, version, VersionDelete, func(current *CatalogEntry) (*CatalogEntry, error) {
+		return nil, nil
+	})
+	return err
+}
+
+// PutTranslation creates or replaces the translation of a message in one language
+func (e *CatalogEditor) PutTranslation(ctx context.Context, catalogName, messageCode, lang string, req *TranslationRequest) (*CatalogEntry, error) {
+	return e.edit(ctx, catalogName, messageCode, req.Version, VersionUpdate, func(current *CatalogEntry) (*CatalogEntry, error) {
+		current.Translations[lang] = req.Translation
+		return current, nil
+	})
+}
+
+// DeleteTranslation removes the translation of a message in one language
+// The default language translation cannot be removed
+func (e *CatalogEditor) DeleteTranslation(ctx context.Context, catalogName, messageCode, lang string, version int) (*CatalogEntry, error) {
+	return e.edit(ctx, catalogName, messageCode, version, VersionUpdate, func(current *CatalogEntry) (*CatalogEntry, error) {
+		if _, exists := current.Translations[lang]; !exists {
+			return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Translation not found",
+				fmt.Sprintf("Message '%s' has no '%s' translation", messageCode, lang), 404)
+		}
+		if lang == e.config.DefaultLanguage {
+			return nil, errors.NewWithDetails(errors.ErrCodeBadRequest, "Cannot delete the default language translation",
+				fmt.Sprintf("Every message needs a '%s' translation; delete the message instead", lang), 400)
+		}
+		delete(current.Translations, lang)
+		return current, nil
+	})
+}
+
+// ListVersions returns the history of a message, oldest first
+// A message that was never edited has a single "initial" version
+func (e *CatalogEditor) ListVersions(ctx context.Context, catalogName, messageCode string) ([]*EntryVersion, error) {
+	store, err := e.storeFor(catalogName)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := store.ListVersions(ctx, catalogName, messageCode)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) > 0 {
+		return versions, nil
+	}
+
+	current, err := store.GetEntry(ctx, catalogName, messageCode)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, messageNotFound(catalogName, messageCode)
+	}
+	return []*EntryVersion{initialVersion(current)}, nil
+}
+
+// GetVersion returns one recorded version of a message
+func (e *CatalogEditor) GetVersion(ctx context.Context, catalogName, messageCode string, version int) (*EntryVersion, error) {
+	versions, err := e.ListVersions(ctx, catalogName, messageCode)
+	if err != nil {
+		return nil, err
+	}
+	for _, candidate := range versions {
+		if candidate.Version == version {
+			return candidate, nil
+		}
+	}
+	return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Version not found",
+		fmt.Sprintf("Message '%s' in catalog '%s' has no version %d", messageCode, catalogName, version), 404)
+}
+
+// DiffVersions compares two versions of a message field by field
+func (e *CatalogEditor) DiffVersions(ctx context.Context, catalogName, messageCode string, from, to int) (*EntryDiff, error) {
+	fromVersion, err := e.GetVersion(ctx, catalogName, messageCode, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := e.GetVersion(ctx, catalogName, messageCode, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EntryDiff{
+		CatalogName: catalogName,
+		MessageCode: messageCode,
+		From:        from,
+		To:          to,
+		Changes:     audit.Diff(flattenEntry(fromVersion.Entry), flattenEntry(toVersion.Entry)),
+	}, nil
+}
+
+// Rollback restores an earlier version of a message as a new version
+// It also recreates a deleted message; req.Version is then 0
+func (e *CatalogEditor) Rollback(ctx context.Context, catalogName, messageCode string, req *RollbackRequest) (*CatalogEntry, error) {
+	target, err := e.GetVersion(ctx, catalogName, messageCode, req.ToVersion)
+	if err != nil {
+		return nil, err
+	}
+	if target.Entry == nil {
+		return nil, errors.NewWithDetails(errors.ErrCodeBadRequest, "Cannot roll back to a deletion",
+			fmt.Sprintf("Version %d deleted the message; delete it instead", req.ToVersion), 400)
+	}
+
+	return e.edit(ctx, catalogName, messageCode, req.Version, VersionRollback, func(current *CatalogEntry) (*CatalogEntry, error) {
+		return cloneEntry(target.Entry), nil
+	})
+}
+
+// edit checks the expected version, saves the change and publishes it
+// change receives a copy of the current message (nil when it does not exist) and returns the
+// new message, or nil to delete it
+func (e *CatalogEditor) edit(ctx context.Context, catalogName, messageCode string, expected int, action string, change func(current *CatalogEntry) (*CatalogEntry, error)) (*CatalogEntry, error) {
+	store, err := e.storeFor(catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := e.save(ctx, store, catalogName, messageCode, action, func(current *CatalogEntry) (*CatalogEntry, error) {
+		if current == nil && action != VersionCreate && action != VersionRollback {
+			return nil, messageNotFound(catalogName, messageCode)
+		}
+		currentVersion := 0
+		if current != nil {
+			currentVersion = current.Version
+		}
+		if action != VersionCreate && expected != currentVersion {
+			return nil, errors.NewWithDetails(errors.ErrCodeConflict, "Message was modified",
+				fmt.Sprintf("Message '%s' is at version %d, not %d; reload it and retry", messageCode, currentVersion, expected), 409).
+				WithField("current_version", currentVersion)
+		}
+		return change(current)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	e.publish(ctx, catalogName)
+	return entry, nil
+}
+
+// save validates a change and records it as a new version of a message, without publishing it
+func (e *CatalogEditor) save(ctx context.Context, store CatalogStore, catalogName, messageCode, action string, change func(current *CatalogEntry) (*CatalogEntry, error)) (*CatalogEntry, error) {
+	author := audit.ActorFromContext(ctx)
+	now := time.Now().UTC()
+	entry, err := store.UpdateEntry(ctx, catalogName, messageCode, func(current *CatalogEntry, lastVersion int) ([]*EntryVersion, error) {
+		next, err := change(cloneEntry(current))
+		if err != nil {
+			return nil, err
+		}
+
+		var versions []*EntryVersion
+		// Record the state before the first edit so it can be diffed and restored
+		if current != nil && lastVersion < current.Version {
+			versions = append(versions, initialVersion(current))
+		}
+
+		currentVersion := 0
+		if current != nil {
+			currentVersion = current.Version
+		}
+		version := max(lastVersion, currentVersion) + 1
+		if next != nil {
+			next.CatalogName = catalogName
+			next.MessageCode = messageCode
+			if err := e.validateEntry(next); err != nil {
+				return nil, err
+			}
+			next.Version = version
+			next.UpdatedBy = author
+			next.UpdatedAt = now
+		}
+		return append(versions, &EntryVersion{
+			Version:   version,
+			Action:    action,
+			Author:    author,
+			CreatedAt: now,
+			Entry:     next,
+		}), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	e.logger.Info(ctx, "Catalog entry changed", interfaces.Fields{
+		"catalog_name": catalogName,
+		"message_code": messageCode,
+		"action":       action,
+		"author":       author,
+	})
+	return entry, nil
+}
+
+// publish reloads a catalog so the edit is served; the edit is already saved when this fails
+func (e *CatalogEditor) publish(ctx context.Context, catalogName string) {
+	if err := e.service.ReloadCatalog(ctx, catalogName); err != nil {
+		e.logger.Warn(ctx, "Catalog edit saved but reload failed", interfaces.Fields{
+			"catalog_name": catalogName,
+			"error":        err.Error(),
+		})
+	}
+}
+
+// storeFor returns the store of an enabled catalog
+func (e *CatalogEditor) storeFor(catalogName string) (CatalogStore, error) {
+	for i := range e.config.Catalogs {
+		catalog := &e.config.Catalogs[i]
+		if catalog.Name != catalogName || !catalog.Enabled {
+			continue
+		}
+		store, exists := e.stores[catalogSource(catalog)]
+		if !exists || store == nil {
+			return nil, errors.NewWithDetails(errors.ErrCodeForbidden, "Catalog is read-only",
+				fmt.Sprintf("Catalog '%s' is loaded from source '%s', which cannot be edited", catalogName, catalogSource(catalog)), 403)
+		}
+		return store, nil
+	}
+	return nil, catalogNotFound(catalogName)
+}
+
+// validateEntry applies the lint rules to a message before it is saved
+// Problems are returned as a 400 error with one "field" -> reason entry each
+func (e *CatalogEditor) validateEntry(entry *CatalogEntry) error {
+	problems := make(map[string]string)
+	if !slices.Contains(KnownSeverities, entry.Severity) {
+		problems["severity"] = fmt.Sprintf("must be one of %v", KnownSeverities)
+	}
+	if !categoryPattern.MatchString(entry.Category) {
+		problems["category"] = "must be a PascalCase identifier"
+	}
+	if entry.Component == "" {
+		problems["component"] = "is required"
+	}
+
+	reference, hasDefault := entry.Translations[e.config.DefaultLanguage]
+	if !hasDefault {
+		problems["translations"] = fmt.Sprintf("a '%s' translation is required", e.config.DefaultLanguage)
+	}
+	for lang, translation := range entry.Translations {
+		if _, err := language.Parse(lang); err != nil {
+			problems["translations."+lang] = "is not a valid language tag"
+			continue
+		}
+		if translation.Message == "" {
+			problems["translations."+lang+".message"] = "is required"
+		}
+		fields := map[string][2]string{
+			"message":              {translation.Message, reference.Message},
+			"detailed_description": {translation.DetailedDescription, reference.DetailedDescription},
+			"response_action":      {translation.ResponseAction, reference.ResponseAction},
+		}
+		for field, texts := range fields {
+			name := "translations." + lang + "." + field
+			parameters, err := patternParameters(texts[0])
+			if err != nil {
+				problems[name] = err.Error()
+				continue
+			}
+			if !hasDefault || lang == e.config.DefaultLanguage {
+				continue
+			}
+			// A broken default language pattern is reported on its own field
+			expected, err := patternParameters(texts[1])
+			if err != nil {
+				continue
+			}
+			if missing, unknown := difference(expected, parameters), difference(parameters, expected); len(missing)+len(unknown) > 0 {
+				problems[name] = fmt.Sprintf("placeholders differ from %s (missing %v, unknown %v)", e.config.DefaultLanguage, missing, unknown)
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid catalog entry",
+		fmt.Sprintf("Message '%s' has %d invalid fields", entry.MessageCode, len(problems)), 400).
+		WithField("problems", problems)
+}
+
+// patternParameters returns the placeholders of a MessageFormat pattern
+func patternParameters(pattern string) ([]string, error) {
+	message, err := messageformat.Parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return message.Parameters(), nil
+}
+
+// messageNotFound is returned for an unknown message code
+func messageNotFound(catalogName, messageCode string) error {
+	return errors.NewWithDetails(errors.ErrCodeNotFound, "Message not found",
+		fmt.Sprintf("Message '%s' not found in catalog '%s'", messageCode, catalogName), 404)
+}
+
+// initialVersion records a message as it was before its first edit
+func initialVersion(entry *CatalogEntry) *EntryVersion {
+	author := entry.UpdatedBy
+	if author == "" {
+		author = audit.SystemActor
+	}
+	return &EntryVersion{
+		Version:   entry.Version,
+		Action:    VersionInitial,
+		Author:    author,
+		CreatedAt: entry.UpdatedAt,
+		Entry:     cloneEntry(entry),
+	}
+}
+
+// cloneEntry copies a message so callers can change it; nil stays nil
+func cloneEntry(entry *CatalogEntry) *CatalogEntry {
+	if entry == nil {
+		return nil
+	}
+	clone := *entry
+	clone.Translations = make(map[string]Translation, len(entry.Translations))
+	for lang, translation := range entry.Translations {
+		clone.Translations[lang] = translation
+	}
+	return &clone
+}
+
+// flattenEntry lists the editable fields of a message for diffing; nil has none
+func flattenEntry(entry *CatalogEntry) map[string]string {
+	fields := make(map[string]string)
+	if entry == nil {
+		return fields
+	}
+	fields["category"] = entry.Category
+	fields["severity"] = entry.Severity
+	fields["component"] = entry.Component
+	for lang, translation := range entry.Translations {
+		prefix := "translations." + lang + "."
+		fields[prefix+"message"] = translation.Message
+		fields[prefix+"detailed_description"] = translation.DetailedDescription
+		fields[prefix+"response_action"] = translation.ResponseAction
+	}
+	return fields
+}
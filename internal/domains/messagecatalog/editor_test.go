@@ -0,0 +1,155 @@
+package messagecatalog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/pkg/audit"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+)
+
+// newTestEditor copies a small catalog into a temp dir and returns an editor over it
+func newTestEditor(t *testing.T) (Editor, Service, string) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog.json"),
+		[]byte(`{"TST0001": {"message_code": "TST0001", "category": "Security", "severity": "HIGH", "component": "Auth"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog-en-US.json"),
+		[]byte(`{"TST0001": {"message": "Login failed for {user}"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog-fr-FR.json"),
+		[]byte(`{"TST0001": {"message": "Échec de connexion pour {user}"}}`), 0o644))
+
+	log := newQuietLogger(t)
+	catalogConfig := config.MessageCatalogConfig{
+		DefaultLanguage: "en-US",
+		CacheEnabled:    true,
+		Catalogs: []config.CatalogConfig{
+			{Name: "test", Path: dir, Enabled: true, StructureFile: "messagecatelog.json", LanguageFilePattern: "messagecatelog-{lang}.json"},
+			{Name: "builtin", Enabled: true, Source: SourceEmbed},
+		},
+	}
+	service := NewMessageCatalogService(catalogConfig, NewFileSystemLoader(catalogConfig.Catalogs, log), NewLRUCacheManager(0, 0), log)
+	editor := NewCatalogEditor(catalogConfig, map[string]CatalogStore{
+		SourceFilesystem: NewFileStore(catalogConfig.Catalogs, log),
+	}, service, log)
+	return editor, service, dir
+}
+
+func appErrorCode(err error) errors.ErrorCode {
+	if appErr := errors.GetAppError(err); appErr != nil {
+		return appErr.Code
+	}
+	return ""
+}
+
+func TestCatalogEditor_UpdatesWithVersionCheckAndPublishes(t *testing.T) {
+	ctx := context.Background()
+	editor, service, dir := newTestEditor(t)
+
+	entry, err := editor.GetEntry(ctx, "test", "TST0001")
+	require.NoError(t, err)
+	assert.Equal(t, 1, entry.Version)
+	assert.Len(t, entry.Translations, 2)
+
+	// Warm the cache so publishing has something to replace
+	_, err = service.GetMessage(ctx, &MessageRequest{MessageCode: "TST0001", CatalogName: "test", Language: "en-US"})
+	require.NoError(t, err)
+
+	updated, err := editor.PutTranslation(ctx, "test", "TST0001", "en-US", &TranslationRequest{
+		Translation: Translation{Message: "Sign-in failed for {user}"},
+		Version:     1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Version)
+	assert.Equal(t, audit.SystemActor, updated.UpdatedBy)
+
+	message, err := service.GetMessage(ctx, &MessageRequest{MessageCode: "TST0001", CatalogName: "test", Language: "en-US",
+		Parameters: map[string]interface{}{"user": "ana"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Sign-in failed for ana", message.FormattedMessage)
+
+	// A writer still holding version 1 loses
+	_, err = editor.UpdateEntry(ctx, "test", "TST0001", &EntryRequest{Category: "Security", Severity: "LOW", Component: "Auth", Version: 1})
+	assert.Equal(t, errors.ErrCodeConflict, appErrorCode(err))
+
+	data, err := os.ReadFile(filepath.Join(dir, "messagecatelog-en-US.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Sign-in failed for {user}")
+	assert.FileExists(t, filepath.Join(dir, HistoryDir, "TST0001.json"))
+}
+
+func TestCatalogEditor_HistoryDiffAndRollback(t *testing.T) {
+	ctx := context.Background()
+	editor, _, _ := newTestEditor(t)
+
+	_, err := editor.UpdateEntry(ctx, "test", "TST0001", &EntryRequest{Category: "Security", Severity: "CRITICAL", Component: "Auth",
+		Translations: map[string]Translation{"de-DE": {Message: "Anmeldung für {user} fehlgeschlagen"}}, Version: 1})
+	require.NoError(t, err)
+
+	versions, err := editor.ListVersions(ctx, "test", "TST0001")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, VersionInitial, versions[0].Action)
+	assert.Equal(t, VersionUpdate, versions[1].Action)
+
+	diff, err := editor.DiffVersions(ctx, "test", "TST0001", 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, audit.FieldChange{Before: "HIGH", After: "CRITICAL"}, diff.Changes["severity"])
+	assert.Equal(t, audit.FieldChange{Before: nil, After: "Anmeldung für {user} fehlgeschlagen"}, diff.Changes["translations.de-DE.message"])
+	assert.Len(t, diff.Changes, 4)
+
+	restored, err := editor.Rollback(ctx, "test", "TST0001", &RollbackRequest{ToVersion: 1, Version: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, restored.Version)
+	assert.Equal(t, "HIGH", restored.Severity)
+	assert.NotContains(t, restored.Translations, "de-DE")
+
+	// Deleting keeps the history, so the message can be restored
+	require.NoError(t, editor.DeleteEntry(ctx, "test", "TST0001", 3))
+	_, err = editor.GetEntry(ctx, "test", "TST0001")
+	assert.Equal(t, errors.ErrCodeNotFound, appErrorCode(err))
+
+	restored, err = editor.Rollback(ctx, "test", "TST0001", &RollbackRequest{ToVersion: 2, Version: 0})
+	require.NoError(t, err)
+	assert.Equal(t, 5, restored.Version)
+	assert.Equal(t, "CRITICAL", restored.Severity)
+}
+
+func TestCatalogEditor_ValidatesEntries(t *testing.T) {
+	ctx := context.Background()
+	editor, _, _ := newTestEditor(t)
+
+	_, err := editor.CreateEntry(ctx, "test", "TST0002", &EntryRequest{Category: "user access", Severity: "URGENT", Component: "Auth",
+		Translations: map[string]Translation{
+			"en-US": {Message: "Locked {user}"},
+			"fr-FR": {Message: "Verrouillé {username"},
+		}})
+	require.Error(t, err)
+	appErr := errors.GetAppError(err)
+	assert.Equal(t, errors.ErrCodeBadRequest, appErr.Code)
+	problems := appErr.Fields["problems"].(map[string]string)
+	assert.Contains(t, problems, "severity")
+	assert.Contains(t, problems, "category")
+	assert.Contains(t, problems, "translations.fr-FR.message")
+
+	_, err = editor.PutTranslation(ctx, "test", "TST0001", "fr-FR", &TranslationRequest{
+		Translation: Translation{Message: "Échec pour {username}"}, Version: 1})
+	assert.Equal(t, errors.ErrCodeBadRequest, appErrorCode(err))
+
+	_, err = editor.DeleteTranslation(ctx, "test", "TST0001", "en-US", 1)
+	assert.Equal(t, errors.ErrCodeBadRequest, appErrorCode(err))
+
+	_, err = editor.CreateEntry(ctx, "test", "TST0001", &EntryRequest{Category: "Security", Severity: "LOW", Component: "Auth",
+		Translations: map[string]Translation{"en-US": {Message: "Again"}}})
+	assert.Equal(t, errors.ErrCodeConflict, appErrorCode(err))
+
+	_, err = editor.CreateEntry(ctx, "test", "../escape", &EntryRequest{})
+	assert.Equal(t, errors.ErrCodeBadRequest, appErrorCode(err))
+
+	_, err = editor.ListEntries(ctx, "builtin")
+	assert.Equal(t, errors.ErrCodeForbidden, appErrorCode(err))
+}
@@ -0,0 +1,83 @@
+package messagecatalog
+
+import (
+	"context"
+	"strings"
+
+	"tushartemplategin/pkg/interfaces"
+	"tushartemplategin/pkg/middleware"
+)
+
+// ErrorCatalogName is the catalog holding AppError texts, keyed by error code
+const ErrorCatalogName = "errors"
+
+// CatalogErrorLocalizer implements middleware.ErrorLocalizer with a message catalog
+type CatalogErrorLocalizer struct {
+	service     Service
+	catalogName string
+	logger      interfaces.Logger
+}
+
+// NewErrorLocalizer creates an error localizer reading error codes from catalogName
+func NewErrorLocalizer(service Service, catalogName string, log interfaces.Logger) middleware.ErrorLocalizer {
+	return &CatalogErrorLocalizer{
+		service:     service,
+		catalogName: catalogName,
+		logger:      log,
+	}
+}
+
+// LocalizeError renders an error code in the first accepted language the catalog
+// supports; codes missing from the catalog are left to the AppError message
+func (l *CatalogErrorLocalizer) LocalizeError(ctx context.Context, code string, parameters map[string]interface{}, languages []string) (*middleware.LocalizedError, bool) {
+	available, err := l.service.ListAvailableLanguages(ctx, l.catalogName)
+	if err != nil {
+		l.logger.Warn(ctx, "Error catalog unavailable, using untranslated error", interfaces.Fields{
+			"catalog_name": l.catalogName,
+			"error":        err.Error(),
+		})
+		return nil, false
+	}
+
+	response, err := l.service.GetMessage(ctx, &MessageRequest{
+		MessageCode: code,
+		CatalogName: l.catalogName,
+		Language:    negotiateLanguage(languages, available),
+		Parameters:  parameters,
+	})
+	if err != nil || response.Message == "" {
+		l.logger.Debug(ctx, "Error code not in error catalog", interfaces.Fields{
+			"catalog_name": l.catalogName,
+			"code":         code,
+		})
+		return nil, false
+	}
+
+	return &middleware.LocalizedError{
+		Message:             response.FormattedMessage,
+		DetailedDescription: response.DetailedDescription,
+		ResponseAction:      response.ResponseAction,
+		Language:            response.Language,
+	}, true
+}
+
+// negotiateLanguage returns the first accepted language the catalog can serve,
+// exactly or through a language with the same primary subtag; "" means the default
+func negotiateLanguage(accepted, available []string) string {
+	primaries := make(map[string]bool, len(available))
+	for _, language := range available {
+		primaries[primarySubtag(language)] = true
+	}
+
+	for _, language := range accepted {
+		if primaries[primarySubtag(language)] {
+			return language
+		}
+	}
+	return ""
+}
+
+// primarySubtag returns the lower-cased primary language subtag of a tag ("fr" for "fr-CA")
+func primarySubtag(tag string) string {
+	return strings.ToLower(strings.SplitN(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-", 2)[0])
+}
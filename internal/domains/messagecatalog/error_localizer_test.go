@@ -0,0 +1,76 @@
+package messagecatalog
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+)
+
+func newTestErrorLocalizer(t *testing.T) *CatalogErrorLocalizer {
+	logger := newQuietLogger(t)
+	catalogConfig := config.MessageCatalogConfig{
+		DefaultLanguage: "en-US",
+		CacheEnabled:    true,
+		Catalogs: []config.CatalogConfig{{
+			Name:                ErrorCatalogName,
+			Enabled:             true,
+			StructureFile:       "messagecatelog.json",
+			LanguageFilePattern: "messagecatelog-{lang}.json",
+			Source:              SourceEmbed,
+		}},
+	}
+	loader := NewEmbedLoader(catalogConfig.Catalogs, map[string]fs.FS{ErrorCatalogName: errors.CatalogFS()}, logger)
+	service := NewMessageCatalogService(catalogConfig, loader, NewLRUCacheManager(0, 0), logger)
+	return NewErrorLocalizer(service, ErrorCatalogName, logger).(*CatalogErrorLocalizer)
+}
+
+func TestErrorLocalizer_NegotiatesAcceptedLanguage(t *testing.T) {
+	localizer := newTestErrorLocalizer(t)
+
+	// de is not translated, so the next accepted language is used
+	localized, ok := localizer.LocalizeError(context.Background(), string(errors.ErrCodeProductNotFound), nil,
+		[]string{"de-DE", "fr-CA", "en"})
+	require.True(t, ok)
+	assert.Equal(t, "Produit introuvable", localized.Message)
+	assert.Equal(t, "fr-FR", localized.Language)
+
+	localized, ok = localizer.LocalizeError(context.Background(), string(errors.ErrCodeProductNotFound), nil, nil)
+	require.True(t, ok)
+	assert.Equal(t, "Product not found", localized.Message)
+	assert.Equal(t, "en-US", localized.Language)
+}
+
+func TestErrorLocalizer_UnknownCode(t *testing.T) {
+	localizer := newTestErrorLocalizer(t)
+
+	_, ok := localizer.LocalizeError(context.Background(), "NOT_A_CODE", nil, []string{"fr-FR"})
+	assert.False(t, ok)
+}
+
+func TestErrorCatalog_CoversEveryLanguage(t *testing.T) {
+	localizer := newTestErrorLocalizer(t)
+	structure, err := localizer.service.(*MessageCatalogService).loader.LoadCatalogStructure(context.Background(), ErrorCatalogName)
+	require.NoError(t, err)
+
+	for code := range structure {
+		for _, language := range []string{"en-US", "fr-FR"} {
+			response, err := localizer.service.GetMessageByCode(context.Background(), code, ErrorCatalogName, language)
+			require.NoError(t, err)
+			assert.Equal(t, language, response.Language, code)
+			assert.Nil(t, response.FallbackFields, code)
+		}
+	}
+}
+
+func TestNegotiateLanguage(t *testing.T) {
+	available := []string{"en-US", "fr-FR"}
+
+	assert.Equal(t, "fr-CA", negotiateLanguage([]string{"de", "fr-CA"}, available))
+	assert.Equal(t, "EN", negotiateLanguage([]string{"EN", "fr"}, available))
+	assert.Equal(t, "", negotiateLanguage([]string{"de"}, available))
+}
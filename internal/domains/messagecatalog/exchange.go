@@ -0,0 +1,247 @@
+package messagecatalog
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"golang.org/x/text/language"
+	"tushartemplategin/pkg/catalogexchange"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/interfaces"
+)
+
+// ExportTranslations builds a vendor document with every text of a catalog in
+// sourceLanguage (default_language when empty) and its targetLanguage translation
+// Write it with catalogexchange.Encode
+func (e *CatalogEditor) ExportTranslations(ctx context.Context, catalogName, sourceLanguage, targetLanguage string) (*catalogexchange.Document, error) {
+	if sourceLanguage == "" {
+		sourceLanguage = e.config.DefaultLanguage
+	}
+	if err := validateTargetLanguage(targetLanguage, sourceLanguage); err != nil {
+		return nil, err
+	}
+
+	entries, err := e.ListEntries(ctx, catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	document := &catalogexchange.Document{
+		Catalog:        catalogName,
+		SourceLanguage: sourceLanguage,
+		TargetLanguage: targetLanguage,
+	}
+	for _, entry := range entries {
+		source, exists := entry.Translations[sourceLanguage]
+		if !exists {
+			continue
+		}
+		target := entry.Translations[targetLanguage]
+		for _, field := range textFields {
+			if translationField(source, field) == "" {
+				continue
+			}
+			document.Units = append(document.Units, catalogexchange.Unit{
+				Code:    entry.MessageCode,
+				Field:   field,
+				Context: fmt.Sprintf("%s, %s, %s", entry.Category, entry.Severity, entry.Component),
+				Source:  translationField(source, field),
+				Target:  translationField(target, field),
+			})
+		}
+	}
+	return document, nil
+}
+
+// ImportTranslations saves the target texts of a vendor document into the
+// document's target language, one "import" version per changed message, and
+// publishes the catalog once
+// Untranslated units are skipped, fuzzy ones unless options.IncludeFuzzy, and units
+// whose placeholders differ from the default language text are rejected
+func (e *CatalogEditor) ImportTranslations(ctx context.Context, catalogName string, document *catalogexchange.Document, options ImportOptions) (*ImportReport, error) {
+	targetLanguage := document.TargetLanguage
+	if document.Catalog != "" && document.Catalog != catalogName {
+		return nil, errors.NewWithDetails(errors.ErrCodeBadRequest, "File is for another catalog",
+			fmt.Sprintf("The file holds catalog '%s', not '%s'", document.Catalog, catalogName), 400)
+	}
+	if err := validateTargetLanguage(targetLanguage, e.config.DefaultLanguage); err != nil {
+		return nil, err
+	}
+
+	store, err := e.storeFor(catalogName)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := store.ListEntries(ctx, catalogName)
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]*CatalogEntry, len(entries))
+	for _, entry := range entries {
+		byCode[entry.MessageCode] = entry
+	}
+
+	report := &ImportReport{CatalogName: catalogName, Language: targetLanguage, DryRun: options.DryRun, Updated: []string{}}
+	changes := make(map[string]map[string]string) // code -> field -> text
+	for _, unit := range document.Units {
+		id := unit.ID()
+		entry, exists := byCode[unit.Code]
+		switch {
+		case !exists:
+			report.Rejected = append(report.Rejected, ImportProblem{Unit: id, Reason: "unknown message code"})
+			continue
+		case !slices.Contains(textFields, unit.Field):
+			report.Rejected = append(report.Rejected, ImportProblem{Unit: id, Reason: "unknown field"})
+			continue
+		case unit.Target == "":
+			report.Untranslated = append(report.Untranslated, id)
+			continue
+		}
+		if unit.Fuzzy {
+			report.Fuzzy = append(report.Fuzzy, id)
+			if !options.IncludeFuzzy {
+				continue
+			}
+		}
+
+		source := translationField(entry.Translations[e.config.DefaultLanguage], unit.Field)
+		if unit.Source != "" && unit.Source != source {
+			report.Outdated = append(report.Outdated, id)
+		}
+		if reason := placeholderProblem(unit.Target, source); reason != "" {
+			report.Rejected = append(report.Rejected, ImportProblem{Unit: id, Reason: reason})
+			continue
+		}
+
+		if changes[unit.Code] == nil {
+			changes[unit.Code] = make(map[string]string)
+		}
+		changes[unit.Code][unit.Field] = unit.Target
+		report.Imported++
+	}
+
+	codes := make([]string, 0, len(changes))
+	for code := range changes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		// Skip messages the file does not change so re-imports add no versions
+		if !translationChanged(byCode[code].Translations[targetLanguage], changes[code]) {
+			continue
+		}
+		if options.DryRun {
+			report.Updated = append(report.Updated, code)
+			continue
+		}
+
+		_, err := e.save(ctx, store, catalogName, code, VersionImport, func(current *CatalogEntry) (*CatalogEntry, error) {
+			if current == nil {
+				return nil, messageNotFound(catalogName, code)
+			}
+			translation := current.Translations[targetLanguage]
+			for field, text := range changes[code] {
+				setTranslationField(&translation, field, text)
+			}
+			current.Translations[targetLanguage] = translation
+			return current, nil
+		})
+		if err != nil {
+			// A message that fails validation (or was deleted meanwhile) rejects its units; others still import
+			if appErr := errors.GetAppError(err); appErr != nil && appErr.HTTPStatus < 500 {
+				for field := range changes[code] {
+					report.Rejected = append(report.Rejected, ImportProblem{Unit: code + "." + field, Reason: appErr.Message})
+					report.Imported--
+				}
+				continue
+			}
+			return nil, err
+		}
+		report.Updated = append(report.Updated, code)
+	}
+
+	if len(report.Updated) > 0 && !options.DryRun {
+		e.publish(ctx, catalogName)
+	}
+
+	e.logger.Info(ctx, "Catalog translations imported", interfaces.Fields{
+		"catalog_name": catalogName,
+		"language":     targetLanguage,
+		"dry_run":      options.DryRun,
+		"imported":     report.Imported,
+		"updated":      len(report.Updated),
+		"untranslated": len(report.Untranslated),
+		"fuzzy":        len(report.Fuzzy),
+		"rejected":     len(report.Rejected),
+	})
+	return report, nil
+}
+
+// validateTargetLanguage requires a valid target language other than the source
+func validateTargetLanguage(targetLanguage, sourceLanguage string) error {
+	if _, err := language.Parse(targetLanguage); err != nil {
+		return errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid target language",
+			fmt.Sprintf("'%s' is not a valid language tag", targetLanguage), 400)
+	}
+	if targetLanguage == sourceLanguage {
+		return errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid target language",
+			fmt.Sprintf("The target language must differ from the source language '%s'", sourceLanguage), 400)
+	}
+	return nil
+}
+
+// placeholderProblem explains why a translated pattern does not match its source, or returns ""
+func placeholderProblem(target, source string) string {
+	parameters, err := patternParameters(target)
+	if err != nil {
+		return "invalid pattern: " + err.Error()
+	}
+	expected, err := patternParameters(source)
+	if err != nil {
+		// The source is reported by the linter; the translation cannot be checked against it
+		return ""
+	}
+	if missing, unknown := difference(expected, parameters), difference(parameters, expected); len(missing)+len(unknown) > 0 {
+		return fmt.Sprintf("placeholders differ from the source (missing %v, unknown %v)", missing, unknown)
+	}
+	return ""
+}
+
+// translationChanged reports whether applying texts changes a translation
+func translationChanged(translation Translation, texts map[string]string) bool {
+	for field, text := range texts {
+		if translationField(translation, field) != text {
+			return true
+		}
+	}
+	return false
+}
+
+// translationField returns one text field of a translation
+func translationField(translation Translation, field string) string {
+	switch field {
+	case fieldMessage:
+		return translation.Message
+	case fieldDetailedDescription:
+		return translation.DetailedDescription
+	case fieldResponseAction:
+		return translation.ResponseAction
+	default:
+		return ""
+	}
+}
+
+// setTranslationField sets one text field of a translation
+func setTranslationField(translation *Translation, field, text string) {
+	switch field {
+	case fieldMessage:
+		translation.Message = text
+	case fieldDetailedDescription:
+		translation.DetailedDescription = text
+	case fieldResponseAction:
+		translation.ResponseAction = text
+	}
+}
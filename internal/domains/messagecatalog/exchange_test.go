@@ -0,0 +1,99 @@
+package messagecatalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/pkg/catalogexchange"
+	"tushartemplategin/pkg/errors"
+)
+
+func TestCatalogEditor_ExportsAndImportsTranslations(t *testing.T) {
+	ctx := context.Background()
+	editor, service, _ := newTestEditor(t)
+
+	_, err := editor.CreateEntry(ctx, "test", "TST0002", &EntryRequest{Category: "Security", Severity: "LOW", Component: "Auth",
+		Translations: map[string]Translation{"en-US": {Message: "Logged out {user}", ResponseAction: "Sign in again"}}})
+	require.NoError(t, err)
+
+	document, err := editor.ExportTranslations(ctx, "test", "", "fr-FR")
+	require.NoError(t, err)
+	assert.Equal(t, "en-US", document.SourceLanguage)
+	assert.Equal(t, []catalogexchange.Unit{
+		{Code: "TST0001", Field: "message", Context: "Security, HIGH, Auth", Source: "Login failed for {user}", Target: "Échec de connexion pour {user}"},
+		{Code: "TST0002", Field: "message", Context: "Security, LOW, Auth", Source: "Logged out {user}"},
+		{Code: "TST0002", Field: "response_action", Context: "Security, LOW, Auth", Source: "Sign in again"},
+	}, document.Units)
+
+	// The translator fills in TST0002 with a broken placeholder and leaves one text fuzzy
+	document.Units[1].Target = "Déconnecté {name}"
+	document.Units[2].Target = "Reconnectez-vous"
+	document.Units[2].Fuzzy = true
+	document.Units = append(document.Units, catalogexchange.Unit{Code: "TST9999", Field: "message", Target: "Inconnu"})
+
+	report, err := editor.ImportTranslations(ctx, "test", document, ImportOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, []string{"TST0002.response_action"}, report.Fuzzy)
+	assert.Equal(t, []ImportProblem{
+		{Unit: "TST0002.message", Reason: "placeholders differ from the source (missing [user], unknown [name])"},
+		{Unit: "TST9999.message", Reason: "unknown message code"},
+	}, report.Rejected)
+	assert.Empty(t, report.Updated, "TST0001 is unchanged and TST0002 has nothing importable")
+
+	document.Units[1].Target = "Déconnecté {user}"
+	report, err = editor.ImportTranslations(ctx, "test", document, ImportOptions{IncludeFuzzy: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TST0002"}, report.Updated)
+	assert.Equal(t, 3, report.Imported, "unchanged TST0001 counts as imported")
+	assert.True(t, report.HasRejected())
+
+	entry, err := editor.GetEntry(ctx, "test", "TST0002")
+	require.NoError(t, err)
+	assert.Equal(t, 2, entry.Version)
+	assert.Equal(t, "Reconnectez-vous", entry.Translations["fr-FR"].ResponseAction)
+	versions, err := editor.ListVersions(ctx, "test", "TST0002")
+	require.NoError(t, err)
+	assert.Equal(t, VersionImport, versions[len(versions)-1].Action)
+
+	message, err := service.GetMessage(ctx, &MessageRequest{MessageCode: "TST0002", CatalogName: "test", Language: "fr-FR"})
+	require.NoError(t, err)
+	assert.Equal(t, "Reconnectez-vous", message.ResponseAction)
+
+	// Importing the same file again adds no version
+	report, err = editor.ImportTranslations(ctx, "test", document, ImportOptions{IncludeFuzzy: true})
+	require.NoError(t, err)
+	assert.Empty(t, report.Updated)
+	entry, err = editor.GetEntry(ctx, "test", "TST0002")
+	require.NoError(t, err)
+	assert.Equal(t, 2, entry.Version)
+}
+
+func TestCatalogEditor_ImportReportsOutdatedAndRejectsInvalidFiles(t *testing.T) {
+	ctx := context.Background()
+	editor, _, _ := newTestEditor(t)
+
+	report, err := editor.ImportTranslations(ctx, "test", &catalogexchange.Document{
+		Catalog:        "test",
+		TargetLanguage: "fr-FR",
+		Units: []catalogexchange.Unit{
+			{Code: "TST0001", Field: "message", Source: "Login rejected for {user}", Target: "Connexion rejetée pour {user}"},
+			{Code: "TST0001", Field: "detailed_description", Source: "Details"},
+		},
+	}, ImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TST0001.message"}, report.Outdated)
+	assert.Equal(t, []string{"TST0001.detailed_description"}, report.Untranslated)
+	assert.Equal(t, []string{"TST0001"}, report.Updated)
+
+	_, err = editor.ImportTranslations(ctx, "test", &catalogexchange.Document{Catalog: "alert", TargetLanguage: "fr-FR"}, ImportOptions{})
+	assert.Equal(t, errors.ErrCodeBadRequest, appErrorCode(err))
+
+	_, err = editor.ImportTranslations(ctx, "test", &catalogexchange.Document{TargetLanguage: "en-US"}, ImportOptions{})
+	assert.Equal(t, errors.ErrCodeBadRequest, appErrorCode(err))
+
+	_, err = editor.ExportTranslations(ctx, "builtin", "", "fr-FR")
+	assert.Equal(t, errors.ErrCodeForbidden, appErrorCode(err))
+}
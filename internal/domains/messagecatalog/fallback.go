@@ -0,0 +1,212 @@
+package messagecatalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+)
+
+// Translated message fields that fall back independently
+const (
+	fieldMessage             = "message"
+	fieldDetailedDescription = "detailed_description"
+	fieldResponseAction      = "response_action"
+)
+
+// availableLanguages caches the languages listed by the loader for one catalog
+type availableLanguages struct {
+	languages []string // Default language first
+	loadedAt  time.Time
+}
+
+// fallbackChain returns the languages to try for a requested BCP-47 tag, most specific first
+//
+// For "fr-CA" with fr-FR and en-US available the chain is fr-CA, fr, fr-FR, en-US:
+//  1. the requested tag and its truncations (RFC 4647 lookup), when available
+//  2. other available languages with the same primary language subtag, in order
+//  3. the default language, always last
+//
+// Tags are matched case-insensitively and returned in their available spelling.
+func fallbackChain(requested, defaultLanguage string, available []string) []string {
+	chain := []string{}
+	seen := make(map[string]bool)
+	add := func(language string) {
+		if !seen[strings.ToLower(language)] {
+			seen[strings.ToLower(language)] = true
+			chain = append(chain, language)
+		}
+	}
+
+	byLower := make(map[string]string, len(available))
+	for _, language := range available {
+		byLower[strings.ToLower(language)] = language
+	}
+
+	tag := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(requested), "_", "-"))
+	if tag != "" {
+		// Requested tag, then drop subtags from the end (and any singleton left behind)
+		for candidate := tag; candidate != ""; candidate = truncateTag(candidate) {
+			if language, ok := byLower[candidate]; ok {
+				add(language)
+			}
+		}
+
+		// Siblings sharing the primary subtag, e.g. fr-FR for fr-CA
+		primary := primarySubtag(tag)
+		for _, language := range available {
+			if primarySubtag(language) == primary {
+				add(language)
+			}
+		}
+	}
+
+	add(defaultLanguage)
+	return chain
+}
+
+// truncateTag removes the last subtag of a lower-cased tag, "" once nothing is left
+func truncateTag(tag string) string {
+	index := strings.LastIndex(tag, "-")
+	if index < 0 {
+		return ""
+	}
+	tag = tag[:index]
+	// A single-character subtag introduces an extension and cannot end a tag
+	if index = strings.LastIndex(tag, "-"); index >= 0 && len(tag)-index-1 == 1 {
+		tag = tag[:index]
+	}
+	return tag
+}
+
+// languageChain returns the fallback chain for a catalog and requested language
+func (s *MessageCatalogService) languageChain(ctx context.Context, catalogConfig *config.CatalogConfig, requested string) []string {
+	if requested == "" || strings.EqualFold(requested, s.config.DefaultLanguage) {
+		return []string{s.config.DefaultLanguage}
+	}
+	return fallbackChain(requested, s.config.DefaultLanguage, s.catalogLanguages(ctx, catalogConfig))
+}
+
+// catalogLanguages returns the available languages of a catalog, cached for CacheTTL
+func (s *MessageCatalogService) catalogLanguages(ctx context.Context, catalogConfig *config.CatalogConfig) []string {
+	s.reloadMutex.RLock()
+	cached := s.languages[catalogConfig.Name]
+	s.reloadMutex.RUnlock()
+
+	if cached != nil && !s.isExpired(cached.loadedAt) {
+		return cached.languages
+	}
+
+	languages, _ := s.discoverAvailableLanguages(ctx, catalogConfig)
+
+	s.reloadMutex.Lock()
+	s.languages[catalogConfig.Name] = &availableLanguages{languages: languages, loadedAt: time.Now()}
+	s.reloadMutex.Unlock()
+
+	return languages
+}
+
+// forgetCatalogLanguages drops the cached language list of a catalog
+func (s *MessageCatalogService) forgetCatalogLanguages(catalogName string) {
+	s.reloadMutex.Lock()
+	defer s.reloadMutex.Unlock()
+	delete(s.languages, catalogName)
+}
+
+// resolveMessage looks a message up along a language chain
+// Each translated field comes from the first language in the chain that has it;
+// the returned map records which language supplied each field
+func (s *MessageCatalogService) resolveMessage(ctx context.Context, catalogName, messageCode string, chain []string) (*Message, map[string]string, error) {
+	var resolved *Message
+	var lastErr error
+	fieldLanguages := make(map[string]string, 3)
+
+	for _, language := range chain {
+		message, err := s.lookupMessage(ctx, catalogName, messageCode, language)
+		if err != nil {
+			// Unknown catalogs and messages fail on any language; other languages may still work
+			if appErr := errors.GetAppError(err); resolved == nil && appErr != nil && appErr.Code == errors.ErrCodeNotFound {
+				return nil, nil, err
+			}
+			lastErr = err
+			continue
+		}
+
+		if resolved == nil {
+			copied := *message
+			resolved = &copied
+			resolved.Message, resolved.DetailedDescription, resolved.ResponseAction = "", "", ""
+		}
+
+		fillField(&resolved.Message, message.Message, fieldMessage, language, fieldLanguages)
+		fillField(&resolved.DetailedDescription, message.DetailedDescription, fieldDetailedDescription, language, fieldLanguages)
+		fillField(&resolved.ResponseAction, message.ResponseAction, fieldResponseAction, language, fieldLanguages)
+
+		if len(fieldLanguages) == 3 {
+			break
+		}
+	}
+
+	if resolved == nil {
+		return nil, nil, lastErr
+	}
+
+	// The language used is the one that supplied the message text
+	resolved.Language = chain[len(chain)-1]
+	if language, ok := fieldLanguages[fieldMessage]; ok {
+		resolved.Language = language
+	}
+
+	return resolved, fieldLanguages, nil
+}
+
+// fillField sets an empty field from a fallback language
+func fillField(target *string, value, field, language string, fieldLanguages map[string]string) {
+	if *target == "" && value != "" {
+		*target = value
+		fieldLanguages[field] = language
+	}
+}
+
+// lookupMessage returns one message in exactly one language, from the cache when enabled
+// A cached language is authoritative: a code it lacks is not found without
+// reading the catalog files again
+func (s *MessageCatalogService) lookupMessage(ctx context.Context, catalogName, messageCode, language string) (*Message, error) {
+	if !s.config.CacheEnabled {
+		return s.loadMessage(ctx, catalogName, messageCode, language)
+	}
+
+	entry := s.currentLanguage(ctx, catalogName, language)
+	if entry == nil {
+		if s.findCatalogConfig(catalogName) == nil {
+			return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog not found",
+				fmt.Sprintf("Catalog '%s' not found in configuration", catalogName), 404)
+		}
+		return nil, errors.NewWithDetails(errors.ErrCodeServiceUnavailable, "Language not available",
+			fmt.Sprintf("Language '%s' of catalog '%s' could not be loaded", language, catalogName), 503)
+	}
+
+	message, found := entry.messages[messageCode]
+	if !found {
+		return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Message not found",
+			fmt.Sprintf("Message '%s' not found in catalog '%s'", messageCode, catalogName), 404)
+	}
+	return message, nil
+}
+
+// fallbackFields returns the fields served from another language than the response language
+func fallbackFields(language string, fieldLanguages map[string]string) map[string]string {
+	var fields map[string]string
+	for field, fieldLanguage := range fieldLanguages {
+		if fieldLanguage != language {
+			if fields == nil {
+				fields = make(map[string]string)
+			}
+			fields[field] = fieldLanguage
+		}
+	}
+	return fields
+}
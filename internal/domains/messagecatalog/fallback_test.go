@@ -0,0 +1,121 @@
+package messagecatalog
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+)
+
+func TestFallbackChain(t *testing.T) {
+	available := []string{"en-US", "fr", "fr-FR", "pt-BR", "zh-Hant"}
+
+	tests := []struct {
+		requested string
+		expected  []string
+	}{
+		{"fr-CA", []string{"fr", "fr-FR", "en-US"}},
+		{"fr-fr", []string{"fr-FR", "fr", "en-US"}},
+		{"pt", []string{"pt-BR", "en-US"}},
+		{"zh-Hant-TW", []string{"zh-Hant", "en-US"}},
+		{"fr-CA-x-private", []string{"fr", "fr-FR", "en-US"}},
+		{"de-DE", []string{"en-US"}},
+		{"", []string{"en-US"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.requested, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fallbackChain(tt.requested, "en-US", available))
+		})
+	}
+}
+
+func TestGetMessage_FallsBackPerField(t *testing.T) {
+	files := fstest.MapFS{
+		"messagecatelog.json": {Data: []byte(testStructure)},
+		"messagecatelog-en-US.json": {Data: []byte(
+			`{"TST0001": {"message": "hello", "detailed_description": "details", "response_action": "retry"}}`)},
+		// The French translation is missing detailed_description and response_action
+		"messagecatelog-fr-FR.json": {Data: []byte(`{"TST0001": {"message": "bonjour"}}`)},
+	}
+	logger := newQuietLogger(t)
+	loader := NewEmbedLoader(testCatalogs, map[string]fs.FS{"test": files}, logger)
+	service := NewMessageCatalogService(config.MessageCatalogConfig{
+		DefaultLanguage: "en-US",
+		CacheEnabled:    true,
+		Catalogs:        testCatalogs,
+	}, loader, NewLRUCacheManager(0, 0), logger)
+
+	response, err := service.GetMessage(context.Background(), &MessageRequest{
+		MessageCode: "TST0001",
+		CatalogName: "test",
+		Language:    "fr-CA",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "bonjour", response.Message)
+	assert.Equal(t, "details", response.DetailedDescription)
+	assert.Equal(t, "retry", response.ResponseAction)
+	assert.Equal(t, "fr-FR", response.Language)
+	assert.Equal(t, "fr-CA", response.RequestedLanguage)
+	assert.Equal(t, map[string]string{
+		"detailed_description": "en-US",
+		"response_action":      "en-US",
+	}, response.FallbackFields)
+
+	// Unknown languages are served in the default language
+	response, err = service.GetMessageByCode(context.Background(), "TST0001", "test", "de-DE")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", response.Message)
+	assert.Equal(t, "en-US", response.Language)
+	assert.Nil(t, response.FallbackFields)
+}
+
+func TestGetMessage_UnknownCodeInCachedLanguageDoesNotReadFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "hello")
+	service := newTestService(t, dir, 60)
+	loader := &countingLoader{CatalogLoader: service.loader}
+	service.loader = loader
+
+	_, err := service.GetMessageByCode(context.Background(), "TST9999", "test", "")
+
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrCodeNotFound, errors.GetAppError(err).Code)
+	assert.Zero(t, loader.structureLoads)
+}
+
+func TestGetMessagesByCategoryAndSeverity_ServedFromCache(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "hello")
+	service := newTestService(t, dir, 60)
+	loader := &countingLoader{CatalogLoader: service.loader}
+	service.loader = loader
+	ctx := context.Background()
+
+	byCategory, err := service.GetMessagesByCategory(ctx, "Test", "test", "")
+	require.NoError(t, err)
+	bySeverity, err := service.GetMessagesBySeverity(ctx, "LOW", "test", "")
+	require.NoError(t, err)
+	none, err := service.GetMessagesBySeverity(ctx, "HIGH", "test", "")
+	require.NoError(t, err)
+
+	require.Len(t, byCategory, 1)
+	assert.Equal(t, "hello", byCategory[0].Message)
+	require.Len(t, bySeverity, 1)
+	assert.Equal(t, "TST0001", bySeverity[0].MessageCode)
+	assert.Empty(t, none)
+	assert.Zero(t, loader.structureLoads)
+
+	// Without caching the structure file is read for every request
+	service.config.CacheEnabled = false
+	byCategory, err = service.GetMessagesByCategory(ctx, "Test", "test", "")
+	require.NoError(t, err)
+	require.Len(t, byCategory, 1)
+	assert.Equal(t, "hello", byCategory[0].Message)
+}
@@ -1,6 +1,10 @@
 package messagecatalog
 
-import "context"
+import (
+	"context"
+
+	"tushartemplategin/pkg/catalogexchange"
+)
 
 // Service defines the interface for message catalog operations
 type Service interface {
@@ -9,20 +13,35 @@ type Service interface {
 	GetMessageByCode(ctx context.Context, messageCode, catalogName, language string) (*MessageResponse, error)
 	GetMessagesByCategory(ctx context.Context, category, catalogName, language string) ([]*MessageResponse, error)
 	GetMessagesBySeverity(ctx context.Context, severity, catalogName, language string) ([]*MessageResponse, error)
+	RenderMessages(ctx context.Context, req *BatchRenderRequest) (*BatchRenderResponse, error)
+	SearchMessages(ctx context.Context, req *SearchRequest) (*SearchResponse, error)
 
 	// Catalog management
 	ReloadCatalog(ctx context.Context, catalogName string) error
 	ReloadAllCatalogs(ctx context.Context) error
 	HealthCheck(ctx context.Context) error
 
+	// Background reload (ReloadInterval)
+	StartAutoReload(ctx context.Context)
+	StopAutoReload()
+
+	// File watching (WatchEnabled)
+	StartWatching(ctx context.Context) error
+	StopWatching()
+
 	// Catalog information
 	ListAvailableCatalogs(ctx context.Context) ([]string, error)
 	ListAvailableLanguages(ctx context.Context, catalogName string) ([]string, error)
 	GetCatalogInfo(ctx context.Context, catalogName string) (*CatalogInfo, error)
 	GetCatalogStats(ctx context.Context) (*CatalogStats, error)
+
+	// Consistency checks (ValidationMode)
+	ValidateCatalogs(ctx context.Context) (*LintReport, error)
 }
 
 // CatalogLoader defines interface for loading catalog data
+// Structure and language data use the JSON file layout (messageCode -> fields);
+// a missing catalog or language is reported as an ErrCodeNotFound AppError
 type CatalogLoader interface {
 	LoadCatalogStructure(ctx context.Context, catalogName string) (map[string]interface{}, error)
 	LoadLanguageFile(ctx context.Context, catalogName, language string) (map[string]interface{}, error)
@@ -30,10 +49,50 @@ type CatalogLoader interface {
 }
 
 // CacheManager defines interface for caching operations
+// ttl is in seconds; 0 keeps the entry until it is deleted or evicted
 type CacheManager interface {
 	Get(ctx context.Context, key string) (interface{}, bool)
 	Set(ctx context.Context, key string, value interface{}, ttl int) error
 	Delete(ctx context.Context, key string) error
 	Clear(ctx context.Context) error
+	Keys(ctx context.Context) []string
 	GetStats(ctx context.Context) map[string]interface{}
 }
+
+// Editor defines catalog management operations
+// Writes take the version the caller last read and fail with 409 Conflict when
+// the message changed since; every write is recorded and then published with ReloadCatalog
+type Editor interface {
+	ListEntries(ctx context.Context, catalogName string) ([]*CatalogEntry, error)
+	GetEntry(ctx context.Context, catalogName, messageCode string) (*CatalogEntry, error)
+	CreateEntry(ctx context.Context, catalogName, messageCode string, req *EntryRequest) (*CatalogEntry, error)
+	UpdateEntry(ctx context.Context, catalogName, messageCode string, req *EntryRequest) (*CatalogEntry, error)
+	DeleteEntry(ctx context.Context, catalogName, messageCode string, version int) error
+	PutTranslation(ctx context.Context, catalogName, messageCode, language string, req *TranslationRequest) (*CatalogEntry, error)
+	DeleteTranslation(ctx context.Context, catalogName, messageCode, language string, version int) (*CatalogEntry, error)
+
+	// History
+	ListVersions(ctx context.Context, catalogName, messageCode string) ([]*EntryVersion, error)
+	GetVersion(ctx context.Context, catalogName, messageCode string, version int) (*EntryVersion, error)
+	DiffVersions(ctx context.Context, catalogName, messageCode string, from, to int) (*EntryDiff, error)
+	Rollback(ctx context.Context, catalogName, messageCode string, req *RollbackRequest) (*CatalogEntry, error)
+
+	// Translation vendor files (XLIFF, PO, CSV)
+	ExportTranslations(ctx context.Context, catalogName, sourceLanguage, targetLanguage string) (*catalogexchange.Document, error)
+	ImportTranslations(ctx context.Context, catalogName string, document *catalogexchange.Document, options ImportOptions) (*ImportReport, error)
+}
+
+// EntryUpdate computes the versions to record for one message
+// current is nil when the message does not exist; lastVersion is the highest
+// recorded version (0 without history). The last returned version is the new state.
+type EntryUpdate func(current *CatalogEntry, lastVersion int) ([]*EntryVersion, error)
+
+// CatalogStore persists catalog entries and their history for one catalog source
+// GetEntry returns nil when the message does not exist; errors returned by an
+// EntryUpdate are passed through unchanged
+type CatalogStore interface {
+	ListEntries(ctx context.Context, catalogName string) ([]*CatalogEntry, error)
+	GetEntry(ctx context.Context, catalogName, messageCode string) (*CatalogEntry, error)
+	ListVersions(ctx context.Context, catalogName, messageCode string) ([]*EntryVersion, error)
+	UpdateEntry(ctx context.Context, catalogName, messageCode string, update EntryUpdate) (*CatalogEntry, error)
+}
@@ -0,0 +1,419 @@
+package messagecatalog
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/interfaces"
+	"tushartemplategin/pkg/messageformat"
+)
+
+// Startup validation modes (message_catalog.validation_mode)
+const (
+	ValidationOff    = "off"    // Do not validate catalogs at startup
+	ValidationWarn   = "warn"   // Log lint issues and keep serving
+	ValidationStrict = "strict" // Refuse to start when a lint error is found
+)
+
+// Lint issue levels
+const (
+	LintError   = "error"   // Breaks lookups or rendering
+	LintWarning = "warning" // Served through fallback, but should be fixed
+)
+
+// Lint issue kinds
+const (
+	IssueLoadFailed          = "load_failed"          // A structure or language file cannot be read or parsed
+	IssueSchemaViolation     = "schema_violation"     // Wrong type, missing required field or unknown field
+	IssueMissingTranslation  = "missing_translation"  // Code in the structure file without a translation
+	IssueExtraCode           = "extra_code"           // Translation of a code the structure file does not define
+	IssueInvalidSeverity     = "invalid_severity"     // Severity outside KnownSeverities
+	IssueInvalidCategory     = "invalid_category"     // Category that is not a PascalCase identifier
+	IssueInvalidPattern      = "invalid_pattern"      // Text that is not valid ICU MessageFormat
+	IssuePlaceholderMismatch = "placeholder_mismatch" // Placeholders differ from the default language
+)
+
+// KnownSeverities are the severities a catalog message may declare
+var KnownSeverities = []string{"LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
+// categoryPattern is the accepted form of a category ("Security", "UserAccess")
+var categoryPattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// Fields of the structure and language files
+var (
+	structureFields = []string{"message_code", "category", "severity", "component"}
+	textFields      = []string{"message", "detailed_description", "response_action"}
+)
+
+// LintIssue is one problem found in a catalog
+type LintIssue struct {
+	Catalog     string `json:"catalog"`
+	Language    string `json:"language,omitempty"` // Empty for the structure file
+	MessageCode string `json:"message_code,omitempty"`
+	Field       string `json:"field,omitempty"`
+	Kind        string `json:"kind"`
+	Level       string `json:"level"`
+	Message     string `json:"message"`
+}
+
+// String formats an issue as "catalog/language CODE field: [level] kind: message"
+func (i LintIssue) String() string {
+	location := i.Catalog
+	if i.Language != "" {
+		location += "/" + i.Language
+	}
+	if i.MessageCode != "" {
+		location += " " + i.MessageCode
+	}
+	if i.Field != "" {
+		location += " " + i.Field
+	}
+	return fmt.Sprintf("%s: [%s] %s: %s", location, i.Level, i.Kind, i.Message)
+}
+
+// LintReport collects the issues of one or more catalogs
+type LintReport struct {
+	Catalogs []string    `json:"catalogs"`
+	Issues   []LintIssue `json:"issues"`
+	Errors   int         `json:"errors"`
+	Warnings int         `json:"warnings"`
+}
+
+// HasErrors reports whether any issue is an error
+func (r *LintReport) HasErrors() bool {
+	return r.Errors > 0
+}
+
+// Linter checks catalogs read through a CatalogLoader for consistency
+type Linter struct {
+	loader          CatalogLoader
+	defaultLanguage string
+}
+
+// NewLinter creates a linter comparing translations against defaultLanguage
+func NewLinter(loader CatalogLoader, defaultLanguage string) *Linter {
+	return &Linter{loader: loader, defaultLanguage: defaultLanguage}
+}
+
+// Lint checks the named catalogs and returns every issue found, sorted by location
+func (l *Linter) Lint(ctx context.Context, catalogNames []string) *LintReport {
+	report := &LintReport{Catalogs: catalogNames, Issues: []LintIssue{}}
+	for _, name := range catalogNames {
+		(&catalogLint{linter: l, catalog: name, report: report}).run(ctx)
+	}
+
+	sort.SliceStable(report.Issues, func(a, b int) bool {
+		x, y := report.Issues[a], report.Issues[b]
+		if x.Catalog != y.Catalog {
+			return x.Catalog < y.Catalog
+		}
+		if x.Language != y.Language {
+			return x.Language < y.Language
+		}
+		return x.MessageCode < y.MessageCode
+	})
+	return report
+}
+
+// catalogLint holds the state of linting one catalog
+type catalogLint struct {
+	linter  *Linter
+	catalog string
+	report  *LintReport
+}
+
+// run lints the structure file, then every language against the default one
+func (c *catalogLint) run(ctx context.Context) {
+	structure, err := c.linter.loader.LoadCatalogStructure(ctx, c.catalog)
+	if err != nil {
+		c.add("", "", "", IssueLoadFailed, LintError, loadErrorMessage(err))
+		return
+	}
+	c.lintStructure(structure)
+
+	languages, err := c.linter.loader.ListAvailableLanguages(ctx, c.catalog)
+	if err != nil {
+		c.add("", "", "", IssueLoadFailed, LintError, loadErrorMessage(err))
+		return
+	}
+	if !containsFold(languages, c.linter.defaultLanguage) {
+		c.add(c.linter.defaultLanguage, "", "", IssueLoadFailed, LintError,
+			"default language file not found; messages have no fallback text")
+	}
+
+	// The default language is the reference for placeholders
+	texts := make(map[string]map[string]map[string][]string, len(languages))
+	for _, language := range languages {
+		data, err := c.linter.loader.LoadLanguageFile(ctx, c.catalog, language)
+		if err != nil {
+			c.add(language, "", "", IssueLoadFailed, LintError, loadErrorMessage(err))
+			continue
+		}
+		texts[language] = c.lintLanguage(language, structure, data)
+	}
+
+	var reference map[string]map[string][]string
+	for language, messages := range texts {
+		if strings.EqualFold(language, c.linter.defaultLanguage) {
+			reference = messages
+		}
+	}
+	for language, messages := range texts {
+		if !strings.EqualFold(language, c.linter.defaultLanguage) && reference != nil {
+			c.comparePlaceholders(language, reference, messages)
+		}
+	}
+}
+
+// lintStructure validates the structure file entries
+func (c *catalogLint) lintStructure(structure map[string]interface{}) {
+	for _, code := range sortedKeys(structure) {
+		entry, ok := structure[code].(map[string]interface{})
+		if !ok {
+			c.add("", code, "", IssueSchemaViolation, LintError, "entry must be a JSON object")
+			continue
+		}
+
+		for _, field := range structureFields {
+			value, exists := entry[field]
+			text, isString := value.(string)
+			switch {
+			case !exists || (isString && strings.TrimSpace(text) == ""):
+				c.add("", code, field, IssueSchemaViolation, LintError, "required field is missing or empty")
+			case !isString:
+				c.add("", code, field, IssueSchemaViolation, LintError, "field must be a string")
+			}
+		}
+		c.unknownFields("", code, entry, structureFields)
+
+		if messageCode := getString(entry, "message_code"); messageCode != "" && messageCode != code {
+			c.add("", code, "message_code", IssueSchemaViolation, LintError,
+				fmt.Sprintf("message_code %q does not match its key", messageCode))
+		}
+		if severity := getString(entry, "severity"); severity != "" && !slices.Contains(KnownSeverities, severity) {
+			c.add("", code, "severity", IssueInvalidSeverity, LintError,
+				fmt.Sprintf("severity %q is not one of %s", severity, strings.Join(KnownSeverities, ", ")))
+		}
+		if category := getString(entry, "category"); category != "" && !categoryPattern.MatchString(category) {
+			c.add("", code, "category", IssueInvalidCategory, LintError,
+				fmt.Sprintf("category %q must be a PascalCase identifier", category))
+		}
+	}
+}
+
+// lintLanguage validates one language file and returns its parameters per code and field
+func (c *catalogLint) lintLanguage(language string, structure, data map[string]interface{}) map[string]map[string][]string {
+	parameters := make(map[string]map[string][]string)
+
+	for _, code := range sortedKeys(structure) {
+		if _, exists := data[code]; !exists {
+			level := LintWarning
+			if strings.EqualFold(language, c.linter.defaultLanguage) {
+				level = LintError
+			}
+			c.add(language, code, "", IssueMissingTranslation, level, "no translation for this code")
+		}
+	}
+
+	for _, code := range sortedKeys(data) {
+		if _, exists := structure[code]; !exists {
+			c.add(language, code, "", IssueExtraCode, LintWarning, "code is not defined in the structure file")
+		}
+
+		entry, ok := data[code].(map[string]interface{})
+		if !ok {
+			c.add(language, code, "", IssueSchemaViolation, LintError, "entry must be a JSON object")
+			continue
+		}
+		c.unknownFields(language, code, entry, textFields)
+
+		fields := make(map[string][]string)
+		for _, field := range textFields {
+			value, exists := entry[field]
+			if !exists {
+				if field == "message" {
+					c.add(language, code, field, IssueSchemaViolation, LintError, "required field is missing")
+				}
+				continue
+			}
+			text, isString := value.(string)
+			if !isString {
+				c.add(language, code, field, IssueSchemaViolation, LintError, "field must be a string")
+				continue
+			}
+
+			pattern, err := messageformat.Parse(text)
+			if err != nil {
+				c.add(language, code, field, IssueInvalidPattern, LintError, err.Error())
+				continue
+			}
+			fields[field] = pattern.Parameters()
+		}
+		parameters[code] = fields
+	}
+	return parameters
+}
+
+// comparePlaceholders reports texts whose placeholders differ from the default language
+func (c *catalogLint) comparePlaceholders(language string, reference, messages map[string]map[string][]string) {
+	codes := make([]string, 0, len(messages))
+	for code := range messages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		expected, exists := reference[code]
+		if !exists {
+			continue
+		}
+		for _, field := range textFields {
+			want, hasWant := expected[field]
+			got, hasGot := messages[code][field]
+			if !hasWant || !hasGot {
+				continue
+			}
+			missing, extra := difference(want, got), difference(got, want)
+			if len(missing) == 0 && len(extra) == 0 {
+				continue
+			}
+
+			var parts []string
+			if len(missing) > 0 {
+				parts = append(parts, "missing {"+strings.Join(missing, "}, {")+"}")
+			}
+			if len(extra) > 0 {
+				parts = append(parts, "unknown {"+strings.Join(extra, "}, {")+"}")
+			}
+			c.add(language, code, field, IssuePlaceholderMismatch, LintError,
+				fmt.Sprintf("%s compared to %s", strings.Join(parts, "; "), c.linter.defaultLanguage))
+		}
+	}
+}
+
+// unknownFields warns about fields the service ignores (usually typos)
+func (c *catalogLint) unknownFields(language, code string, entry map[string]interface{}, known []string) {
+	for _, field := range sortedKeys(entry) {
+		if !slices.Contains(known, field) {
+			c.add(language, code, field, IssueSchemaViolation, LintWarning, "unknown field is ignored")
+		}
+	}
+}
+
+func (c *catalogLint) add(language, code, field, kind, level, message string) {
+	c.report.Issues = append(c.report.Issues, LintIssue{
+		Catalog:     c.catalog,
+		Language:    language,
+		MessageCode: code,
+		Field:       field,
+		Kind:        kind,
+		Level:       level,
+		Message:     message,
+	})
+	if level == LintError {
+		c.report.Errors++
+	} else {
+		c.report.Warnings++
+	}
+}
+
+// ValidateCatalogs lints every enabled catalog through the service's loader and
+// logs each issue; in strict validation mode lint errors are returned as an error
+func (s *MessageCatalogService) ValidateCatalogs(ctx context.Context) (*LintReport, error) {
+	var names []string
+	for _, catalog := range s.config.Catalogs {
+		if catalog.Enabled {
+			names = append(names, catalog.Name)
+		}
+	}
+
+	report := NewLinter(s.loader, s.config.DefaultLanguage).Lint(ctx, names)
+	for _, issue := range report.Issues {
+		fields := interfaces.Fields{
+			"catalog_name": issue.Catalog,
+			"language":     issue.Language,
+			"message_code": issue.MessageCode,
+			"field":        issue.Field,
+			"kind":         issue.Kind,
+			"issue":        issue.Message,
+		}
+		if issue.Level == LintError {
+			s.logger.Error(ctx, "Message catalog validation error", fields)
+		} else {
+			s.logger.Warn(ctx, "Message catalog validation warning", fields)
+		}
+	}
+
+	s.logger.Info(ctx, "Message catalogs validated", interfaces.Fields{
+		"catalogs": len(names),
+		"errors":   report.Errors,
+		"warnings": report.Warnings,
+	})
+
+	if report.HasErrors() && ValidationMode(&s.config) == ValidationStrict {
+		return report, errors.NewWithDetails(errors.ErrCodeInternalServer, "Message catalogs failed validation",
+			fmt.Sprintf("%d errors and %d warnings in message catalogs", report.Errors, report.Warnings), 500).
+			WithField("errors", report.Errors)
+	}
+	return report, nil
+}
+
+// ValidationMode returns the configured startup validation mode, off by default
+func ValidationMode(cfg *config.MessageCatalogConfig) string {
+	switch strings.ToLower(cfg.ValidationMode) {
+	case ValidationWarn:
+		return ValidationWarn
+	case ValidationStrict:
+		return ValidationStrict
+	default:
+		return ValidationOff
+	}
+}
+
+// loadErrorMessage prefers the details of a loader AppError
+func loadErrorMessage(err error) string {
+	if appErr := errors.GetAppError(err); appErr != nil && appErr.Details != "" {
+		return appErr.Details
+	}
+	return err.Error()
+}
+
+func sortedKeys(data map[string]interface{}) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// difference returns the values of a that are not in b
+func difference(a, b []string) []string {
+	present := make(map[string]bool, len(b))
+	for _, value := range b {
+		present[value] = true
+	}
+
+	var result []string
+	for _, value := range a {
+		if !present[value] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package messagecatalog
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+)
+
+const lintStructure = `{
+  "TST0001": {"message_code": "TST0001", "category": "Security", "severity": "HIGH", "component": "Auth"},
+  "TST0002": {"message_code": "TST9999", "category": "user access", "severity": "URGENT", "component": "Auth", "owner": "team"},
+  "TST0003": {"message_code": "TST0003", "category": "Security", "severity": "LOW"}
+}`
+
+func newLintLoader(t *testing.T, files fstest.MapFS) CatalogLoader {
+	files["messagecatelog.json"] = &fstest.MapFile{Data: []byte(lintStructure)}
+	return NewEmbedLoader(testCatalogs, map[string]fs.FS{"test": files}, newQuietLogger(t))
+}
+
+// issueKeys summarizes issues as "language code field kind level"
+func issueKeys(report *LintReport) []string {
+	keys := make([]string, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		keys = append(keys, issue.Language+" "+issue.MessageCode+" "+issue.Field+" "+issue.Kind+" "+issue.Level)
+	}
+	return keys
+}
+
+func TestLinter_ReportsCatalogIssues(t *testing.T) {
+	loader := newLintLoader(t, fstest.MapFS{
+		"messagecatelog-en-US.json": {Data: []byte(`{
+			"TST0001": {"message": "{count, plural, one {# attempt} other {# attempts}} by {user}"},
+			"TST0002": {"message": "Broken {user", "detailed_description": 42},
+			"TST0003": {"message": "Done"}
+		}`)},
+		"messagecatelog-fr-FR.json": {Data: []byte(`{
+			"TST0001": {"message": "{count, plural, one {# tentative} other {# tentatives}} par {username}", "mesage": "typo"},
+			"TST0004": {"message": "En trop"}
+		}`)},
+	})
+
+	report := NewLinter(loader, "en-US").Lint(context.Background(), []string{"test"})
+
+	assert.ElementsMatch(t, []string{
+		" TST0002 message_code schema_violation error",
+		" TST0002 owner schema_violation warning",
+		" TST0002 severity invalid_severity error",
+		" TST0002 category invalid_category error",
+		" TST0003 component schema_violation error",
+		"en-US TST0002 message invalid_pattern error",
+		"en-US TST0002 detailed_description schema_violation error",
+		"fr-FR TST0001 mesage schema_violation warning",
+		"fr-FR TST0001 message placeholder_mismatch error",
+		"fr-FR TST0002  missing_translation warning",
+		"fr-FR TST0003  missing_translation warning",
+		"fr-FR TST0004  extra_code warning",
+	}, issueKeys(report))
+	assert.Equal(t, 7, report.Errors)
+	assert.Equal(t, 5, report.Warnings)
+	assert.True(t, report.HasErrors())
+
+	for _, issue := range report.Issues {
+		if issue.Kind == IssuePlaceholderMismatch {
+			assert.Equal(t, "missing {user}; unknown {username} compared to en-US", issue.Message)
+		}
+	}
+}
+
+func TestLinter_MissingDefaultLanguage(t *testing.T) {
+	loader := newLintLoader(t, fstest.MapFS{
+		"messagecatelog-fr-FR.json": {Data: []byte(`{"TST0001": {"message": "a"}, "TST0002": {"message": "b"}, "TST0003": {"message": "c"}}`)},
+	})
+
+	report := NewLinter(loader, "en-US").Lint(context.Background(), []string{"test", "unknown"})
+
+	assert.Contains(t, issueKeys(report), "en-US   load_failed error")
+	assert.Contains(t, issueKeys(report), "   load_failed error")
+	assert.Equal(t, []string{"test", "unknown"}, report.Catalogs)
+}
+
+func TestLinter_ShippedCatalogsAreClean(t *testing.T) {
+	catalogs := []config.CatalogConfig{
+		{Name: "alert", Path: "../../../pkg/alert/catalog", Enabled: true, StructureFile: "messagecatelog.json", LanguageFilePattern: "messagecatelog-{lang}.json"},
+		{Name: "audit", Path: "../../../pkg/audit/catalog", Enabled: true, StructureFile: "messagecatelog.json", LanguageFilePattern: "messagecatelog-{lang}.json"},
+		{Name: ErrorCatalogName, Path: "../../../pkg/errors/catalog", Enabled: true, StructureFile: "messagecatelog.json", LanguageFilePattern: "messagecatelog-{lang}.json"},
+	}
+
+	report := NewLinter(NewFileSystemLoader(catalogs, newQuietLogger(t)), "en-US").
+		Lint(context.Background(), []string{"alert", "audit", ErrorCatalogName})
+
+	assert.Empty(t, report.Issues)
+}
+
+func TestValidateCatalogs_StrictMode(t *testing.T) {
+	loader := newLintLoader(t, fstest.MapFS{
+		"messagecatelog-en-US.json": {Data: []byte(`{"TST0001": {"message": "a"}, "TST0002": {"message": "b"}, "TST0003": {"message": "c"}}`)},
+	})
+	catalogConfig := config.MessageCatalogConfig{DefaultLanguage: "en-US", Catalogs: testCatalogs, ValidationMode: ValidationWarn}
+
+	service := NewMessageCatalogService(catalogConfig, loader, NewLRUCacheManager(0, 0), newQuietLogger(t)).(*MessageCatalogService)
+	report, err := service.ValidateCatalogs(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Errors)
+
+	service.config.ValidationMode = ValidationStrict
+	report, err = service.ValidateCatalogs(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrCodeInternalServer, errors.GetAppError(err).Code)
+	assert.Equal(t, 4, report.Errors)
+}
@@ -0,0 +1,377 @@
+package messagecatalog
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/interfaces"
+)
+
+// Catalog sources selectable with CatalogConfig.Source
+const (
+	SourceFilesystem = "filesystem" // Files under CatalogConfig.Path (default)
+	SourceEmbed      = "embed"      // Files compiled into the binary with embed.FS
+	SourceDatabase   = "database"   // Rows in the message_catalog_* tables
+)
+
+// catalogSource returns the source of a catalog, defaulting to the filesystem
+func catalogSource(catalog *config.CatalogConfig) string {
+	if catalog.Source == "" {
+		return SourceFilesystem
+	}
+	return catalog.Source
+}
+
+// catalogNotFound is returned when a loader does not know a catalog
+func catalogNotFound(catalogName string) error {
+	return errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog not found",
+		fmt.Sprintf("Catalog '%s' not found in configuration", catalogName), 404)
+}
+
+// fsCatalog is one catalog served from an fs.FS
+type fsCatalog struct {
+	config   config.CatalogConfig
+	fsys     fs.FS
+	location string // Shown in errors and logs
+}
+
+// FSLoader implements CatalogLoader over fs.FS trees, one per catalog
+// It backs both the filesystem and the embed sources
+type FSLoader struct {
+	catalogs map[string]*fsCatalog
+	logger   interfaces.Logger
+}
+
+// NewFileSystemLoader creates a loader reading every configured catalog from its Path on disk
+func NewFileSystemLoader(catalogs []config.CatalogConfig, log interfaces.Logger) CatalogLoader {
+	loader := &FSLoader{catalogs: make(map[string]*fsCatalog), logger: log}
+	for _, catalog := range catalogs {
+		loader.catalogs[catalog.Name] = &fsCatalog{
+			config:   catalog,
+			fsys:     os.DirFS(catalog.Path),
+			location: catalog.Path,
+		}
+	}
+	return loader
+}
+
+// NewEmbedLoader creates a loader serving catalogs compiled into the binary
+// embedded maps a catalog name to its files (e.g. alert.CatalogFS()); catalogs
+// without an embedded tree are reported as not found
+func NewEmbedLoader(catalogs []config.CatalogConfig, embedded map[string]fs.FS, log interfaces.Logger) CatalogLoader {
+	loader := &FSLoader{catalogs: make(map[string]*fsCatalog), logger: log}
+	for _, catalog := range catalogs {
+		fsys, exists := embedded[catalog.Name]
+		if !exists {
+			continue
+		}
+		loader.catalogs[catalog.Name] = &fsCatalog{
+			config:   catalog,
+			fsys:     fsys,
+			location: "embed:" + catalog.Name,
+		}
+	}
+	return loader
+}
+
+// LoadCatalogStructure loads the structure file of a catalog
+func (l *FSLoader) LoadCatalogStructure(ctx context.Context, catalogName string) (map[string]interface{}, error) {
+	catalog, exists := l.catalogs[catalogName]
+	if !exists {
+		return nil, catalogNotFound(catalogName)
+	}
+
+	filePath := path.Join(catalog.location, catalog.config.StructureFile)
+	l.logger.Debug(ctx, "Loading structure file", interfaces.Fields{
+		"file_path": filePath,
+		"catalog":   catalogName,
+	})
+
+	data, err := fs.ReadFile(catalog.fsys, catalog.config.StructureFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Structure file not found",
+				fmt.Sprintf("Structure file '%s' not found for catalog '%s'", filePath, catalogName), 404)
+		}
+		return nil, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to read structure file", 500, err)
+	}
+
+	var structureData map[string]interface{}
+	if err := json.Unmarshal(data, &structureData); err != nil {
+		return nil, errors.NewWithError(errors.ErrCodeBadRequest, "Failed to parse structure file", 400, err)
+	}
+
+	return structureData, nil
+}
+
+// LoadLanguageFile loads the language file of a catalog
+func (l *FSLoader) LoadLanguageFile(ctx context.Context, catalogName, language string) (map[string]interface{}, error) {
+	catalog, exists := l.catalogs[catalogName]
+	if !exists {
+		return nil, catalogNotFound(catalogName)
+	}
+
+	fileName := languageFileName(&catalog.config, language)
+	filePath := path.Join(catalog.location, fileName)
+	l.logger.Debug(ctx, "Loading language file", interfaces.Fields{
+		"file_path": filePath,
+		"catalog":   catalogName,
+		"language":  language,
+	})
+
+	data, err := fs.ReadFile(catalog.fsys, fileName)
+	if err != nil {
+		if os.IsNotExist(err) || !fs.ValidPath(fileName) {
+			return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Language file not found",
+				fmt.Sprintf("Language file '%s' not found for catalog '%s'", filePath, catalogName), 404)
+		}
+		return nil, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to read language file", 500, err)
+	}
+
+	var languageData map[string]interface{}
+	if err := json.Unmarshal(data, &languageData); err != nil {
+		return nil, errors.NewWithError(errors.ErrCodeBadRequest, "Failed to parse language file", 400, err)
+	}
+
+	return languageData, nil
+}
+
+// ListAvailableLanguages lists the languages that have a language file, sorted
+func (l *FSLoader) ListAvailableLanguages(ctx context.Context, catalogName string) ([]string, error) {
+	catalog, exists := l.catalogs[catalogName]
+	if !exists {
+		return nil, catalogNotFound(catalogName)
+	}
+
+	entries, err := fs.ReadDir(catalog.fsys, ".")
+	if err != nil {
+		return nil, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to list language files", 500, err)
+	}
+
+	languages := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if language, ok := languageFromFileName(&catalog.config, entry.Name()); ok {
+			languages = append(languages, language)
+		}
+	}
+
+	sort.Strings(languages)
+	return languages, nil
+}
+
+// isFileSystemCatalog reports whether a catalog is read from disk (and can be watched)
+func isFileSystemCatalog(catalog *config.CatalogConfig) bool {
+	return catalogSource(catalog) == SourceFilesystem
+}
+
+// languageFileName returns the language file name of a catalog (LanguageFilePattern with {lang} replaced)
+func languageFileName(catalog *config.CatalogConfig, language string) string {
+	return strings.ReplaceAll(catalog.LanguageFilePattern, "{lang}", language)
+}
+
+// DatabaseLoader implements CatalogLoader over the message_catalog_messages and
+// message_catalog_translations tables (see scripts/migrations/005)
+type DatabaseLoader struct {
+	db     interfaces.Database
+	logger interfaces.Logger
+}
+
+// NewDatabaseLoader creates a loader reading catalogs from the database
+func NewDatabaseLoader(db interfaces.Database, log interfaces.Logger) CatalogLoader {
+	return &DatabaseLoader{
+		db:     db,
+		logger: log,
+	}
+}
+
+// LoadCatalogStructure loads the structure of every message in a catalog
+func (l *DatabaseLoader) LoadCatalogStructure(ctx context.Context, catalogName string) (map[string]interface{}, error) {
+	query := `
+		SELECT message_code, category, severity, component
+		FROM message_catalog_messages
+		WHERE catalog_name = $1
+	`
+
+	structureData := make(map[string]interface{})
+	err := l.queryRows(ctx, query, []interface{}{catalogName}, func(rows *sql.Rows) error {
+		var code, category, severity, component string
+		if err := rows.Scan(&code, &category, &severity, &component); err != nil {
+			return err
+		}
+		structureData[code] = map[string]interface{}{
+			"message_code": code,
+			"category":     category,
+			"severity":     severity,
+			"component":    component,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, l.queryError(ctx, "Failed to load catalog structure", catalogName, err)
+	}
+
+	if len(structureData) == 0 {
+		return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog structure not found",
+			fmt.Sprintf("No messages stored for catalog '%s'", catalogName), 404)
+	}
+
+	return structureData, nil
+}
+
+// LoadLanguageFile loads the translations of a catalog in one language
+func (l *DatabaseLoader) LoadLanguageFile(ctx context.Context, catalogName, language string) (map[string]interface{}, error) {
+	query := `
+		SELECT message_code, message, detailed_description, response_action
+		FROM message_catalog_translations
+		WHERE catalog_name = $1 AND language = $2
+	`
+
+	languageData := make(map[string]interface{})
+	err := l.queryRows(ctx, query, []interface{}{catalogName, language}, func(rows *sql.Rows) error {
+		var code, message, description, action string
+		if err := rows.Scan(&code, &message, &description, &action); err != nil {
+			return err
+		}
+		languageData[code] = map[string]interface{}{
+			"message":              message,
+			"detailed_description": description,
+			"response_action":      action,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, l.queryError(ctx, "Failed to load catalog translations", catalogName, err)
+	}
+
+	if len(languageData) == 0 {
+		return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Language not found",
+			fmt.Sprintf("No '%s' translations stored for catalog '%s'", language, catalogName), 404)
+	}
+
+	return languageData, nil
+}
+
+// ListAvailableLanguages lists the languages with stored translations, sorted
+func (l *DatabaseLoader) ListAvailableLanguages(ctx context.Context, catalogName string) ([]string, error) {
+	query := `
+		SELECT DISTINCT language
+		FROM message_catalog_translations
+		WHERE catalog_name = $1
+		ORDER BY language
+	`
+
+	languages := []string{}
+	err := l.queryRows(ctx, query, []interface{}{catalogName}, func(rows *sql.Rows) error {
+		var language string
+		if err := rows.Scan(&language); err != nil {
+			return err
+		}
+		languages = append(languages, language)
+		return nil
+	})
+	if err != nil {
+		return nil, l.queryError(ctx, "Failed to list catalog languages", catalogName, err)
+	}
+
+	return languages, nil
+}
+
+// queryRows runs a query and calls scan for every row
+func (l *DatabaseLoader) queryRows(ctx context.Context, query string, args []interface{}, scan func(*sql.Rows) error) error {
+	return l.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			if err := scan(rows); err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	})
+}
+
+// queryError logs a failed catalog query and wraps it in an AppError
+func (l *DatabaseLoader) queryError(ctx context.Context, message, catalogName string, err error) error {
+	l.logger.Error(ctx, message, interfaces.Fields{
+		"catalog_name": catalogName,
+		"error":        err.Error(),
+	})
+	return errors.NewWithError(errors.ErrCodeDatabaseQuery, message, 500, err).
+		WithField("catalog_name", catalogName)
+}
+
+// SourceLoader routes every catalog to the loader of its CatalogConfig.Source
+type SourceLoader struct {
+	sources map[string]string        // catalog name -> source
+	loaders map[string]CatalogLoader // source -> loader
+}
+
+// NewSourceLoader creates a loader that dispatches each catalog to loaders[catalog.Source]
+// Catalogs whose source has no loader fail to load with a 500 error
+func NewSourceLoader(catalogs []config.CatalogConfig, loaders map[string]CatalogLoader) CatalogLoader {
+	sources := make(map[string]string, len(catalogs))
+	for i := range catalogs {
+		sources[catalogs[i].Name] = catalogSource(&catalogs[i])
+	}
+	return &SourceLoader{
+		sources: sources,
+		loaders: loaders,
+	}
+}
+
+// LoadCatalogStructure loads a catalog structure from the catalog's source
+func (l *SourceLoader) LoadCatalogStructure(ctx context.Context, catalogName string) (map[string]interface{}, error) {
+	loader, err := l.loaderFor(catalogName)
+	if err != nil {
+		return nil, err
+	}
+	return loader.LoadCatalogStructure(ctx, catalogName)
+}
+
+// LoadLanguageFile loads catalog translations from the catalog's source
+func (l *SourceLoader) LoadLanguageFile(ctx context.Context, catalogName, language string) (map[string]interface{}, error) {
+	loader, err := l.loaderFor(catalogName)
+	if err != nil {
+		return nil, err
+	}
+	return loader.LoadLanguageFile(ctx, catalogName, language)
+}
+
+// ListAvailableLanguages lists catalog languages from the catalog's source
+func (l *SourceLoader) ListAvailableLanguages(ctx context.Context, catalogName string) ([]string, error) {
+	loader, err := l.loaderFor(catalogName)
+	if err != nil {
+		return nil, err
+	}
+	return loader.ListAvailableLanguages(ctx, catalogName)
+}
+
+// loaderFor returns the loader configured for a catalog
+func (l *SourceLoader) loaderFor(catalogName string) (CatalogLoader, error) {
+	source, exists := l.sources[catalogName]
+	if !exists {
+		return nil, catalogNotFound(catalogName)
+	}
+	loader, exists := l.loaders[source]
+	if !exists || loader == nil {
+		return nil, errors.NewWithDetails(errors.ErrCodeInternalServer, "Catalog source not available",
+			fmt.Sprintf("No loader for source '%s' of catalog '%s'", source, catalogName), 500)
+	}
+	return loader, nil
+}
@@ -0,0 +1,114 @@
+package messagecatalog
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/mocks"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/interfaces"
+)
+
+var testCatalogs = []config.CatalogConfig{{
+	Name:                "test",
+	Enabled:             true,
+	StructureFile:       "messagecatelog.json",
+	LanguageFilePattern: "messagecatelog-{lang}.json",
+	Source:              SourceEmbed,
+}}
+
+func newQuietLogger(t *testing.T) interfaces.Logger {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	return mockLogger
+}
+
+func newTestEmbedLoader(t *testing.T) CatalogLoader {
+	files := fstest.MapFS{
+		"messagecatelog.json":       {Data: []byte(testStructure)},
+		"messagecatelog-en-US.json": {Data: []byte(`{"TST0001": {"message": "hello"}}`)},
+		"messagecatelog-fr-FR.json": {Data: []byte(`{"TST0001": {"message": "bonjour"}}`)},
+		"README.md":                 {Data: []byte("not a catalog")},
+	}
+	return NewEmbedLoader(testCatalogs, map[string]fs.FS{"test": files}, newQuietLogger(t))
+}
+
+func TestFSLoader_LoadsCatalogFiles(t *testing.T) {
+	ctx := context.Background()
+	loader := newTestEmbedLoader(t)
+
+	structure, err := loader.LoadCatalogStructure(ctx, "test")
+	require.NoError(t, err)
+	assert.Contains(t, structure, "TST0001")
+
+	language, err := loader.LoadLanguageFile(ctx, "test", "fr-FR")
+	require.NoError(t, err)
+	assert.Equal(t, "bonjour", language["TST0001"].(map[string]interface{})["message"])
+
+	languages, err := loader.ListAvailableLanguages(ctx, "test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en-US", "fr-FR"}, languages)
+}
+
+func TestFSLoader_ReportsMissingDataAsNotFound(t *testing.T) {
+	ctx := context.Background()
+	loader := newTestEmbedLoader(t)
+
+	for _, err := range []error{
+		func() error { _, err := loader.LoadLanguageFile(ctx, "test", "de-DE"); return err }(),
+		func() error { _, err := loader.LoadLanguageFile(ctx, "test", "../en-US"); return err }(),
+		func() error { _, err := loader.LoadCatalogStructure(ctx, "unknown"); return err }(),
+	} {
+		appErr := errors.GetAppError(err)
+		require.NotNil(t, appErr)
+		assert.Equal(t, errors.ErrCodeNotFound, appErr.Code)
+	}
+}
+
+func TestSourceLoader_RoutesByCatalogSource(t *testing.T) {
+	ctx := context.Background()
+	loader := NewSourceLoader(testCatalogs, map[string]CatalogLoader{SourceEmbed: newTestEmbedLoader(t)})
+
+	_, err := loader.LoadCatalogStructure(ctx, "test")
+	assert.NoError(t, err)
+
+	// No loader registered for the catalog's source
+	unrouted := NewSourceLoader(testCatalogs, map[string]CatalogLoader{})
+	_, err = unrouted.LoadCatalogStructure(ctx, "test")
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrCodeInternalServer, errors.GetAppError(err).Code)
+}
+
+func TestMessageCatalogService_EvictedLanguageIsReloaded(t *testing.T) {
+	ctx := context.Background()
+	logger := newQuietLogger(t)
+	catalogConfig := config.MessageCatalogConfig{DefaultLanguage: "en-US", CacheEnabled: true, Catalogs: testCatalogs}
+	cache := NewLRUCacheManager(1, 0)
+	service := NewMessageCatalogService(catalogConfig, newTestEmbedLoader(t), cache, logger)
+
+	french, err := service.GetMessageByCode(ctx, "TST0001", "test", "fr-FR")
+	require.NoError(t, err)
+	assert.Equal(t, "bonjour", french.Message)
+
+	// Loading fr-FR evicted en-US; it is loaded again on demand
+	english, err := service.GetMessageByCode(ctx, "TST0001", "test", "")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", english.Message)
+
+	stats, err := service.GetCatalogStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.Cache["evictions"])
+	assert.Equal(t, SourceEmbed, stats.Catalogs[0].Source)
+}
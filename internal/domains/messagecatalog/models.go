@@ -1,6 +1,11 @@
 package messagecatalog
 
-import "time"
+import (
+	"time"
+
+	"tushartemplategin/pkg/audit"
+	"tushartemplategin/pkg/errors"
+)
 
 // Message represents a complete message with structure and translations
 type Message struct {
@@ -40,7 +45,9 @@ type MessageResponse struct {
 	Message             string                 `json:"message"`
 	DetailedDescription string                 `json:"detailed_description"`
 	ResponseAction      string                 `json:"response_action"`
-	Language            string                 `json:"language"`
+	Language            string                 `json:"language"`                     // Language the message text was served in
+	RequestedLanguage   string                 `json:"requested_language,omitempty"` // Language asked for, before fallback
+	FallbackFields      map[string]string      `json:"fallback_fields,omitempty"`    // Fields served from another language (field -> language)
 	CatalogName         string                 `json:"catalog_name"`
 	FormattedMessage    string                 `json:"formatted_message"`
 	Metadata            map[string]interface{} `json:"metadata,omitempty"`
@@ -49,20 +56,238 @@ type MessageResponse struct {
 
 // CatalogInfo represents information about a catalog
 type CatalogInfo struct {
-	Name         string    `json:"name"`
-	Path         string    `json:"path"`
-	Enabled      bool      `json:"enabled"`
-	Languages    []string  `json:"languages"`
-	MessageCount int       `json:"message_count"`
-	LastReloaded time.Time `json:"last_reloaded"`
+	Name            string    `json:"name"`
+	Path            string    `json:"path"`
+	Source          string    `json:"source"`
+	Enabled         bool      `json:"enabled"`
+	Languages       []string  `json:"languages"`
+	LoadedLanguages []string  `json:"loaded_languages"` // Languages currently in the cache
+	MessageCount    int       `json:"message_count"`
+	MemoryBytes     int64     `json:"memory_bytes"` // Estimated memory of the loaded languages
+	LastReloaded    time.Time `json:"last_reloaded"`
 }
 
 // CatalogStats represents statistics about all catalogs
 type CatalogStats struct {
-	TotalCatalogs     int            `json:"total_catalogs"`
-	TotalMessages     int            `json:"total_messages"`
-	LanguagesCount    int            `json:"languages_count"`
-	Catalogs          []CatalogInfo  `json:"catalogs"`
-	MessagesByCatalog map[string]int `json:"messages_by_catalog"`
-	LastReloaded      time.Time      `json:"last_reloaded"`
+	TotalCatalogs     int                    `json:"total_catalogs"`
+	TotalMessages     int                    `json:"total_messages"`
+	MemoryBytes       int64                  `json:"memory_bytes"`
+	LanguagesCount    int                    `json:"languages_count"`
+	Catalogs          []CatalogInfo          `json:"catalogs"`
+	MessagesByCatalog map[string]int         `json:"messages_by_catalog"`
+	LastReloaded      time.Time              `json:"last_reloaded"`
+	Cache             map[string]interface{} `json:"cache,omitempty"` // CacheManager statistics (hits, misses, evictions, ...)
+}
+
+// RenderMessageRequest represents the body for rendering a message with parameters
+type RenderMessageRequest struct {
+	Language   string                 `json:"language,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// RenderItem is one message of a batch render
+// CatalogName and Language default to those of the batch
+type RenderItem struct {
+	MessageCode string                 `json:"message_code" binding:"required"`
+	CatalogName string                 `json:"catalog_name,omitempty"`
+	Language    string                 `json:"language,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// BatchRenderRequest represents a request to render many messages in one call
+type BatchRenderRequest struct {
+	CatalogName string       `json:"catalog_name,omitempty"`
+	Language    string       `json:"language,omitempty"`
+	Items       []RenderItem `json:"items" binding:"required,min=1,dive"`
+}
+
+// RenderResult is the outcome of one batch item, in request order
+// Exactly one of Message and Error is set; Status is the HTTP status the item
+// would have had on its own
+type RenderResult struct {
+	Index       int              `json:"index"`
+	MessageCode string           `json:"message_code"`
+	CatalogName string           `json:"catalog_name"`
+	Status      int              `json:"status"`
+	Message     *MessageResponse `json:"message,omitempty"`
+	Error       *errors.AppError `json:"error,omitempty"`
+}
+
+// BatchRenderResponse represents the results of a batch render
+type BatchRenderResponse struct {
+	Results []RenderResult `json:"results"`
+	Count   int            `json:"count"`
+	Failed  int            `json:"failed"`
+}
+
+// SearchRequest represents a full-text search over message texts
+// An empty CatalogName searches every enabled catalog; Category, Severity and
+// Component filter the matches (case-insensitive)
+type SearchRequest struct {
+	Query       string `form:"q" json:"q" binding:"required"`
+	CatalogName string `form:"catalog" json:"catalog_name,omitempty"`
+	Language    string `form:"language" json:"language,omitempty"`
+	Category    string `form:"category" json:"category,omitempty"`
+	Severity    string `form:"severity" json:"severity,omitempty"`
+	Component   string `form:"component" json:"component,omitempty"`
+	Limit       int    `form:"limit" json:"limit,omitempty" binding:"omitempty,min=1,max=100"`
+}
+
+// SearchResult is one matching message, best match first
+type SearchResult struct {
+	CatalogName   string           `json:"catalog_name"`
+	MessageCode   string           `json:"message_code"`
+	Score         float64          `json:"score"`
+	MatchedFields []string         `json:"matched_fields"`
+	Message       *MessageResponse `json:"message"`
+}
+
+// SearchResponse represents the results of a search
+// Total counts every match; Results holds at most Limit of them
+type SearchResponse struct {
+	Query    string          `json:"query"`
+	Language string          `json:"language"`
+	Total    int             `json:"total"`
+	Results  []*SearchResult `json:"results"`
+}
+
+// MessageListResponse represents a list of messages from one catalog
+type MessageListResponse struct {
+	CatalogName string             `json:"catalog_name"`
+	Messages    []*MessageResponse `json:"messages"`
+	Count       int                `json:"count"`
+}
+
+// CatalogListResponse represents the list of available catalogs
+type CatalogListResponse struct {
+	Catalogs []string `json:"catalogs"`
+}
+
+// LanguageListResponse represents the languages available for a catalog
+type LanguageListResponse struct {
+	CatalogName string   `json:"catalog_name"`
+	Languages   []string `json:"languages"`
+}
+
+// ReloadResponse represents the result of a catalog reload
+type ReloadResponse struct {
+	Catalogs   []string  `json:"catalogs"`
+	ReloadedAt time.Time `json:"reloaded_at"`
+}
+
+// Translation is the text of one message in one language
+type Translation struct {
+	Message             string `json:"message"`
+	DetailedDescription string `json:"detailed_description"`
+	ResponseAction      string `json:"response_action"`
+}
+
+// CatalogEntry is an editable message: its structure and every translation
+// Version starts at 1 and grows with every edit of the message or a translation
+type CatalogEntry struct {
+	CatalogName  string                 `json:"catalog_name"`
+	MessageCode  string                 `json:"message_code"`
+	Category     string                 `json:"category"`
+	Severity     string                 `json:"severity"`
+	Component    string                 `json:"component"`
+	Translations map[string]Translation `json:"translations"`
+	Version      int                    `json:"version"`
+	UpdatedBy    string                 `json:"updated_by,omitempty"`
+	UpdatedAt    time.Time              `json:"updated_at,omitempty"`
+}
+
+// Entry version actions
+const (
+	VersionInitial  = "initial"  // State before the first recorded edit
+	VersionCreate   = "create"   // Message was created
+	VersionUpdate   = "update"   // Structure or a translation was changed
+	VersionDelete   = "delete"   // Message was deleted
+	VersionRollback = "rollback" // An earlier version was restored
+	VersionImport   = "import"   // Translations were imported from a vendor file
+)
+
+// EntryVersion is one recorded state of a message
+// Entry is nil for a delete
+type EntryVersion struct {
+	Version   int           `json:"version"`
+	Action    string        `json:"action"`
+	Author    string        `json:"author"`
+	CreatedAt time.Time     `json:"created_at"`
+	Entry     *CatalogEntry `json:"entry"`
+}
+
+// EntryDiff lists the fields that differ between two versions of a message
+// Translation fields are named "translations.<language>.<field>"
+type EntryDiff struct {
+	CatalogName string                       `json:"catalog_name"`
+	MessageCode string                       `json:"message_code"`
+	From        int                          `json:"from"`
+	To          int                          `json:"to"`
+	Changes     map[string]audit.FieldChange `json:"changes"`
+}
+
+// EntryRequest creates (version 0) or replaces (current version) a message
+// Translations are optional on update; languages left out are kept
+type EntryRequest struct {
+	Category     string                 `json:"category" binding:"required"`
+	Severity     string                 `json:"severity" binding:"required"`
+	Component    string                 `json:"component" binding:"required"`
+	Translations map[string]Translation `json:"translations,omitempty"`
+	Version      int                    `json:"version"`
+}
+
+// TranslationRequest creates or replaces one translation of a message
+type TranslationRequest struct {
+	Translation
+	Version int `json:"version" binding:"required"`
+}
+
+// RollbackRequest restores ToVersion as a new version; Version is the current one
+type RollbackRequest struct {
+	ToVersion int `json:"to_version" binding:"required"`
+	Version   int `json:"version"`
+}
+
+// EntryListResponse represents the editable messages of one catalog
+type EntryListResponse struct {
+	CatalogName string          `json:"catalog_name"`
+	Entries     []*CatalogEntry `json:"entries"`
+	Count       int             `json:"count"`
+}
+
+// VersionListResponse represents the history of one message, oldest first
+type VersionListResponse struct {
+	CatalogName string          `json:"catalog_name"`
+	MessageCode string          `json:"message_code"`
+	Versions    []*EntryVersion `json:"versions"`
+}
+
+// ImportOptions controls ImportTranslations
+type ImportOptions struct {
+	IncludeFuzzy bool // Import translations marked fuzzy instead of skipping them
+	DryRun       bool // Check and report without saving
+}
+
+// ImportProblem is a unit that was not imported
+type ImportProblem struct {
+	Unit   string `json:"unit"`
+	Reason string `json:"reason"`
+}
+
+// ImportReport summarizes an import; units are named "<code>.<field>"
+type ImportReport struct {
+	CatalogName  string          `json:"catalog_name"`
+	Language     string          `json:"language"`
+	DryRun       bool            `json:"dry_run"`
+	Imported     int             `json:"imported"`               // Units saved (or that would be saved)
+	Updated      []string        `json:"updated"`                // Message codes whose translation changed
+	Untranslated []string        `json:"untranslated,omitempty"` // Units without a target text
+	Fuzzy        []string        `json:"fuzzy,omitempty"`        // Units marked fuzzy (skipped unless IncludeFuzzy)
+	Outdated     []string        `json:"outdated,omitempty"`     // Units whose source text changed since the export
+	Rejected     []ImportProblem `json:"rejected,omitempty"`     // Units with unknown codes or fields, or invalid placeholders
+}
+
+// HasRejected reports whether some units could not be imported
+func (r *ImportReport) HasRejected() bool {
+	return len(r.Rejected) > 0
 }
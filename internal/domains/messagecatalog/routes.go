@@ -0,0 +1,637 @@
+package messagecatalog
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"tushartemplategin/pkg/catalogexchange"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/middleware"
+)
+
+// RegisterRoutes registers all message catalog routes to the given router group
+// adminAuth guards the reload and management endpoints; all other endpoints are public
+func RegisterRoutes(router *gin.RouterGroup, adminAuth gin.HandlerFunc) {
+	// Create a catalog group under the main API group
+	// This will create routes like /api/v1/catalogs, /api/v1/catalogs/:catalog/messages/:code, etc.
+	catalogGroup := router.Group("/catalogs")
+	{
+		// GET /catalogs - List enabled catalogs
+		catalogGroup.GET("", listCatalogsHandler)
+
+		// GET /catalogs/stats - Statistics across all catalogs
+		catalogGroup.GET("/stats", getCatalogStatsHandler)
+
+		// GET /catalogs/search?q=... - Full-text search over message texts
+		catalogGroup.GET("/search", searchMessagesHandler)
+
+		// POST /catalogs/render - Render many messages in one call, with per-item errors
+		catalogGroup.POST("/render", renderMessagesHandler)
+
+		// POST /catalogs/reload - Reload all catalogs (authenticated)
+		catalogGroup.POST("/reload", adminAuth, reloadAllCatalogsHandler)
+
+		// GET /catalogs/:catalog - Catalog information
+		catalogGroup.GET("/:catalog", getCatalogInfoHandler)
+
+		// GET /catalogs/:catalog/languages - Languages available for a catalog
+		catalogGroup.GET("/:catalog/languages", listLanguagesHandler)
+
+		// GET /catalogs/:catalog/messages/:code - Get a message; query parameters fill the template
+		catalogGroup.GET("/:catalog/messages/:code", getMessageHandler)
+
+		// POST /catalogs/:catalog/messages/:code/render - Render a message with JSON parameters
+		catalogGroup.POST("/:catalog/messages/:code/render", renderMessageHandler)
+
+		// GET /catalogs/:catalog/categories/:category - Messages in a category
+		catalogGroup.GET("/:catalog/categories/:category", getMessagesByCategoryHandler)
+
+		// GET /catalogs/:catalog/severities/:severity - Messages with a severity
+		catalogGroup.GET("/:catalog/severities/:severity", getMessagesBySeverityHandler)
+
+		// POST /catalogs/:catalog/reload - Reload one catalog (authenticated)
+		catalogGroup.POST("/:catalog/reload", adminAuth, reloadCatalogHandler)
+	}
+
+	// Catalog management (authenticated): edits are versioned per message and published by reloading the catalog
+	entryGroup := catalogGroup.Group("/:catalog/entries", adminAuth)
+	{
+		// GET /catalogs/:catalog/entries - Every message with all translations
+		entryGroup.GET("", listEntriesHandler)
+
+		// GET /catalogs/:catalog/entries/:code - One message with all translations and its version
+		entryGroup.GET("/:code", getEntryHandler)
+
+		// POST /catalogs/:catalog/entries/:code - Create a message
+		entryGroup.POST("/:code", createEntryHandler)
+
+		// PUT /catalogs/:catalog/entries/:code - Update a message (body carries the current version)
+		entryGroup.PUT("/:code", updateEntryHandler)
+
+		// DELETE /catalogs/:catalog/entries/:code?version=N - Delete a message
+		entryGroup.DELETE("/:code", deleteEntryHandler)
+
+		// PUT /catalogs/:catalog/entries/:code/translations/:language - Create or replace a translation
+		entryGroup.PUT("/:code/translations/:language", putTranslationHandler)
+
+		// DELETE /catalogs/:catalog/entries/:code/translations/:language?version=N - Delete a translation
+		entryGroup.DELETE("/:code/translations/:language", deleteTranslationHandler)
+
+		// GET /catalogs/:catalog/entries/:code/versions - Version history, oldest first
+		entryGroup.GET("/:code/versions", listVersionsHandler)
+
+		// GET /catalogs/:catalog/entries/:code/versions/:version - One version
+		entryGroup.GET("/:code/versions/:version", getVersionHandler)
+
+		// GET /catalogs/:catalog/entries/:code/diff?from=N&to=M - Fields changed between two versions
+		entryGroup.GET("/:code/diff", diffVersionsHandler)
+
+		// POST /catalogs/:catalog/entries/:code/rollback - Restore an earlier version
+		entryGroup.POST("/:code/rollback", rollbackEntryHandler)
+	}
+
+	// GET /catalogs/:catalog/export?target=fr-FR&format=xliff - Download a vendor file (authenticated)
+	catalogGroup.GET("/:catalog/export", adminAuth, exportTranslationsHandler)
+
+	// POST /catalogs/:catalog/import?format=po - Import a translated vendor file (authenticated)
+	catalogGroup.POST("/:catalog/import", adminAuth, importTranslationsHandler)
+}
+
+// listCatalogsHandler handles listing enabled catalogs
+func listCatalogsHandler(c *gin.Context) {
+	// Get the message catalog service from the context
+	catalogService := c.MustGet("messageCatalogService").(Service)
+
+	catalogs, err := catalogService.ListAvailableCatalogs(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err, "Failed to list catalogs")
+		return
+	}
+
+	c.JSON(http.StatusOK, CatalogListResponse{Catalogs: catalogs})
+}
+
+// getCatalogStatsHandler handles catalog statistics requests
+func getCatalogStatsHandler(c *gin.Context) {
+	// Get the message catalog service from the context
+	catalogService := c.MustGet("messageCatalogService").(Service)
+
+	stats, err := catalogService.GetCatalogStats(c.Request.Context())
+	if err != nil {
+		handleServiceError(c, err, "Failed to get catalog stats")
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// getCatalogInfoHandler handles catalog information requests
+func getCatalogInfoHandler(c *gin.Context) {
+	// Get the message catalog service from the context
+	catalogService := c.MustGet("messageCatalogService").(Service)
+
+	info, err := catalogService.GetCatalogInfo(c.Request.Context(), c.Param("catalog"))
+	if err != nil {
+		handleServiceError(c, err, "Failed to get catalog info")
+		return
+	}
+
+	c.JSON(http.StatusOK, info)
+}
+
+// listLanguagesHandler handles listing the languages of a catalog
+func listLanguagesHandler(c *gin.Context) {
+	// Get the message catalog service from the context
+	catalogService := c.MustGet("messageCatalogService").(Service)
+
+	catalogName := c.Param("catalog")
+	languages, err := catalogService.ListAvailableLanguages(c.Request.Context(), catalogName)
+	if err != nil {
+		handleServiceError(c, err, "Failed to list catalog languages")
+		return
+	}
+
+	c.JSON(http.StatusOK, LanguageListResponse{CatalogName: catalogName, Languages: languages})
+}
+
+// getMessageHandler handles getting a message by code
+// Every query parameter except "language" is passed to the message template
+func getMessageHandler(c *gin.Context) {
+	// Get the message catalog service from the context
+	catalogService := c.MustGet("messageCatalogService").(Service)
+
+	parameters := make(map[string]interface{})
+	for key, values := range c.Request.URL.Query() {
+		if key == middleware.LanguageQueryParam || len(values) == 0 {
+			continue
+		}
+		parameters[key] = values[0]
+	}
+
+	message, err := catalogService.GetMessage(c.Request.Context(), &MessageRequest{
+		MessageCode: c.Param("code"),
+		CatalogName: c.Param("catalog"),
+		Language:    middleware.PreferredLanguage(c),
+		Parameters:  parameters,
+	})
+	if err != nil {
+		handleServiceError(c, err, "Failed to get message")
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
+
+// renderMessageHandler handles rendering a message with parameters from the request body
+func renderMessageHandler(c *gin.Context) {
+	// Get the message catalog service from the context
+	catalogService := c.MustGet("messageCatalogService").(Service)
+
+	var req RenderMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid request body", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	language := req.Language
+	if language == "" {
+		language = middleware.PreferredLanguage(c)
+	}
+
+	message, err := catalogService.GetMessage(c.Request.Context(), &MessageRequest{
+		MessageCode: c.Param("code"),
+		CatalogName: c.Param("catalog"),
+		Language:    language,
+		Parameters:  req.Parameters,
+	})
+	if err != nil {
+		handleServiceError(c, err, "Failed to render message")
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
+
+// renderMessagesHandler handles rendering a batch of messages
+// The batch language defaults to ?language= or the Accept-Language header; items may override it
+func renderMessagesHandler(c *gin.Context) {
+	// Get the message catalog service from the context
+	catalogService := c.MustGet("messageCatalogService").(Service)
+
+	var req BatchRenderRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if req.Language == "" {
+		req.Language = middleware.PreferredLanguage(c)
+	}
+
+	response, err := catalogService.RenderMessages(c.Request.Context(), &req)
+	if err != nil {
+		handleServiceError(c, err, "Failed to render messages")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// searchMessagesHandler handles full-text search across catalogs
+// The language defaults to ?language= or the Accept-Language header
+func searchMessagesHandler(c *gin.Context) {
+	// Get the message catalog service from the context
+	catalogService := c.MustGet("messageCatalogService").(Service)
+
+	var req SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid search query", err.Error(), http.StatusBadRequest))
+		return
+	}
+	req.Language = middleware.PreferredLanguage(c)
+
+	response, err := catalogService.SearchMessages(c.Request.Context(), &req)
+	if err != nil {
+		handleServiceError(c, err, "Failed to search messages")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// getMessagesByCategoryHandler handles listing the messages of a category
+func getMessagesByCategoryHandler(c *gin.Context) {
+	// Get the message catalog service from the context
+	catalogService := c.MustGet("messageCatalogService").(Service)
+
+	catalogName := c.Param("catalog")
+	messages, err := catalogService.GetMessagesByCategory(c.Request.Context(), c.Param("category"), catalogName, middleware.PreferredLanguage(c))
+	if err != nil {
+		handleServiceError(c, err, "Failed to get messages by category")
+		return
+	}
+
+	respondWithMessages(c, catalogName, messages)
+}
+
+// getMessagesBySeverityHandler handles listing the messages of a severity
+func getMessagesBySeverityHandler(c *gin.Context) {
+	// Get the message catalog service from the context
+	catalogService := c.MustGet("messageCatalogService").(Service)
+
+	catalogName := c.Param("catalog")
+	messages, err := catalogService.GetMessagesBySeverity(c.Request.Context(), c.Param("severity"), catalogName, middleware.PreferredLanguage(c))
+	if err != nil {
+		handleServiceError(c, err, "Failed to get messages by severity")
+		return
+	}
+
+	respondWithMessages(c, catalogName, messages)
+}
+
+// reloadCatalogHandler handles reloading one catalog
+func reloadCatalogHandler(c *gin.Context) {
+	// Get the message catalog service from the context
+	catalogService := c.MustGet("messageCatalogService").(Service)
+
+	catalogName := c.Param("catalog")
+	if err := catalogService.ReloadCatalog(c.Request.Context(), catalogName); err != nil {
+		handleServiceError(c, err, "Failed to reload catalog")
+		return
+	}
+
+	c.JSON(http.StatusOK, ReloadResponse{Catalogs: []string{catalogName}, ReloadedAt: time.Now()})
+}
+
+// reloadAllCatalogsHandler handles reloading every catalog
+func reloadAllCatalogsHandler(c *gin.Context) {
+	// Get the message catalog service from the context
+	catalogService := c.MustGet("messageCatalogService").(Service)
+
+	ctx := c.Request.Context()
+	if err := catalogService.ReloadAllCatalogs(ctx); err != nil {
+		handleServiceError(c, err, "Failed to reload catalogs")
+		return
+	}
+
+	catalogs, err := catalogService.ListAvailableCatalogs(ctx)
+	if err != nil {
+		handleServiceError(c, err, "Failed to list catalogs")
+		return
+	}
+
+	c.JSON(http.StatusOK, ReloadResponse{Catalogs: catalogs, ReloadedAt: time.Now()})
+}
+
+// listEntriesHandler handles listing the editable messages of a catalog
+func listEntriesHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	catalogName := c.Param("catalog")
+	entries, err := editor.ListEntries(c.Request.Context(), catalogName)
+	if err != nil {
+		handleServiceError(c, err, "Failed to list catalog entries")
+		return
+	}
+	if entries == nil {
+		entries = []*CatalogEntry{}
+	}
+
+	c.JSON(http.StatusOK, EntryListResponse{CatalogName: catalogName, Entries: entries, Count: len(entries)})
+}
+
+// getEntryHandler handles getting one editable message
+func getEntryHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	entry, err := editor.GetEntry(c.Request.Context(), c.Param("catalog"), c.Param("code"))
+	if err != nil {
+		handleServiceError(c, err, "Failed to get catalog entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// createEntryHandler handles creating a message
+func createEntryHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	var req EntryRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	entry, err := editor.CreateEntry(c.Request.Context(), c.Param("catalog"), c.Param("code"), &req)
+	if err != nil {
+		handleServiceError(c, err, "Failed to create catalog entry")
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// updateEntryHandler handles updating a message
+func updateEntryHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	var req EntryRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	entry, err := editor.UpdateEntry(c.Request.Context(), c.Param("catalog"), c.Param("code"), &req)
+	if err != nil {
+		handleServiceError(c, err, "Failed to update catalog entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// deleteEntryHandler handles deleting a message
+func deleteEntryHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	version, ok := parseVersion(c, c.Query("version"), "version")
+	if !ok {
+		return
+	}
+
+	if err := editor.DeleteEntry(c.Request.Context(), c.Param("catalog"), c.Param("code"), version); err != nil {
+		handleServiceError(c, err, "Failed to delete catalog entry")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// putTranslationHandler handles creating or replacing a translation
+func putTranslationHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	var req TranslationRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	entry, err := editor.PutTranslation(c.Request.Context(), c.Param("catalog"), c.Param("code"), c.Param("language"), &req)
+	if err != nil {
+		handleServiceError(c, err, "Failed to save translation")
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// deleteTranslationHandler handles deleting a translation
+func deleteTranslationHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	version, ok := parseVersion(c, c.Query("version"), "version")
+	if !ok {
+		return
+	}
+
+	entry, err := editor.DeleteTranslation(c.Request.Context(), c.Param("catalog"), c.Param("code"), c.Param("language"), version)
+	if err != nil {
+		handleServiceError(c, err, "Failed to delete translation")
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// listVersionsHandler handles listing the history of a message
+func listVersionsHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	catalogName, messageCode := c.Param("catalog"), c.Param("code")
+	versions, err := editor.ListVersions(c.Request.Context(), catalogName, messageCode)
+	if err != nil {
+		handleServiceError(c, err, "Failed to list versions")
+		return
+	}
+
+	c.JSON(http.StatusOK, VersionListResponse{CatalogName: catalogName, MessageCode: messageCode, Versions: versions})
+}
+
+// getVersionHandler handles getting one version of a message
+func getVersionHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	version, ok := parseVersion(c, c.Param("version"), "version")
+	if !ok {
+		return
+	}
+
+	entryVersion, err := editor.GetVersion(c.Request.Context(), c.Param("catalog"), c.Param("code"), version)
+	if err != nil {
+		handleServiceError(c, err, "Failed to get version")
+		return
+	}
+
+	c.JSON(http.StatusOK, entryVersion)
+}
+
+// diffVersionsHandler handles comparing two versions of a message
+func diffVersionsHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	from, ok := parseVersion(c, c.Query("from"), "from")
+	if !ok {
+		return
+	}
+	to, ok := parseVersion(c, c.Query("to"), "to")
+	if !ok {
+		return
+	}
+
+	diff, err := editor.DiffVersions(c.Request.Context(), c.Param("catalog"), c.Param("code"), from, to)
+	if err != nil {
+		handleServiceError(c, err, "Failed to diff versions")
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// rollbackEntryHandler handles restoring an earlier version of a message
+func rollbackEntryHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	var req RollbackRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	entry, err := editor.Rollback(c.Request.Context(), c.Param("catalog"), c.Param("code"), &req)
+	if err != nil {
+		handleServiceError(c, err, "Failed to roll back catalog entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// exportTranslationsHandler handles exporting a catalog to XLIFF, PO or CSV
+func exportTranslationsHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	format, ok := exchangeFormat(c)
+	if !ok {
+		return
+	}
+
+	catalogName := c.Param("catalog")
+	document, err := editor.ExportTranslations(c.Request.Context(), catalogName, c.Query("source"), c.Query("target"))
+	if err != nil {
+		handleServiceError(c, err, "Failed to export translations")
+		return
+	}
+
+	var body bytes.Buffer
+	if err := catalogexchange.Encode(&body, format, document); err != nil {
+		handleServiceError(c, err, "Failed to export translations")
+		return
+	}
+
+	fileName := catalogName + "-" + document.TargetLanguage + catalogexchange.FileExtension(format)
+	c.Header("Content-Disposition", `attachment; filename="`+fileName+`"`)
+	c.Data(http.StatusOK, catalogexchange.ContentType(format), body.Bytes())
+}
+
+// importTranslationsHandler handles importing a translated XLIFF, PO or CSV file sent as the request body
+// include_fuzzy=true imports fuzzy translations; dry_run=true only reports
+func importTranslationsHandler(c *gin.Context) {
+	// Get the catalog editor from the context
+	editor := c.MustGet("catalogEditor").(Editor)
+
+	format, ok := exchangeFormat(c)
+	if !ok {
+		return
+	}
+
+	document, err := catalogexchange.Decode(c.Request.Body, format)
+	if err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid translation file", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	report, err := editor.ImportTranslations(c.Request.Context(), c.Param("catalog"), document, ImportOptions{
+		IncludeFuzzy: c.Query("include_fuzzy") == "true",
+		DryRun:       c.Query("dry_run") == "true",
+	})
+	if err != nil {
+		handleServiceError(c, err, "Failed to import translations")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// exchangeFormat reads the format query parameter (default xliff), responding with 400 when unknown
+func exchangeFormat(c *gin.Context) (string, bool) {
+	format := c.DefaultQuery("format", catalogexchange.FormatXLIFF)
+	if !slices.Contains(catalogexchange.Formats, format) {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid format",
+			"'format' must be one of xliff, po or csv", http.StatusBadRequest))
+		return "", false
+	}
+	return format, true
+}
+
+// bindJSON binds the request body, responding with 400 when it is invalid
+func bindJSON(c *gin.Context, target interface{}) bool {
+	if err := c.ShouldBindJSON(target); err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid request body", err.Error(), http.StatusBadRequest))
+		return false
+	}
+	return true
+}
+
+// parseVersion parses a version number, responding with 400 when it is missing or invalid
+func parseVersion(c *gin.Context, value, name string) (int, bool) {
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 0 {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid version",
+			"'"+name+"' must be a non-negative integer", http.StatusBadRequest))
+		return 0, false
+	}
+	return version, true
+}
+
+// respondWithMessages writes a message list response
+func respondWithMessages(c *gin.Context, catalogName string, messages []*MessageResponse) {
+	if messages == nil {
+		messages = []*MessageResponse{}
+	}
+	c.JSON(http.StatusOK, MessageListResponse{
+		CatalogName: catalogName,
+		Messages:    messages,
+		Count:       len(messages),
+	})
+}
+
+// handleServiceError responds with the AppError from the service or wraps unknown errors
+func handleServiceError(c *gin.Context, err error, message string) {
+	if appErr := errors.GetAppError(err); appErr != nil {
+		middleware.HandleAppError(c, appErr)
+		return
+	}
+	middleware.HandleAppError(c, errors.NewWithError(errors.ErrCodeInternalServer, message, http.StatusInternalServerError, err))
+}
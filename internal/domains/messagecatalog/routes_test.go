@@ -0,0 +1,257 @@
+package messagecatalog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/mocks"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/middleware"
+)
+
+func newTestRouter(t *testing.T) *gin.Engine {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	catalogConfig := config.MessageCatalogConfig{
+		DefaultLanguage: "en-US",
+		CacheEnabled:    true,
+		Catalogs: []config.CatalogConfig{{
+			Name:                "alert",
+			Path:                "../../../pkg/alert/catalog",
+			Enabled:             true,
+			StructureFile:       "messagecatelog.json",
+			LanguageFilePattern: "messagecatelog-{lang}.json",
+		}},
+	}
+	service := NewMessageCatalogService(catalogConfig, NewFileSystemLoader(catalogConfig.Catalogs, mockLogger),
+		NewLRUCacheManager(0, 0), mockLogger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandlerMiddleware(mockLogger))
+	router.Use(func(c *gin.Context) {
+		c.Set("messageCatalogService", service)
+		c.Next()
+	})
+	RegisterRoutes(router.Group("/api/v1"), middleware.APIKeyAuth(map[string]string{"ops": "key"}, mockLogger))
+	return router
+}
+
+func serve(router *gin.Engine, req *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestGetMessageHandler_AppliesQueryParametersAndLanguage(t *testing.T) {
+	router := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/catalogs/alert/messages/ABC0001?exp_date=2026-12-31", nil)
+	req.Header.Set("Accept-Language", "fr-FR,fr;q=0.9")
+	recorder := serve(router, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var message MessageResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &message))
+	assert.Equal(t, "fr-FR", message.Language)
+	assert.Contains(t, message.DetailedDescription, "2026-12-31")
+}
+
+func TestGetMessageHandler_UnknownCode(t *testing.T) {
+	router := newTestRouter(t)
+
+	recorder := serve(router, httptest.NewRequest(http.MethodGet, "/api/v1/catalogs/alert/messages/NOPE", nil))
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestRenderMessageHandler_UsesBodyParameters(t *testing.T) {
+	router := newTestRouter(t)
+
+	body := `{"language":"en-US","parameters":{"username":"alice"}}`
+	recorder := serve(router, httptest.NewRequest(http.MethodPost, "/api/v1/catalogs/alert/messages/ABC0002/render", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "alice")
+}
+
+func TestRenderMessagesHandler_ReturnsPerItemResults(t *testing.T) {
+	router := newTestRouter(t)
+
+	body := `{"catalog_name":"alert","items":[
+		{"message_code":"ABC0002","parameters":{"username":"alice"}},
+		{"message_code":"NOPE"},
+		{"message_code":"ABC0002","language":"en-US","parameters":{"username":"bob"}}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/catalogs/render", strings.NewReader(body))
+	req.Header.Set("Accept-Language", "fr-FR")
+	recorder := serve(router, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response BatchRenderResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Failed)
+	assert.Equal(t, "fr-FR", response.Results[0].Message.Language)
+	assert.Equal(t, http.StatusNotFound, response.Results[1].Status)
+	assert.Equal(t, errors.ErrCodeNotFound, response.Results[1].Error.Code)
+	assert.Equal(t, "en-US", response.Results[2].Message.Language)
+	assert.Contains(t, response.Results[2].Message.DetailedDescription, "bob")
+
+	recorder = serve(router, httptest.NewRequest(http.MethodPost, "/api/v1/catalogs/render", strings.NewReader(`{"items":[]}`)))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestSearchMessagesHandler(t *testing.T) {
+	router := newTestRouter(t)
+
+	recorder := serve(router, httptest.NewRequest(http.MethodGet, "/api/v1/catalogs/search?q=authentication&severity=high", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response SearchResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "en-US", response.Language)
+	require.NotEmpty(t, response.Results)
+	assert.Equal(t, "ABC0002", response.Results[0].MessageCode)
+
+	recorder = serve(router, httptest.NewRequest(http.MethodGet, "/api/v1/catalogs/search?q=x&limit=500", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestGetMessagesBySeverityHandler(t *testing.T) {
+	router := newTestRouter(t)
+
+	recorder := serve(router, httptest.NewRequest(http.MethodGet, "/api/v1/catalogs/alert/severities/CRITICAL", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response MessageListResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Count)
+	assert.Equal(t, "ABC0001", response.Messages[0].MessageCode)
+	assert.Equal(t, "en-US", response.Messages[0].Language)
+}
+
+func TestListCatalogsHandler(t *testing.T) {
+	router := newTestRouter(t)
+
+	recorder := serve(router, httptest.NewRequest(http.MethodGet, "/api/v1/catalogs", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"catalogs":["alert"]}`, recorder.Body.String())
+}
+
+func TestReloadCatalogHandler_RequiresAPIKey(t *testing.T) {
+	router := newTestRouter(t)
+
+	recorder := serve(router, httptest.NewRequest(http.MethodPost, "/api/v1/catalogs/alert/reload", nil))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/catalogs/alert/reload", nil)
+	req.Header.Set("Authorization", "Bearer key")
+	recorder = serve(router, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestEntryHandlers_VersionedEdits(t *testing.T) {
+	editor, service, _ := newTestEditor(t)
+	log := newQuietLogger(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandlerMiddleware(log))
+	router.Use(func(c *gin.Context) {
+		c.Set("messageCatalogService", service)
+		c.Set("catalogEditor", editor)
+		c.Next()
+	})
+	RegisterRoutes(router.Group("/api/v1"), middleware.APIKeyAuth(map[string]string{"translator": "key"}, log))
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer key")
+		return serve(router, req)
+	}
+
+	recorder := serve(router, httptest.NewRequest(http.MethodGet, "/api/v1/catalogs/test/entries/TST0001", nil))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	recorder = send(http.MethodPut, "/api/v1/catalogs/test/entries/TST0001/translations/fr-FR",
+		`{"message": "Connexion refusée pour {user}", "version": 1}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var entry CatalogEntry
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &entry))
+	assert.Equal(t, 2, entry.Version)
+	assert.Equal(t, "translator", entry.UpdatedBy)
+
+	recorder = send(http.MethodDelete, "/api/v1/catalogs/test/entries/TST0001?version=1", "")
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+
+	recorder = send(http.MethodGet, "/api/v1/catalogs/test/entries/TST0001/diff?from=1&to=2", "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "translations.fr-FR.message")
+
+	recorder = send(http.MethodPost, "/api/v1/catalogs/test/entries/TST0001/rollback", `{"to_version": 1, "version": 2}`)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = send(http.MethodGet, "/api/v1/catalogs/test/entries/TST0001/versions", "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var versions VersionListResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &versions))
+	assert.Len(t, versions.Versions, 3)
+
+	recorder = send(http.MethodGet, "/api/v1/catalogs/test/entries/TST0001/versions/abc", "")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestExchangeHandlers_ExportAndImport(t *testing.T) {
+	editor, service, _ := newTestEditor(t)
+	log := newQuietLogger(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandlerMiddleware(log))
+	router.Use(func(c *gin.Context) {
+		c.Set("messageCatalogService", service)
+		c.Set("catalogEditor", editor)
+		c.Next()
+	})
+	RegisterRoutes(router.Group("/api/v1"), middleware.APIKeyAuth(map[string]string{"translator": "key"}, log))
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer key")
+		return serve(router, req)
+	}
+
+	recorder := send(http.MethodGet, "/api/v1/catalogs/test/export?target=de-DE&format=po", "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, `attachment; filename="test-de-DE.po"`, recorder.Header().Get("Content-Disposition"))
+	assert.Contains(t, recorder.Body.String(), `msgctxt "TST0001.message"`)
+
+	po := strings.Replace(recorder.Body.String(), `msgid "Login failed for {user}"`+"\n"+`msgstr ""`,
+		`msgid "Login failed for {user}"`+"\n"+`msgstr "Anmeldung für {user} fehlgeschlagen"`, 1)
+	recorder = send(http.MethodPost, "/api/v1/catalogs/test/import?format=po", po)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var report ImportReport
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.Equal(t, "de-DE", report.Language)
+	assert.Equal(t, []string{"TST0001"}, report.Updated)
+
+	recorder = send(http.MethodPost, "/api/v1/catalogs/test/import?format=po", "msgid \"x\"\nmsgstr \"y\"\n")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = send(http.MethodGet, "/api/v1/catalogs/test/export?target=de-DE&format=docx", "")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,319 @@
+package messagecatalog
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/interfaces"
+)
+
+// Search result limits
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// fieldMessageCode is the search field of the message code itself
+const fieldMessageCode = "message_code"
+
+// searchFieldWeights ranks a match in the short message text above one in the
+// longer description or action; a code match ranks highest
+var searchFieldWeights = map[string]float64{
+	fieldMessageCode:         4,
+	fieldMessage:             2,
+	fieldResponseAction:      1.2,
+	fieldDetailedDescription: 1,
+}
+
+// prefixMatchWeight discounts a term that only matches as a prefix
+const prefixMatchWeight = 0.5
+
+// searchPosting records how often a term occurs in one field of one message
+type searchPosting struct {
+	messageCode string
+	field       string
+	count       int
+}
+
+// searchIndex is an inverted index over the texts of one catalog language
+// It is built with the language and replaced with it on reload, never updated in place
+type searchIndex struct {
+	postings  map[string][]searchPosting // term -> postings
+	frequency map[string]int             // term -> number of messages containing it
+	terms     []string                   // Sorted terms, for prefix matches
+	messages  int
+}
+
+// searchMatch accumulates the score of one message
+type searchMatch struct {
+	score  float64
+	fields map[string]bool
+}
+
+// newSearchIndex indexes the code, message, detailed description and response action of messages
+func newSearchIndex(messages map[string]*Message) *searchIndex {
+	index := &searchIndex{
+		postings:  make(map[string][]searchPosting),
+		frequency: make(map[string]int),
+		messages:  len(messages),
+	}
+
+	for messageCode, message := range messages {
+		seen := make(map[string]bool)
+		for field, text := range map[string]string{
+			fieldMessageCode:         message.MessageCode,
+			fieldMessage:             message.Message,
+			fieldDetailedDescription: message.DetailedDescription,
+			fieldResponseAction:      message.ResponseAction,
+		} {
+			counts := make(map[string]int)
+			for _, term := range searchTerms(text) {
+				counts[term]++
+			}
+			for term, count := range counts {
+				index.postings[term] = append(index.postings[term], searchPosting{messageCode: messageCode, field: field, count: count})
+				if !seen[term] {
+					seen[term] = true
+					index.frequency[term]++
+				}
+			}
+		}
+	}
+
+	index.terms = make([]string, 0, len(index.postings))
+	for term := range index.postings {
+		index.terms = append(index.terms, term)
+	}
+	sort.Strings(index.terms)
+	return index
+}
+
+// Approximate memory of one index term and of one posting
+const (
+	termOverhead    = 64
+	postingOverhead = 48
+)
+
+// size estimates the memory use of the index in bytes
+func (i *searchIndex) size() int64 {
+	size := int64(0)
+	for term, postings := range i.postings {
+		size += termOverhead + 2*int64(len(term)) + int64(len(postings))*postingOverhead
+	}
+	return size
+}
+
+// match returns the messages containing every query term with their TF-IDF score
+// The last term also matches longer terms it is a prefix of, so partial input finds results
+func (i *searchIndex) match(terms []string) map[string]*searchMatch {
+	var matches map[string]*searchMatch
+	for n, term := range terms {
+		termMatches := make(map[string]*searchMatch)
+		i.score(term, 1, termMatches)
+		if n == len(terms)-1 {
+			for _, longer := range i.withPrefix(term) {
+				i.score(longer, prefixMatchWeight, termMatches)
+			}
+		}
+
+		if matches == nil {
+			matches = termMatches
+			continue
+		}
+		for messageCode, match := range matches {
+			termMatch, found := termMatches[messageCode]
+			if !found {
+				delete(matches, messageCode)
+				continue
+			}
+			match.score += termMatch.score
+			for field := range termMatch.fields {
+				match.fields[field] = true
+			}
+		}
+	}
+	return matches
+}
+
+// score adds the weighted score of one index term to matches
+func (i *searchIndex) score(term string, weight float64, matches map[string]*searchMatch) {
+	postings := i.postings[term]
+	if len(postings) == 0 {
+		return
+	}
+	idf := math.Log(1 + float64(i.messages)/float64(i.frequency[term]))
+	for _, posting := range postings {
+		match, found := matches[posting.messageCode]
+		if !found {
+			match = &searchMatch{fields: make(map[string]bool)}
+			matches[posting.messageCode] = match
+		}
+		match.score += weight * searchFieldWeights[posting.field] * idf * (1 + math.Log(float64(posting.count)))
+		match.fields[posting.field] = true
+	}
+}
+
+// withPrefix returns the index terms that start with prefix, excluding prefix itself
+func (i *searchIndex) withPrefix(prefix string) []string {
+	start := sort.SearchStrings(i.terms, prefix)
+	end := start
+	for end < len(i.terms) && strings.HasPrefix(i.terms[end], prefix) {
+		end++
+	}
+	if start < end && i.terms[start] == prefix {
+		start++
+	}
+	return i.terms[start:end]
+}
+
+// searchTerms splits text into lowercase words without diacritics, so "Échec"
+// matches "echec"; the query is split the same way
+func searchTerms(text string) []string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		folded = text
+	}
+	return strings.FieldsFunc(strings.ToLower(folded), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// SearchMessages finds messages whose code or texts contain every word of the query
+// Each catalog is searched in the nearest available language of the fallback chain;
+// results are ranked by relevance and carry the raw (unrendered) message texts
+func (s *MessageCatalogService) SearchMessages(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	terms := uniqueTerms(searchTerms(req.Query))
+	if len(terms) == 0 {
+		return nil, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid search query",
+			"The query must contain at least one word", 400)
+	}
+
+	language := req.Language
+	if language == "" {
+		language = s.config.DefaultLanguage
+	}
+
+	catalogNames, err := s.searchCatalogs(req.CatalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SearchResponse{Query: req.Query, Language: language, Results: []*SearchResult{}}
+	for _, catalogName := range catalogNames {
+		entry := s.searchLanguage(ctx, catalogName, language)
+		if entry == nil {
+			continue
+		}
+
+		for messageCode, match := range entry.index.match(terms) {
+			message := entry.messages[messageCode]
+			if !matchesFilter(message.Category, req.Category) ||
+				!matchesFilter(message.Severity, req.Severity) ||
+				!matchesFilter(message.Component, req.Component) {
+				continue
+			}
+
+			fields := make([]string, 0, len(match.fields))
+			for field := range match.fields {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			response.Results = append(response.Results, &SearchResult{
+				CatalogName:   catalogName,
+				MessageCode:   messageCode,
+				Score:         math.Round(match.score*1000) / 1000,
+				MatchedFields: fields,
+				Message:       s.formatMessageResponse(message),
+			})
+		}
+	}
+
+	sort.Slice(response.Results, func(i, j int) bool {
+		a, b := response.Results[i], response.Results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.CatalogName != b.CatalogName {
+			return a.CatalogName < b.CatalogName
+		}
+		return a.MessageCode < b.MessageCode
+	})
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	response.Total = len(response.Results)
+	if len(response.Results) > limit {
+		response.Results = response.Results[:limit]
+	}
+
+	s.logger.Debug(ctx, "Searched message catalogs", interfaces.Fields{
+		"query":    req.Query,
+		"catalogs": len(catalogNames),
+		"language": language,
+		"total":    response.Total,
+	})
+	return response, nil
+}
+
+// searchCatalogs returns the catalog to search, or every enabled catalog
+func (s *MessageCatalogService) searchCatalogs(catalogName string) ([]string, error) {
+	if catalogName != "" {
+		if s.findCatalogConfig(catalogName) == nil {
+			return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog not found",
+				fmt.Sprintf("Catalog '%s' not found in configuration", catalogName), 404)
+		}
+		return []string{catalogName}, nil
+	}
+
+	var names []string
+	for _, catalog := range s.config.Catalogs {
+		if catalog.Enabled {
+			names = append(names, catalog.Name)
+		}
+	}
+	return names, nil
+}
+
+// searchLanguage returns the first language of the fallback chain the catalog has, or nil
+func (s *MessageCatalogService) searchLanguage(ctx context.Context, catalogName, language string) *cachedLanguage {
+	catalogConfig := s.findCatalogConfig(catalogName)
+	if catalogConfig == nil {
+		return nil
+	}
+	for _, candidate := range s.languageChain(ctx, catalogConfig, language) {
+		if entry := s.currentLanguage(ctx, catalogName, candidate); entry != nil && entry.index != nil {
+			return entry
+		}
+	}
+	return nil
+}
+
+// matchesFilter reports whether value passes an optional case-insensitive filter
+func matchesFilter(value, filter string) bool {
+	return filter == "" || strings.EqualFold(value, filter)
+}
+
+// uniqueTerms drops repeated terms, keeping their first position
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	unique := terms[:0]
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			unique = append(unique, term)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,100 @@
+package messagecatalog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/pkg/errors"
+)
+
+// writeSearchCatalog writes a three-message catalog in en-US and fr-FR
+func writeSearchCatalog(t *testing.T, dir, englishLogin string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog.json"), []byte(`{
+		"TST0001": {"message_code": "TST0001", "category": "Security", "severity": "HIGH", "component": "Auth"},
+		"TST0002": {"message_code": "TST0002", "category": "Security", "severity": "LOW", "component": "Session"},
+		"TST0003": {"message_code": "TST0003", "category": "Storage", "severity": "HIGH", "component": "Disk"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog-en-US.json"), []byte(`{
+		"TST0001": {"message": "`+englishLogin+`", "response_action": "Check the password"},
+		"TST0002": {"message": "Session expired", "detailed_description": "The login session of {user} expired"},
+		"TST0003": {"message": "Disk almost full", "response_action": "Free disk space"}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog-fr-FR.json"), []byte(`{
+		"TST0001": {"message": "Échec de connexion"},
+		"TST0003": {"message": "Disque presque plein"}}`), 0o644))
+}
+
+func resultCodes(response *SearchResponse) []string {
+	codes := make([]string, 0, len(response.Results))
+	for _, result := range response.Results {
+		codes = append(codes, result.MessageCode)
+	}
+	return codes
+}
+
+func TestSearchMessages_RanksAndFilters(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeSearchCatalog(t, dir, "Login failed")
+	service := newTestService(t, dir, 60)
+
+	// A match in the message text ranks above one in the description
+	response, err := service.SearchMessages(ctx, &SearchRequest{Query: "login"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TST0001", "TST0002"}, resultCodes(response))
+	assert.Equal(t, []string{fieldMessage}, response.Results[0].MatchedFields)
+	assert.Equal(t, []string{fieldDetailedDescription}, response.Results[1].MatchedFields)
+	assert.Greater(t, response.Results[0].Score, response.Results[1].Score)
+	assert.Equal(t, "Login failed", response.Results[0].Message.Message)
+
+	// Every word must match; the last one may be a prefix
+	response, err = service.SearchMessages(ctx, &SearchRequest{Query: "DISK sp"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TST0003"}, resultCodes(response))
+
+	response, err = service.SearchMessages(ctx, &SearchRequest{Query: "login", Severity: "low", Category: "security"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TST0002"}, resultCodes(response))
+
+	response, err = service.SearchMessages(ctx, &SearchRequest{Query: "tst0003"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TST0003"}, resultCodes(response))
+
+	response, err = service.SearchMessages(ctx, &SearchRequest{Query: "TST", Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, response.Total)
+	assert.Len(t, response.Results, 2)
+
+	_, err = service.SearchMessages(ctx, &SearchRequest{Query: " ?! "})
+	assert.Equal(t, errors.ErrCodeBadRequest, appErrorCode(err))
+
+	_, err = service.SearchMessages(ctx, &SearchRequest{Query: "login", CatalogName: "missing"})
+	assert.Equal(t, errors.ErrCodeNotFound, appErrorCode(err))
+}
+
+func TestSearchMessages_OtherLanguagesAndReload(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeSearchCatalog(t, dir, "Login failed")
+	service := newTestService(t, dir, 60)
+
+	// Diacritics are ignored, and fr-CA falls back to the fr-FR texts
+	response, err := service.SearchMessages(ctx, &SearchRequest{Query: "echec", Language: "fr-CA"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TST0001"}, resultCodes(response))
+	assert.Equal(t, "fr-FR", response.Results[0].Message.Language)
+
+	// The index is rebuilt with the catalog
+	writeSearchCatalog(t, dir, "Sign-in failed")
+	require.NoError(t, service.ReloadCatalog(ctx, "test"))
+
+	response, err = service.SearchMessages(ctx, &SearchRequest{Query: "sign failed"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TST0001"}, resultCodes(response))
+	response, err = service.SearchMessages(ctx, &SearchRequest{Query: "failed login"})
+	require.NoError(t, err)
+	assert.Empty(t, response.Results)
+}
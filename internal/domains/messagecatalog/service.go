@@ -2,45 +2,91 @@ package messagecatalog
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
-	"text/template"
 	"time"
 
 	"tushartemplategin/pkg/config"
 	"tushartemplategin/pkg/errors"
 	"tushartemplategin/pkg/interfaces"
+	"tushartemplategin/pkg/messageformat"
 )
 
 // MessageCatalogService implements the Service interface
 type MessageCatalogService struct {
-	config     config.MessageCatalogConfig
-	logger     interfaces.Logger
-	cache      map[string]map[string]map[string]*Message // catalog -> language -> messageCode -> Message
-	cacheMutex sync.RWMutex
-	lastReload map[string]time.Time
+	config config.MessageCatalogConfig
+	loader CatalogLoader
+	cache  CacheManager // "catalog/language" -> *cachedLanguage
+	logger interfaces.Logger
+	// reloadMutex guards lastReload, languages and catalogLocks; reloads hold it
+	// for writing while they swap a catalog's languages into the cache
+	reloadMutex  sync.RWMutex
+	lastReload   map[string]time.Time
+	languages    map[string]*availableLanguages
+	catalogLocks map[string]*sync.Mutex // Serialize reloads of one catalog
+
+	stopReload     chan struct{}
+	stopReloadOnce sync.Once
+	stopWatch      chan struct{}
+	stopWatchOnce  sync.Once
 }
 
-// NewMessageCatalogService creates a new message catalog service
-func NewMessageCatalogService(config config.MessageCatalogConfig, logger interfaces.Logger) Service {
-	service := &MessageCatalogService{
-		config:     config,
-		logger:     logger,
-		cache:      make(map[string]map[string]map[string]*Message),
-		lastReload: make(map[string]time.Time),
+// cachedLanguage holds every message of one catalog language
+// Entries are replaced as a whole so readers never see a half-loaded language
+type cachedLanguage struct {
+	messages map[string]*Message // messageCode -> Message
+	index    *searchIndex        // Full-text index of messages, built with them
+	size     int64               // Estimated memory use in bytes
+	loadedAt time.Time
+}
+
+// messageOverhead approximates the fixed memory of one cached message: the
+// Message struct, its map entry and its metadata map
+const messageOverhead = 400
+
+// newCachedLanguage wraps loaded messages with their search index and size
+func newCachedLanguage(messages map[string]*Message, loadedAt time.Time) *cachedLanguage {
+	index := newSearchIndex(messages)
+	size := index.size()
+	for _, message := range messages {
+		size += messageOverhead + int64(len(message.MessageCode)+len(message.Category)+len(message.Severity)+
+			len(message.Component)+len(message.Message)+len(message.DetailedDescription)+
+			len(message.ResponseAction)+len(message.Language)+len(message.CatalogName))
 	}
+	return &cachedLanguage{messages: messages, index: index, size: size, loadedAt: loadedAt}
+}
 
-	// Load only default language for all catalogs on startup
+// CacheSize returns the estimated memory use of the language, counted against cache_max_bytes
+func (c *cachedLanguage) CacheSize() int64 {
+	return c.size
+}
+
+// NewMessageCatalogService creates a new message catalog service
+// Catalog data is read through loader and whole languages are kept in cache
+func NewMessageCatalogService(config config.MessageCatalogConfig, loader CatalogLoader, cache CacheManager, logger interfaces.Logger) Service {
+	service := &MessageCatalogService{
+		config:       config,
+		loader:       loader,
+		cache:        cache,
+		logger:       logger,
+		lastReload:   make(map[string]time.Time),
+		languages:    make(map[string]*availableLanguages),
+		catalogLocks: make(map[string]*sync.Mutex),
+		stopReload:   make(chan struct{}),
+		stopWatch:    make(chan struct{}),
+	}
+
+	// Load the default language for all catalogs on startup, then the configured
+	// preload languages; other languages are loaded on their first lookup
 	if err := service.LoadDefaultLanguageCatalogs(context.Background()); err != nil {
 		logger.Error(context.Background(), "Failed to load initial message catalogs", interfaces.Fields{
 			"error": err.Error(),
 		})
 	}
+	service.PreloadCatalogLanguages(context.Background())
 
 	return service
 }
@@ -54,53 +100,39 @@ func (s *MessageCatalogService) GetMessage(ctx context.Context, req *MessageRequ
 	})
 
 	// Use default language if not specified
-	if req.Language == "" {
-		req.Language = s.config.DefaultLanguage
-	}
-
-	// Check cache first
-	if s.config.CacheEnabled {
-		s.cacheMutex.RLock()
-		if catalogCache, exists := s.cache[req.CatalogName]; exists {
-			if langCache, exists := catalogCache[req.Language]; exists {
-				if message, exists := langCache[req.MessageCode]; exists {
-					s.cacheMutex.RUnlock()
-					return s.formatMessageResponse(message, req.Parameters), nil
-				}
-			}
-		}
-		s.cacheMutex.RUnlock()
+	requested := req.Language
+	if requested == "" {
+		requested = s.config.DefaultLanguage
 	}
 
-	// Load message from files
-	message, err := s.loadMessageFromFiles(ctx, req.CatalogName, req.MessageCode, req.Language)
+	message, fieldLanguages, err := s.findMessage(ctx, req.CatalogName, req.MessageCode, requested)
 	if err != nil {
 		return nil, err
 	}
+	return s.renderMessage(ctx, message, fieldLanguages, requested, req.Parameters)
+}
 
-	// Update cache
-	if s.config.CacheEnabled {
-		s.cacheMutex.Lock()
-		if s.cache[req.CatalogName] == nil {
-			s.cache[req.CatalogName] = make(map[string]map[string]*Message)
-		}
-		if s.cache[req.CatalogName][req.Language] == nil {
-			s.cache[req.CatalogName][req.Language] = make(map[string]*Message)
-		}
-		s.cache[req.CatalogName][req.Language][req.MessageCode] = message
-		s.cacheMutex.Unlock()
-
-		// Log when loading a non-default language on-demand
-		if req.Language != s.config.DefaultLanguage {
-			s.logger.Info(ctx, "Loaded non-default language on-demand", interfaces.Fields{
-				"catalog_name": req.CatalogName,
-				"language":     req.Language,
-				"message_code": req.MessageCode,
-			})
-		}
+// findMessage resolves a message in the requested language
+// It walks the fallback chain (fr-CA -> fr -> fr-FR -> default); cached languages
+// are served from the cache, expired or missing ones are loaded as a whole
+func (s *MessageCatalogService) findMessage(ctx context.Context, catalogName, messageCode, requested string) (*Message, map[string]string, error) {
+	catalogConfig := s.findCatalogConfig(catalogName)
+	if catalogConfig == nil {
+		return nil, nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog not found",
+			fmt.Sprintf("Catalog '%s' not found in configuration", catalogName), 404)
 	}
+	return s.resolveMessage(ctx, catalogName, messageCode, s.languageChain(ctx, catalogConfig, requested))
+}
 
-	return s.formatMessageResponse(message, req.Parameters), nil
+// renderMessage builds the response for a resolved message with its parameters rendered
+func (s *MessageCatalogService) renderMessage(ctx context.Context, message *Message, fieldLanguages map[string]string, requested string, parameters map[string]interface{}) (*MessageResponse, error) {
+	response := s.formatMessageResponse(message)
+	if err := s.renderParameters(ctx, response, parameters); err != nil {
+		return nil, err
+	}
+	response.RequestedLanguage = requested
+	response.FallbackFields = fallbackFields(response.Language, fieldLanguages)
+	return response, nil
 }
 
 // LoadDefaultLanguageCatalogs loads only the default language for all enabled catalogs
@@ -132,79 +164,85 @@ func (s *MessageCatalogService) LoadCatalogDefaultLanguage(ctx context.Context,
 		"default_language": s.config.DefaultLanguage,
 	})
 
-	// Find catalog configuration
-	var catalogConfig *config.CatalogConfig
-	for _, catalog := range s.config.Catalogs {
-		if catalog.Name == catalogName {
-			catalogConfig = &catalog
-			break
-		}
-	}
-
+	catalogConfig := s.findCatalogConfig(catalogName)
 	if catalogConfig == nil {
 		return errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog not found",
 			fmt.Sprintf("Catalog '%s' not found in configuration", catalogName), 404)
 	}
 
-	// Load structure file
-	structureData, err := s.loadStructureFile(ctx, catalogConfig)
+	// Load structure and default language file
+	entry, err := s.loadDefaultLanguage(ctx, catalogConfig)
 	if err != nil {
 		return err
 	}
 
-	// Load only default language file
-	languageData, err := s.loadLanguageFile(ctx, catalogConfig, s.config.DefaultLanguage)
-	if err != nil {
-		s.logger.Warn(ctx, "Failed to load default language file", interfaces.Fields{
-			"catalog_name":     catalogName,
-			"default_language": s.config.DefaultLanguage,
-			"error":            err.Error(),
-		})
-		languageData = make(map[string]interface{})
-	}
+	s.setCachedLanguage(ctx, catalogName, s.config.DefaultLanguage, entry)
+	s.setLastReload(catalogName, entry.loadedAt)
 
-	// Initialize catalog cache with only default language
-	catalogMessages := make(map[string]map[string]*Message) // language -> messageCode -> Message
-	catalogMessages[s.config.DefaultLanguage] = make(map[string]*Message)
+	s.logger.Info(ctx, "Default language catalog loaded successfully", interfaces.Fields{
+		"catalog_name":     catalogName,
+		"default_language": s.config.DefaultLanguage,
+		"message_count":    len(entry.messages),
+	})
 
-	// Combine structure and default language data
-	for messageCode, messageStructure := range structureData {
-		structure, ok := messageStructure.(map[string]interface{})
-		if !ok {
+	return nil
+}
+
+// PreloadCatalogLanguages loads the preload_languages of every enabled catalog
+// A language that fails to load is logged and left to load on its first lookup
+func (s *MessageCatalogService) PreloadCatalogLanguages(ctx context.Context) {
+	for i := range s.config.Catalogs {
+		catalogConfig := &s.config.Catalogs[i]
+		if !catalogConfig.Enabled {
 			continue
 		}
 
-		languageContent, exists := languageData[messageCode]
-		if !exists {
-			languageContent = make(map[string]interface{})
+		var loaded []string
+		for _, language := range s.preloadLanguages(ctx, catalogConfig) {
+			entry, found, err := s.loadLanguageMessages(ctx, catalogConfig, language)
+			if err != nil || !found {
+				fields := interfaces.Fields{"catalog_name": catalogConfig.Name, "language": language}
+				if err != nil {
+					fields["error"] = err.Error()
+				}
+				s.logger.Warn(ctx, "Failed to preload catalog language", fields)
+				continue
+			}
+			s.setCachedLanguage(ctx, catalogConfig.Name, language, entry)
+			loaded = append(loaded, language)
 		}
 
-		// Type assert to map[string]interface{}
-		languageMap, ok := languageContent.(map[string]interface{})
-		if !ok {
-			languageMap = make(map[string]interface{})
+		if len(loaded) > 0 {
+			s.logger.Info(ctx, "Preloaded catalog languages", interfaces.Fields{
+				"catalog_name": catalogConfig.Name,
+				"languages":    loaded,
+			})
 		}
-
-		message := s.combineMessageData(structure, languageMap, catalogName, s.config.DefaultLanguage)
-		catalogMessages[s.config.DefaultLanguage][messageCode] = message
 	}
+}
 
-	// Update cache atomically
-	s.cacheMutex.Lock()
-	s.cache[catalogName] = catalogMessages
-	s.lastReload[catalogName] = time.Now()
-	s.cacheMutex.Unlock()
-
-	// Count total messages for default language
-	totalMessages := len(catalogMessages[s.config.DefaultLanguage])
-
-	s.logger.Info(ctx, "Default language catalog loaded successfully", interfaces.Fields{
-		"catalog_name":     catalogName,
-		"default_language": s.config.DefaultLanguage,
-		"message_count":    totalMessages,
-	})
+// preloadLanguages returns the configured preload languages of a catalog other
+// than the default language, expanding "*" to every available language
+func (s *MessageCatalogService) preloadLanguages(ctx context.Context, catalogConfig *config.CatalogConfig) []string {
+	configured := catalogConfig.PreloadLanguages
+	if slices.Contains(configured, "*") {
+		available, err := s.discoverAvailableLanguages(ctx, catalogConfig)
+		if err != nil {
+			s.logger.Warn(ctx, "Failed to discover languages to preload", interfaces.Fields{
+				"catalog_name": catalogConfig.Name,
+				"error":        err.Error(),
+			})
+		}
+		configured = available
+	}
 
-	return nil
+	languages := make([]string, 0, len(configured))
+	for _, language := range configured {
+		if language != s.config.DefaultLanguage && !slices.Contains(languages, language) {
+			languages = append(languages, language)
+		}
+	}
+	return languages
 }
 
 // GetMessageByCode retrieves a message by code, catalog, and language
@@ -225,181 +263,154 @@ func (s *MessageCatalogService) GetMessagesByCategory(ctx context.Context, categ
 		"language":     language,
 	})
 
-	// Find catalog configuration
-	var catalogConfig *config.CatalogConfig
-	for _, catalog := range s.config.Catalogs {
-		if catalog.Name == catalogName {
-			catalogConfig = &catalog
-			break
-		}
+	return s.messagesWhere(ctx, catalogName, language, func(message *Message) bool {
+		return message.Category == category
+	})
+}
+
+// GetMessagesBySeverity retrieves all messages with a specific severity
+func (s *MessageCatalogService) GetMessagesBySeverity(ctx context.Context, severity, catalogName, language string) ([]*MessageResponse, error) {
+	s.logger.Debug(ctx, "Getting messages by severity", interfaces.Fields{
+		"severity":     severity,
+		"catalog_name": catalogName,
+		"language":     language,
+	})
+
+	return s.messagesWhere(ctx, catalogName, language, func(message *Message) bool {
+		return message.Severity == severity
+	})
+}
+
+// messagesWhere resolves every message of a catalog whose structure matches, in code order
+func (s *MessageCatalogService) messagesWhere(ctx context.Context, catalogName, language string, match func(*Message) bool) ([]*MessageResponse, error) {
+	// Use default language if not specified
+	if language == "" {
+		language = s.config.DefaultLanguage
 	}
 
+	// Find catalog configuration
+	catalogConfig := s.findCatalogConfig(catalogName)
 	if catalogConfig == nil {
 		return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog not found",
 			fmt.Sprintf("Catalog '%s' not found in configuration", catalogName), 404)
 	}
 
-	// Load structure file to get all messages
-	structureData, err := s.loadStructureFile(ctx, catalogConfig)
+	structure, err := s.structureMessages(ctx, catalogConfig)
 	if err != nil {
 		return nil, err
 	}
+	chain := s.languageChain(ctx, catalogConfig, language)
 
 	var messages []*MessageResponse
-	for messageCode, messageData := range structureData {
-		messageStructure, ok := messageData.(map[string]interface{})
-		if !ok {
+	for messageCode, candidate := range structure {
+		if !match(candidate) {
 			continue
 		}
 
-		// Check if message belongs to the requested category
-		if msgCategory, exists := messageStructure["category"]; exists {
-			if categoryStr, ok := msgCategory.(string); ok && categoryStr == category {
-				// Load the complete message
-				message, err := s.loadMessageFromFiles(ctx, catalogName, messageCode, language)
-				if err != nil {
-					s.logger.Warn(ctx, "Failed to load message", interfaces.Fields{
-						"message_code": messageCode,
-						"error":        err.Error(),
-					})
-					continue
-				}
-				messages = append(messages, s.formatMessageResponse(message, nil))
-			}
+		message, _, err := s.resolveMessage(ctx, catalogName, messageCode, chain)
+		if err != nil {
+			s.logger.Warn(ctx, "Failed to load message", interfaces.Fields{
+				"message_code": messageCode,
+				"error":        err.Error(),
+			})
+			continue
 		}
+		messages = append(messages, s.formatMessageResponse(message))
 	}
 
+	// Map iteration order is random; return messages in code order
+	sort.Slice(messages, func(i, j int) bool { return messages[i].MessageCode < messages[j].MessageCode })
+
 	return messages, nil
 }
 
-// GetMessagesBySeverity retrieves all messages with a specific severity
-func (s *MessageCatalogService) GetMessagesBySeverity(ctx context.Context, severity, catalogName, language string) ([]*MessageResponse, error) {
-	s.logger.Debug(ctx, "Getting messages by severity", interfaces.Fields{
-		"severity":     severity,
-		"catalog_name": catalogName,
-		"language":     language,
-	})
-
-	// Find catalog configuration
-	var catalogConfig *config.CatalogConfig
-	for _, catalog := range s.config.Catalogs {
-		if catalog.Name == catalogName {
-			catalogConfig = &catalog
-			break
+// structureMessages returns every message of a catalog with its structure fields
+// With caching enabled they come from the cached default language, which holds
+// every code of the structure file; otherwise the structure file is read
+func (s *MessageCatalogService) structureMessages(ctx context.Context, catalogConfig *config.CatalogConfig) (map[string]*Message, error) {
+	if s.config.CacheEnabled {
+		if entry := s.currentLanguage(ctx, catalogConfig.Name, s.config.DefaultLanguage); entry != nil {
+			return entry.messages, nil
 		}
 	}
 
-	if catalogConfig == nil {
-		return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog not found",
-			fmt.Sprintf("Catalog '%s' not found in configuration", catalogName), 404)
-	}
-
-	// Load structure file to get all messages
-	structureData, err := s.loadStructureFile(ctx, catalogConfig)
+	structureData, err := s.loader.LoadCatalogStructure(ctx, catalogConfig.Name)
 	if err != nil {
 		return nil, err
 	}
-
-	var messages []*MessageResponse
+	messages := make(map[string]*Message, len(structureData))
 	for messageCode, messageData := range structureData {
-		messageStructure, ok := messageData.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		// Check if message has the requested severity
-		if msgSeverity, exists := messageStructure["severity"]; exists {
-			if severityStr, ok := msgSeverity.(string); ok && severityStr == severity {
-				// Load the complete message
-				message, err := s.loadMessageFromFiles(ctx, catalogName, messageCode, language)
-				if err != nil {
-					s.logger.Warn(ctx, "Failed to load message", interfaces.Fields{
-						"message_code": messageCode,
-						"error":        err.Error(),
-					})
-					continue
-				}
-				messages = append(messages, s.formatMessageResponse(message, nil))
-			}
+		if structure, ok := messageData.(map[string]interface{}); ok {
+			messages[messageCode] = s.combineMessageData(structure, nil, catalogConfig.Name, s.config.DefaultLanguage)
 		}
 	}
-
 	return messages, nil
 }
 
 // ReloadCatalog reloads a specific catalog
+// The default language and every language currently cached are reloaded and
+// swapped in together; on failure the previous data keeps being served.
+// Reloads from the API, the auto reload and the watcher are serialized per catalog
 func (s *MessageCatalogService) ReloadCatalog(ctx context.Context, catalogName string) error {
+	catalogConfig := s.findCatalogConfig(catalogName)
+	if catalogConfig == nil {
+		return errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog not found",
+			fmt.Sprintf("Catalog '%s' not found in configuration", catalogName), 404)
+	}
+
+	lock := s.catalogLock(catalogName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	return s.reloadCatalog(ctx, catalogConfig)
+}
+
+// reloadCatalog reloads a catalog; the caller holds its catalog lock
+func (s *MessageCatalogService) reloadCatalog(ctx context.Context, catalogConfig *config.CatalogConfig) error {
+	catalogName := catalogConfig.Name
 	s.logger.Info(ctx, "Reloading catalog", interfaces.Fields{
 		"catalog_name": catalogName,
 	})
 
-	// Find catalog configuration
-	var catalogConfig *config.CatalogConfig
-	for _, catalog := range s.config.Catalogs {
-		if catalog.Name == catalogName {
-			catalogConfig = &catalog
-			break
+	// Snapshot the languages to reload: the cached ones and the preload languages
+	languages := s.preloadLanguages(ctx, catalogConfig)
+	for language := range s.cachedLanguages(ctx, catalogName) {
+		if language != s.config.DefaultLanguage && !slices.Contains(languages, language) {
+			languages = append(languages, language)
 		}
 	}
 
-	if catalogConfig == nil {
-		return errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog not found",
-			fmt.Sprintf("Catalog '%s' not found in configuration", catalogName), 404)
-	}
-
-	// Load structure file
-	structureData, err := s.loadStructureFile(ctx, catalogConfig)
+	// Load structure and default language
+	defaultEntry, err := s.loadDefaultLanguage(ctx, catalogConfig)
 	if err != nil {
 		return err
 	}
+	catalogMessages := map[string]*cachedLanguage{s.config.DefaultLanguage: defaultEntry}
 
-	// Load only default language
-	catalogMessages := make(map[string]map[string]*Message) // language -> messageCode -> Message
-	languageData, err := s.loadLanguageFile(ctx, catalogConfig, s.config.DefaultLanguage)
-	if err != nil {
-		s.logger.Warn(ctx, "Failed to load default language file", interfaces.Fields{
-			"catalog_name":     catalogName,
-			"default_language": s.config.DefaultLanguage,
-			"error":            err.Error(),
-		})
-		languageData = make(map[string]interface{})
-	}
-
-	// Initialize default language cache
-	catalogMessages[s.config.DefaultLanguage] = make(map[string]*Message)
-
-	// Combine structure and default language data
-	for messageCode, messageStructure := range structureData {
-		structure, ok := messageStructure.(map[string]interface{})
-		if !ok {
-			continue
+	// Reload other cached languages; languages whose file was removed are dropped
+	for _, language := range languages {
+		entry, found, err := s.loadLanguageMessages(ctx, catalogConfig, language)
+		if err != nil {
+			return err
 		}
-
-		languageContent, exists := languageData[messageCode]
-		if !exists {
-			languageContent = make(map[string]interface{})
+		if found {
+			catalogMessages[language] = entry
 		}
+	}
 
-		// Type assert to map[string]interface{}
-		languageMap, ok := languageContent.(map[string]interface{})
-		if !ok {
-			languageMap = make(map[string]interface{})
+	// Swap the reloaded languages in only once all of them loaded
+	var dropped []string
+	for _, language := range languages {
+		if _, found := catalogMessages[language]; !found {
+			dropped = append(dropped, language)
 		}
-
-		message := s.combineMessageData(structure, languageMap, catalogName, s.config.DefaultLanguage)
-		catalogMessages[s.config.DefaultLanguage][messageCode] = message
 	}
-
-	// Update cache atomically
-	s.cacheMutex.Lock()
-	s.cache[catalogName] = catalogMessages
-	s.lastReload[catalogName] = time.Now()
-	s.cacheMutex.Unlock()
+	s.swapCatalogLanguages(ctx, catalogName, catalogMessages, dropped)
 
 	// Count total messages across all languages
 	totalMessages := 0
-	for _, langMessages := range catalogMessages {
-		totalMessages += len(langMessages)
+	for _, entry := range catalogMessages {
+		totalMessages += len(entry.messages)
 	}
 
 	s.logger.Info(ctx, "Catalog reloaded successfully", interfaces.Fields{
@@ -431,6 +442,52 @@ func (s *MessageCatalogService) ReloadAllCatalogs(ctx context.Context) error {
 	return nil
 }
 
+// StartAutoReload reloads every enabled catalog each ReloadInterval until
+// StopAutoReload is called or ctx is cancelled
+// A catalog that fails to reload keeps serving its previous data
+func (s *MessageCatalogService) StartAutoReload(ctx context.Context) {
+	if s.config.ReloadInterval <= 0 {
+		s.logger.Info(ctx, "Message catalog auto reload disabled", interfaces.Fields{})
+		return
+	}
+
+	interval := time.Duration(s.config.ReloadInterval) * time.Second
+	s.logger.Info(ctx, "Starting message catalog auto reload", interfaces.Fields{
+		"interval": interval.String(),
+	})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				for _, catalog := range s.config.Catalogs {
+					if !catalog.Enabled {
+						continue
+					}
+					if err := s.ReloadCatalog(ctx, catalog.Name); err != nil {
+						s.logger.Error(ctx, "Scheduled catalog reload failed, keeping previous data", interfaces.Fields{
+							"catalog_name": catalog.Name,
+							"error":        err.Error(),
+						})
+					}
+				}
+			case <-s.stopReload:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// StopAutoReload stops the background reload loop
+func (s *MessageCatalogService) StopAutoReload() {
+	s.stopReloadOnce.Do(func() { close(s.stopReload) })
+}
+
 // HealthCheck checks the health of the message catalog service
 func (s *MessageCatalogService) HealthCheck(ctx context.Context) error {
 	s.logger.Debug(ctx, "Performing health check", interfaces.Fields{})
@@ -442,7 +499,7 @@ func (s *MessageCatalogService) HealthCheck(ctx context.Context) error {
 	}
 
 	if len(catalogs) == 0 {
-		return errors.NewWithDetails(errors.ErrCodeInternalServer, "No catalogs available", "No catalogs are loaded", 500)
+		return errors.NewWithDetails(errors.ErrCodeInternalServer, "No catalogs available", "No catalogs are enabled", 500)
 	}
 
 	s.logger.Debug(ctx, "Health check passed", interfaces.Fields{
@@ -454,14 +511,16 @@ func (s *MessageCatalogService) HealthCheck(ctx context.Context) error {
 
 // ListAvailableCatalogs returns a list of available catalogs
 func (s *MessageCatalogService) ListAvailableCatalogs(ctx context.Context) ([]string, error) {
-	s.cacheMutex.RLock()
-	defer s.cacheMutex.RUnlock()
-
-	var catalogs []string
-	for catalogName := range s.cache {
-		catalogs = append(catalogs, catalogName)
+	// List enabled catalogs from configuration; the cache only holds catalogs
+	// that have already been requested
+	catalogs := []string{}
+	for _, catalog := range s.config.Catalogs {
+		if catalog.Enabled {
+			catalogs = append(catalogs, catalog.Name)
+		}
 	}
 
+	sort.Strings(catalogs)
 	return catalogs, nil
 }
 
@@ -472,52 +531,35 @@ func (s *MessageCatalogService) ListAvailableLanguages(ctx context.Context, cata
 	})
 
 	// Find catalog configuration
-	var catalogConfig *config.CatalogConfig
-	for _, catalog := range s.config.Catalogs {
-		if catalog.Name == catalogName {
-			catalogConfig = &catalog
-			break
-		}
-	}
-
+	catalogConfig := s.findCatalogConfig(catalogName)
 	if catalogConfig == nil {
 		return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog not found",
 			fmt.Sprintf("Catalog '%s' not found in configuration", catalogName), 404)
 	}
 
-	// Discover available languages dynamically
-	return s.discoverAvailableLanguages(ctx, catalogConfig)
+	// Languages are listed by the loader and cached like messages
+	return s.catalogLanguages(ctx, catalogConfig), nil
 }
 
-// discoverAvailableLanguages discovers available language files for a catalog
+// discoverAvailableLanguages lists the languages of a catalog, default language first
 func (s *MessageCatalogService) discoverAvailableLanguages(ctx context.Context, catalogConfig *config.CatalogConfig) ([]string, error) {
 	languages := []string{}
 
 	// Always include default language
 	languages = append(languages, s.config.DefaultLanguage)
 
-	// Scan directory for language files matching the pattern
-	pattern := filepath.Join(catalogConfig.Path, strings.Replace(catalogConfig.LanguageFilePattern, "{lang}", "*", 1))
-	matches, err := filepath.Glob(pattern)
+	available, err := s.loader.ListAvailableLanguages(ctx, catalogConfig.Name)
 	if err != nil {
-		s.logger.Warn(ctx, "Failed to scan for language files", interfaces.Fields{
+		s.logger.Warn(ctx, "Failed to list catalog languages", interfaces.Fields{
 			"catalog_name": catalogConfig.Name,
-			"pattern":      pattern,
 			"error":        err.Error(),
 		})
 		return languages, nil // Return at least default language
 	}
 
-	// Extract language codes from file names
-	for _, match := range matches {
-		filename := filepath.Base(match)
-		// Extract language from filename like "messagecatelog-en-US.json"
-		if strings.HasPrefix(filename, "messagecatelog-") && strings.HasSuffix(filename, ".json") {
-			langCode := strings.TrimPrefix(filename, "messagecatelog-")
-			langCode = strings.TrimSuffix(langCode, ".json")
-			if langCode != s.config.DefaultLanguage {
-				languages = append(languages, langCode)
-			}
+	for _, langCode := range available {
+		if langCode != s.config.DefaultLanguage {
+			languages = append(languages, langCode)
 		}
 	}
 
@@ -531,34 +573,25 @@ func (s *MessageCatalogService) discoverAvailableLanguages(ctx context.Context,
 
 // GetCatalogInfo returns information about a specific catalog
 func (s *MessageCatalogService) GetCatalogInfo(ctx context.Context, catalogName string) (*CatalogInfo, error) {
-	s.cacheMutex.RLock()
-	defer s.cacheMutex.RUnlock()
-
-	// Find catalog configuration
-	var catalogConfig *config.CatalogConfig
-	for _, catalog := range s.config.Catalogs {
-		if catalog.Name == catalogName {
-			catalogConfig = &catalog
-			break
-		}
-	}
-
+	catalogConfig := s.findCatalogConfig(catalogName)
 	if catalogConfig == nil {
 		return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog not found",
 			fmt.Sprintf("Catalog '%s' not found in configuration", catalogName), 404)
 	}
 
 	messageCount := 0
-	if catalogCache, exists := s.cache[catalogName]; exists {
-		for _, langMessages := range catalogCache {
-			messageCount += len(langMessages)
-		}
+	memoryBytes := int64(0)
+	loadedLanguages := []string{}
+	for language, entry := range s.cachedLanguages(ctx, catalogName) {
+		messageCount += len(entry.messages)
+		memoryBytes += entry.size
+		loadedLanguages = append(loadedLanguages, language)
 	}
+	sort.Strings(loadedLanguages)
 
-	lastReloaded := time.Time{}
-	if reloadTime, exists := s.lastReload[catalogName]; exists {
-		lastReloaded = reloadTime
-	}
+	s.reloadMutex.RLock()
+	lastReloaded := s.lastReload[catalogName]
+	s.reloadMutex.RUnlock()
 
 	// Get available languages dynamically
 	availableLanguages, err := s.discoverAvailableLanguages(ctx, catalogConfig)
@@ -571,93 +604,301 @@ func (s *MessageCatalogService) GetCatalogInfo(ctx context.Context, catalogName
 	}
 
 	return &CatalogInfo{
-		Name:         catalogName,
-		Path:         catalogConfig.Path,
-		Enabled:      catalogConfig.Enabled,
-		Languages:    availableLanguages,
-		MessageCount: messageCount,
-		LastReloaded: lastReloaded,
+		Name:            catalogName,
+		Path:            catalogConfig.Path,
+		Source:          catalogSource(catalogConfig),
+		Enabled:         catalogConfig.Enabled,
+		Languages:       availableLanguages,
+		LoadedLanguages: loadedLanguages,
+		MessageCount:    messageCount,
+		MemoryBytes:     memoryBytes,
+		LastReloaded:    lastReloaded,
 	}, nil
 }
 
-// GetCatalogStats returns statistics about all catalogs
+// GetCatalogStats returns statistics about all loaded catalogs and the cache
 func (s *MessageCatalogService) GetCatalogStats(ctx context.Context) (*CatalogStats, error) {
-	s.cacheMutex.RLock()
-	defer s.cacheMutex.RUnlock()
-
-	// Count total unique languages across all catalogs
-	allLanguages := make(map[string]bool)
-	for catalogName := range s.cache {
-		// Find catalog configuration
-		var catalogConfig *config.CatalogConfig
-		for _, catalog := range s.config.Catalogs {
-			if catalog.Name == catalogName {
-				catalogConfig = &catalog
-				break
-			}
-		}
-		if catalogConfig != nil {
-			availableLanguages, err := s.discoverAvailableLanguages(ctx, catalogConfig)
-			if err == nil {
-				for _, lang := range availableLanguages {
-					allLanguages[lang] = true
-				}
-			}
-		}
-	}
-
 	stats := &CatalogStats{
-		TotalCatalogs:     len(s.cache),
-		TotalMessages:     0,
-		LanguagesCount:    len(allLanguages),
 		Catalogs:          []CatalogInfo{},
 		MessagesByCatalog: make(map[string]int),
 		LastReloaded:      time.Time{},
+		Cache:             s.cache.GetStats(ctx),
 	}
 
-	for catalogName, catalogCache := range s.cache {
-		messageCount := 0
-		for _, langMessages := range catalogCache {
-			messageCount += len(langMessages)
+	allLanguages := make(map[string]bool)
+	for _, catalog := range s.config.Catalogs {
+		if !catalog.Enabled || len(s.cachedLanguages(ctx, catalog.Name)) == 0 {
+			continue
 		}
-		stats.TotalMessages += messageCount
-		stats.MessagesByCatalog[catalogName] = messageCount
 
-		// Get catalog info
-		catalogInfo, err := s.GetCatalogInfo(ctx, catalogName)
-		if err == nil {
-			stats.Catalogs = append(stats.Catalogs, *catalogInfo)
+		catalogInfo, err := s.GetCatalogInfo(ctx, catalog.Name)
+		if err != nil {
+			continue
+		}
+
+		stats.TotalCatalogs++
+		stats.TotalMessages += catalogInfo.MessageCount
+		stats.MemoryBytes += catalogInfo.MemoryBytes
+		stats.MessagesByCatalog[catalog.Name] = catalogInfo.MessageCount
+		stats.Catalogs = append(stats.Catalogs, *catalogInfo)
+
+		// Count total unique languages across all catalogs
+		for _, lang := range catalogInfo.Languages {
+			allLanguages[lang] = true
 		}
 
 		// Track latest reload time
-		if reloadTime, exists := s.lastReload[catalogName]; exists {
-			if reloadTime.After(stats.LastReloaded) {
-				stats.LastReloaded = reloadTime
-			}
+		if catalogInfo.LastReloaded.After(stats.LastReloaded) {
+			stats.LastReloaded = catalogInfo.LastReloaded
 		}
 	}
+	stats.LanguagesCount = len(allLanguages)
 
 	return stats, nil
 }
 
-// loadMessageFromFiles loads and combines structure and language files
-func (s *MessageCatalogService) loadMessageFromFiles(ctx context.Context, catalogName, messageCode, language string) (*Message, error) {
-	// Find catalog configuration
-	var catalogConfig *config.CatalogConfig
-	for _, catalog := range s.config.Catalogs {
-		if catalog.Name == catalogName {
-			catalogConfig = &catalog
-			break
+// findCatalogConfig returns the configuration of a catalog, or nil if it is not configured
+func (s *MessageCatalogService) findCatalogConfig(catalogName string) *config.CatalogConfig {
+	for i := range s.config.Catalogs {
+		if s.config.Catalogs[i].Name == catalogName {
+			return &s.config.Catalogs[i]
 		}
 	}
+	return nil
+}
 
+// isExpired reports whether data loaded at loadedAt is older than CacheTTL (0 disables expiry)
+func (s *MessageCatalogService) isExpired(loadedAt time.Time) bool {
+	return s.config.CacheTTL > 0 && time.Since(loadedAt) >= time.Duration(s.config.CacheTTL)*time.Second
+}
+
+// currentLanguage returns a cached catalog language, loading it when it is
+// missing or expired; nil when the language has no translation file
+func (s *MessageCatalogService) currentLanguage(ctx context.Context, catalogName, language string) *cachedLanguage {
+	entry := s.cachedLanguage(ctx, catalogName, language)
+	if entry == nil || s.isExpired(entry.loadedAt) {
+		entry = s.refreshLanguage(ctx, catalogName, language, entry)
+	}
+	return entry
+}
+
+// refreshLanguage loads one catalog language and swaps it into the cache
+// On failure the previous entry is kept and retried after another TTL
+func (s *MessageCatalogService) refreshLanguage(ctx context.Context, catalogName, language string, previous *cachedLanguage) *cachedLanguage {
+	catalogConfig := s.findCatalogConfig(catalogName)
+	if catalogConfig == nil {
+		return previous
+	}
+
+	entry, found, err := s.loadLanguageMessages(ctx, catalogConfig, language)
+	if err != nil {
+		if previous == nil {
+			return nil
+		}
+		s.logger.Warn(ctx, "Failed to refresh catalog language, serving previous data", interfaces.Fields{
+			"catalog_name": catalogName,
+			"language":     language,
+			"error":        err.Error(),
+		})
+		entry = &cachedLanguage{messages: previous.messages, index: previous.index, size: previous.size, loadedAt: time.Now()}
+	} else if !found && language != s.config.DefaultLanguage {
+		// Do not cache languages without a translation file
+		if previous != nil {
+			s.deleteCachedLanguage(ctx, catalogName, language)
+		}
+		return nil
+	}
+
+	s.setCachedLanguage(ctx, catalogName, language, entry)
+
+	switch {
+	case previous == nil && language != s.config.DefaultLanguage:
+		// Log when loading a non-default language on-demand
+		s.logger.Info(ctx, "Loaded non-default language on-demand", interfaces.Fields{
+			"catalog_name":  catalogName,
+			"language":      language,
+			"message_count": len(entry.messages),
+		})
+	case previous != nil:
+		s.logger.Debug(ctx, "Catalog language cache refreshed", interfaces.Fields{
+			"catalog_name": catalogName,
+			"language":     language,
+		})
+	}
+
+	return entry
+}
+
+// cacheKey returns the CacheManager key of a catalog language
+func cacheKey(catalogName, language string) string {
+	return catalogName + "/" + language
+}
+
+// cachedLanguage returns a cached catalog language, or nil
+// Reading under reloadMutex means a reload is seen either entirely or not at all
+func (s *MessageCatalogService) cachedLanguage(ctx context.Context, catalogName, language string) *cachedLanguage {
+	s.reloadMutex.RLock()
+	defer s.reloadMutex.RUnlock()
+	return s.getCachedLanguage(ctx, catalogName, language)
+}
+
+// getCachedLanguage reads a catalog language from the cache; the caller holds reloadMutex
+func (s *MessageCatalogService) getCachedLanguage(ctx context.Context, catalogName, language string) *cachedLanguage {
+	value, found := s.cache.Get(ctx, cacheKey(catalogName, language))
+	if !found {
+		return nil
+	}
+	entry, _ := value.(*cachedLanguage)
+	return entry
+}
+
+// cachedLanguages returns every cached language of a catalog
+func (s *MessageCatalogService) cachedLanguages(ctx context.Context, catalogName string) map[string]*cachedLanguage {
+	s.reloadMutex.RLock()
+	defer s.reloadMutex.RUnlock()
+
+	prefix := cacheKey(catalogName, "")
+	languages := make(map[string]*cachedLanguage)
+	for _, key := range s.cache.Keys(ctx) {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		language := strings.TrimPrefix(key, prefix)
+		if entry := s.getCachedLanguage(ctx, catalogName, language); entry != nil {
+			languages[language] = entry
+		}
+	}
+	return languages
+}
+
+// swapCatalogLanguages replaces the loaded languages of a catalog and drops the
+// removed ones in one step, so readers never mix languages of two reloads
+func (s *MessageCatalogService) swapCatalogLanguages(ctx context.Context, catalogName string, loaded map[string]*cachedLanguage, dropped []string) {
+	s.reloadMutex.Lock()
+	defer s.reloadMutex.Unlock()
+
+	for language, entry := range loaded {
+		s.setCachedLanguage(ctx, catalogName, language, entry)
+	}
+	for _, language := range dropped {
+		s.deleteCachedLanguage(ctx, catalogName, language)
+	}
+	s.lastReload[catalogName] = time.Now()
+	// Added or removed language files change the fallback chains
+	delete(s.languages, catalogName)
+}
+
+// catalogLock returns the mutex serializing reloads of a catalog
+func (s *MessageCatalogService) catalogLock(catalogName string) *sync.Mutex {
+	s.reloadMutex.Lock()
+	defer s.reloadMutex.Unlock()
+
+	lock, ok := s.catalogLocks[catalogName]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.catalogLocks[catalogName] = lock
+	}
+	return lock
+}
+
+// setCachedLanguage swaps one catalog language into the cache
+// Entries are stored without a cache TTL: expiry is checked by isExpired so a
+// failed refresh can keep serving the previous entry
+func (s *MessageCatalogService) setCachedLanguage(ctx context.Context, catalogName, language string, entry *cachedLanguage) {
+	if err := s.cache.Set(ctx, cacheKey(catalogName, language), entry, 0); err != nil {
+		s.logger.Warn(ctx, "Failed to cache catalog language", interfaces.Fields{
+			"catalog_name": catalogName,
+			"language":     language,
+			"error":        err.Error(),
+		})
+	}
+}
+
+// deleteCachedLanguage drops one catalog language from the cache
+func (s *MessageCatalogService) deleteCachedLanguage(ctx context.Context, catalogName, language string) {
+	if err := s.cache.Delete(ctx, cacheKey(catalogName, language)); err != nil {
+		s.logger.Warn(ctx, "Failed to evict catalog language", interfaces.Fields{
+			"catalog_name": catalogName,
+			"language":     language,
+			"error":        err.Error(),
+		})
+	}
+}
+
+// setLastReload records when a catalog was last loaded
+func (s *MessageCatalogService) setLastReload(catalogName string, at time.Time) {
+	s.reloadMutex.Lock()
+	defer s.reloadMutex.Unlock()
+	s.lastReload[catalogName] = at
+}
+
+// loadDefaultLanguage loads the default language of a catalog
+// A missing default language file yields structure-only messages
+func (s *MessageCatalogService) loadDefaultLanguage(ctx context.Context, catalogConfig *config.CatalogConfig) (*cachedLanguage, error) {
+	entry, found, err := s.loadLanguageMessages(ctx, catalogConfig, s.config.DefaultLanguage)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		s.logger.Warn(ctx, "Failed to load default language file", interfaces.Fields{
+			"catalog_name":     catalogConfig.Name,
+			"default_language": s.config.DefaultLanguage,
+		})
+	}
+	return entry, nil
+}
+
+// loadLanguageMessages loads every message of a catalog in one language
+// found is false when the language does not exist; messages then carry structure only
+func (s *MessageCatalogService) loadLanguageMessages(ctx context.Context, catalogConfig *config.CatalogConfig, language string) (*cachedLanguage, bool, error) {
+	// Load structure
+	structureData, err := s.loader.LoadCatalogStructure(ctx, catalogConfig.Name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Load translations
+	found := true
+	languageData, err := s.loader.LoadLanguageFile(ctx, catalogConfig.Name, language)
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr == nil || appErr.Code != errors.ErrCodeNotFound {
+			return nil, false, err
+		}
+		found = false
+		languageData = make(map[string]interface{})
+	}
+
+	// Combine structure and language data
+	messages := make(map[string]*Message, len(structureData))
+	for messageCode, messageStructure := range structureData {
+		structure, ok := messageStructure.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		// Type assert to map[string]interface{}
+		languageMap, ok := languageData[messageCode].(map[string]interface{})
+		if !ok {
+			languageMap = make(map[string]interface{})
+		}
+
+		messages[messageCode] = s.combineMessageData(structure, languageMap, catalogConfig.Name, language)
+	}
+
+	return newCachedLanguage(messages, time.Now()), found, nil
+}
+
+// loadMessage loads and combines the structure and translation of one message
+func (s *MessageCatalogService) loadMessage(ctx context.Context, catalogName, messageCode, language string) (*Message, error) {
+	// Find catalog configuration
+	catalogConfig := s.findCatalogConfig(catalogName)
 	if catalogConfig == nil {
 		return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog not found",
 			fmt.Sprintf("Catalog '%s' not found in configuration", catalogName), 404)
 	}
 
-	// Load structure file
-	structureData, err := s.loadStructureFile(ctx, catalogConfig)
+	// Load structure
+	structureData, err := s.loader.LoadCatalogStructure(ctx, catalogConfig.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -675,8 +916,8 @@ func (s *MessageCatalogService) loadMessageFromFiles(ctx context.Context, catalo
 			fmt.Sprintf("Message '%s' has invalid structure in catalog '%s'", messageCode, catalogName), 400)
 	}
 
-	// Load language file
-	languageData, err := s.loadLanguageFile(ctx, catalogConfig, language)
+	// Load translations
+	languageData, err := s.loader.LoadLanguageFile(ctx, catalogConfig.Name, language)
 	if err != nil {
 		s.logger.Warn(ctx, "Language content not found, using structure only", interfaces.Fields{
 			"message_code": messageCode,
@@ -704,68 +945,6 @@ func (s *MessageCatalogService) loadMessageFromFiles(ctx context.Context, catalo
 	return message, nil
 }
 
-// loadStructureFile loads the structure file for a catalog
-func (s *MessageCatalogService) loadStructureFile(ctx context.Context, catalogConfig *config.CatalogConfig) (map[string]interface{}, error) {
-	filePath := filepath.Join(catalogConfig.Path, catalogConfig.StructureFile)
-
-	s.logger.Debug(ctx, "Loading structure file", interfaces.Fields{
-		"file_path": filePath,
-		"catalog":   catalogConfig.Name,
-	})
-
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Structure file not found",
-			fmt.Sprintf("Structure file '%s' not found for catalog '%s'", filePath, catalogConfig.Name), 404)
-	}
-
-	// Read file
-	data, err := ioutil.ReadFile(filePath)
-	if err != nil {
-		return nil, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to read structure file", 500, err)
-	}
-
-	// Parse JSON
-	var structureData map[string]interface{}
-	if err := json.Unmarshal(data, &structureData); err != nil {
-		return nil, errors.NewWithError(errors.ErrCodeBadRequest, "Failed to parse structure file", 400, err)
-	}
-
-	return structureData, nil
-}
-
-// loadLanguageFile loads a language file for a catalog
-func (s *MessageCatalogService) loadLanguageFile(ctx context.Context, catalogConfig *config.CatalogConfig, language string) (map[string]interface{}, error) {
-	fileName := strings.ReplaceAll(catalogConfig.LanguageFilePattern, "{lang}", language)
-	filePath := filepath.Join(catalogConfig.Path, fileName)
-
-	s.logger.Debug(ctx, "Loading language file", interfaces.Fields{
-		"file_path": filePath,
-		"catalog":   catalogConfig.Name,
-		"language":  language,
-	})
-
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Language file not found",
-			fmt.Sprintf("Language file '%s' not found for catalog '%s'", filePath, catalogConfig.Name), 404)
-	}
-
-	// Read file
-	data, err := ioutil.ReadFile(filePath)
-	if err != nil {
-		return nil, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to read language file", 500, err)
-	}
-
-	// Parse JSON
-	var languageData map[string]interface{}
-	if err := json.Unmarshal(data, &languageData); err != nil {
-		return nil, errors.NewWithError(errors.ErrCodeBadRequest, "Failed to parse language file", 400, err)
-	}
-
-	return languageData, nil
-}
-
 // combineMessageData combines structure and language data into a Message object
 func (s *MessageCatalogService) combineMessageData(structure, language map[string]interface{}, catalogName, languageCode string) *Message {
 	message := &Message{
@@ -788,14 +967,15 @@ func (s *MessageCatalogService) combineMessageData(structure, language map[strin
 	return message
 }
 
-// formatMessageResponse formats the message with parameters
-func (s *MessageCatalogService) formatMessageResponse(message *Message, parameters map[string]interface{}) *MessageResponse {
-	response := &MessageResponse{
+// formatMessageResponse builds the response for a message without rendering parameters
+func (s *MessageCatalogService) formatMessageResponse(message *Message) *MessageResponse {
+	return &MessageResponse{
 		MessageCode:         message.MessageCode,
 		Category:            message.Category,
 		Severity:            message.Severity,
 		Component:           message.Component,
 		Message:             message.Message,
+		FormattedMessage:    message.Message,
 		DetailedDescription: message.DetailedDescription,
 		ResponseAction:      message.ResponseAction,
 		Language:            message.Language,
@@ -803,43 +983,78 @@ func (s *MessageCatalogService) formatMessageResponse(message *Message, paramete
 		Metadata:            message.Metadata,
 		Timestamp:           time.Now(),
 	}
+}
 
-	// Apply parameter substitution if parameters provided
-	if parameters != nil && len(parameters) > 0 {
-		response.FormattedMessage = s.applyParameters(message.Message, parameters)
-		response.DetailedDescription = s.applyParameters(message.DetailedDescription, parameters)
-	} else {
-		response.FormattedMessage = message.Message
+// renderParameters formats the message texts as ICU MessageFormat in the
+// language of the message, so plurals, numbers and dates follow its locale
+// In strict mode missing or invalid parameters and broken patterns are errors;
+// otherwise the placeholder is kept and the problem logged
+func (s *MessageCatalogService) renderParameters(ctx context.Context, response *MessageResponse, parameters map[string]interface{}) error {
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{"message", &response.FormattedMessage},
+		{"detailed_description", &response.DetailedDescription},
+		{"response_action", &response.ResponseAction},
+	}
+
+	for _, field := range fields {
+		rendered, err := s.renderText(ctx, response, field.name, *field.value, parameters)
+		if err != nil {
+			return err
+		}
+		*field.value = rendered
 	}
-
-	return response
+	return nil
 }
 
-// applyParameters applies template parameters to message content
-func (s *MessageCatalogService) applyParameters(content string, parameters map[string]interface{}) string {
+// renderText formats one message text
+func (s *MessageCatalogService) renderText(ctx context.Context, response *MessageResponse, field, content string, parameters map[string]interface{}) (string, error) {
 	if content == "" {
-		return content
+		return content, nil
 	}
 
-	tmpl, err := template.New("message").Parse(content)
+	pattern, err := messageformat.Parse(content)
 	if err != nil {
-		s.logger.Warn(context.Background(), "Failed to parse message template", interfaces.Fields{
-			"content": content,
-			"error":   err.Error(),
+		if s.config.StrictParameters {
+			return "", errors.NewWithError(errors.ErrCodeInternalServer, "Invalid message pattern", 500, err).
+				WithField("message_code", response.MessageCode).
+				WithField("catalog_name", response.CatalogName).
+				WithField("field", field)
+		}
+		s.logger.Warn(ctx, "Invalid message pattern, returning it unformatted", interfaces.Fields{
+			"message_code": response.MessageCode,
+			"catalog_name": response.CatalogName,
+			"field":        field,
+			"error":        err.Error(),
 		})
-		return content
+		return content, nil
 	}
 
-	var result strings.Builder
-	if err := tmpl.Execute(&result, parameters); err != nil {
-		s.logger.Warn(context.Background(), "Failed to execute message template", interfaces.Fields{
-			"content": content,
-			"error":   err.Error(),
+	rendered, err := pattern.Format(response.Language, parameters)
+	if err != nil {
+		if s.config.StrictParameters {
+			return "", errors.NewWithDetails(errors.ErrCodeBadRequest, "Missing or invalid message parameters", err.Error(), 400).
+				WithField("message_code", response.MessageCode).
+				WithField("parameters", parameterProblems(err))
+		}
+		s.logger.Debug(ctx, "Message rendered with missing or invalid parameters", interfaces.Fields{
+			"message_code": response.MessageCode,
+			"catalog_name": response.CatalogName,
+			"field":        field,
+			"error":        err.Error(),
 		})
-		return content
 	}
+	return rendered, nil
+}
 
-	return result.String()
+// parameterProblems extracts the per-parameter problems of a formatting error
+func parameterProblems(err error) []messageformat.ParameterProblem {
+	if parameterErr, ok := err.(*messageformat.ParameterError); ok {
+		return parameterErr.Problems
+	}
+	return nil
 }
 
 // Helper function to safely get string values from map
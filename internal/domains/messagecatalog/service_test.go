@@ -0,0 +1,250 @@
+package messagecatalog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/mocks"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/messageformat"
+)
+
+const testStructure = `{"TST0001": {"message_code": "TST0001", "category": "Test", "severity": "LOW", "component": "Test"}}`
+
+// writeCatalog writes a one-message test catalog with an en-US translation
+func writeCatalog(t *testing.T, dir, message string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog.json"), []byte(testStructure), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog-en-US.json"),
+		[]byte(`{"TST0001": {"message": "`+message+`"}}`), 0o644))
+}
+
+func newTestService(t *testing.T, dir string, cacheTTL int) *MessageCatalogService {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	catalogConfig := config.MessageCatalogConfig{
+		DefaultLanguage: "en-US",
+		CacheEnabled:    true,
+		CacheTTL:        cacheTTL,
+		Catalogs: []config.CatalogConfig{{
+			Name:                "test",
+			Path:                dir,
+			Enabled:             true,
+			StructureFile:       "messagecatelog.json",
+			LanguageFilePattern: "messagecatelog-{lang}.json",
+		}},
+	}
+	return NewMessageCatalogService(catalogConfig, NewFileSystemLoader(catalogConfig.Catalogs, mockLogger),
+		NewLRUCacheManager(0, 0), mockLogger).(*MessageCatalogService)
+}
+
+func getTestMessage(t *testing.T, service Service) string {
+	t.Helper()
+	response, err := service.GetMessage(context.Background(), &MessageRequest{MessageCode: "TST0001", CatalogName: "test"})
+	require.NoError(t, err)
+	return response.Message
+}
+
+// expireCache backdates every cached language so the next lookup sees it as expired
+func expireCache(service *MessageCatalogService) {
+	ctx := context.Background()
+	for _, key := range service.cache.Keys(ctx) {
+		value, _ := service.cache.Get(ctx, key)
+		entry := value.(*cachedLanguage)
+		entry.loadedAt = entry.loadedAt.Add(-time.Hour)
+	}
+}
+
+func TestGetMessage_RefreshesExpiredLanguage(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "first")
+	service := newTestService(t, dir, 60)
+
+	assert.Equal(t, "first", getTestMessage(t, service))
+
+	// Still within TTL: the cached copy is served
+	writeCatalog(t, dir, "second")
+	assert.Equal(t, "first", getTestMessage(t, service))
+
+	expireCache(service)
+	assert.Equal(t, "second", getTestMessage(t, service))
+}
+
+func TestGetMessage_NoExpiryWhenTTLDisabled(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "first")
+	service := newTestService(t, dir, 0)
+
+	writeCatalog(t, dir, "second")
+	expireCache(service)
+
+	assert.Equal(t, "first", getTestMessage(t, service))
+}
+
+func TestReloadCatalog_KeepsPreviousDataOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "first")
+	service := newTestService(t, dir, 60)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog-en-US.json"), []byte(`{not json`), 0o644))
+
+	assert.Error(t, service.ReloadCatalog(context.Background(), "test"))
+	assert.Equal(t, "first", getTestMessage(t, service))
+
+	writeCatalog(t, dir, "fixed")
+	assert.NoError(t, service.ReloadCatalog(context.Background(), "test"))
+	assert.Equal(t, "fixed", getTestMessage(t, service))
+}
+
+func TestReloadCatalog_ConcurrentReloadsAreSerialized(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "first")
+	service := newTestService(t, dir, 60)
+	writeCatalog(t, dir, "second")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, service.ReloadCatalog(context.Background(), "test"))
+			assert.Contains(t, []string{"first", "second"}, getTestMessage(t, service))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, "second", getTestMessage(t, service))
+}
+
+func TestStopAutoReload_IsIdempotent(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "first")
+	service := newTestService(t, dir, 60)
+	service.config.ReloadInterval = 3600
+
+	service.StartAutoReload(context.Background())
+	service.StopAutoReload()
+	service.StopAutoReload()
+}
+
+func TestGetMessage_RendersMessageFormat(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "{count, plural, =0 {No alerts} one {# alert} other {# alerts}} for {user}")
+	service := newTestService(t, dir, 60)
+
+	response, err := service.GetMessage(context.Background(), &MessageRequest{
+		MessageCode: "TST0001",
+		CatalogName: "test",
+		Parameters:  map[string]interface{}{"count": 1200, "user": "ada"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1,200 alerts for ada", response.FormattedMessage)
+	assert.Equal(t, "{count, plural, =0 {No alerts} one {# alert} other {# alerts}} for {user}", response.Message)
+
+	// Lenient mode keeps the placeholders of missing parameters
+	response, err = service.GetMessage(context.Background(), &MessageRequest{
+		MessageCode: "TST0001",
+		CatalogName: "test",
+		Parameters:  map[string]interface{}{"user": "ada"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "{count} for ada", response.FormattedMessage)
+}
+
+func TestGetMessage_StrictParameters(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "{count, number, integer} alerts for {user}")
+	service := newTestService(t, dir, 60)
+	service.config.StrictParameters = true
+
+	_, err := service.GetMessage(context.Background(), &MessageRequest{
+		MessageCode: "TST0001",
+		CatalogName: "test",
+		Parameters:  map[string]interface{}{"count": "many"},
+	})
+	require.Error(t, err)
+	appErr := errors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, errors.ErrCodeBadRequest, appErr.Code)
+	assert.Equal(t, []messageformat.ParameterProblem{
+		{Name: "count", Reason: "not a number"},
+		{Name: "user", Reason: "missing"},
+	}, appErr.Fields["parameters"])
+
+	// A broken pattern is a server-side catalog error
+	writeCatalog(t, dir, "{count, plural, one {# alert}}")
+	require.NoError(t, service.ReloadCatalog(context.Background(), "test"))
+	_, err = service.GetMessage(context.Background(), &MessageRequest{
+		MessageCode: "TST0001",
+		CatalogName: "test",
+		Parameters:  map[string]interface{}{"count": 2},
+	})
+	require.Error(t, err)
+	assert.Equal(t, errors.ErrCodeInternalServer, errors.GetAppError(err).Code)
+}
+
+func TestPreloadCatalogLanguages_LoadsConfiguredLanguagesWithinBudget(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeCatalog(t, dir, "hello")
+	for _, language := range []string{"fr-FR", "de-DE"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "messagecatelog-"+language+".json"),
+			[]byte(`{"TST0001": {"message": "`+language+`", "detailed_description": "d", "response_action": "a"}}`), 0o644))
+	}
+
+	catalogConfig := config.MessageCatalogConfig{
+		DefaultLanguage: "en-US",
+		CacheEnabled:    true,
+		Catalogs: []config.CatalogConfig{{
+			Name:                "test",
+			Path:                dir,
+			Enabled:             true,
+			StructureFile:       "messagecatelog.json",
+			LanguageFilePattern: "messagecatelog-{lang}.json",
+			PreloadLanguages:    []string{"*"},
+		}},
+	}
+	logger := newQuietLogger(t)
+	service := NewMessageCatalogService(catalogConfig, NewFileSystemLoader(catalogConfig.Catalogs, logger),
+		NewLRUCacheManager(0, 0), logger)
+
+	info, err := service.GetCatalogInfo(ctx, "test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"de-DE", "en-US", "fr-FR"}, info.LoadedLanguages)
+	assert.Positive(t, info.MemoryBytes)
+
+	// A budget for about two languages evicts the least recently used one
+	languageSize := info.MemoryBytes / 3
+	cache := NewLRUCacheManager(0, 2*languageSize+languageSize/2)
+	catalogConfig.Catalogs[0].PreloadLanguages = []string{"fr-FR", "fr-FR", "en-US", "it-IT"}
+	service = NewMessageCatalogService(catalogConfig, NewFileSystemLoader(catalogConfig.Catalogs, logger), cache, logger)
+
+	stats, err := service.GetCatalogStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en-US", "fr-FR"}, stats.Catalogs[0].LoadedLanguages)
+	assert.Equal(t, stats.Catalogs[0].MemoryBytes, stats.MemoryBytes)
+	assert.Equal(t, stats.MemoryBytes, stats.Cache["bytes"])
+
+	response, err := service.GetMessage(ctx, &MessageRequest{MessageCode: "TST0001", CatalogName: "test", Language: "de-DE"})
+	require.NoError(t, err)
+	assert.Equal(t, "de-DE", response.Message)
+	info, err = service.GetCatalogInfo(ctx, "test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"de-DE", "fr-FR"}, info.LoadedLanguages, "en-US was the least recently used")
+	assert.Equal(t, int64(1), cache.GetStats(ctx)["evictions"])
+}
@@ -0,0 +1,577 @@
+package messagecatalog
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/lib/pq"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/interfaces"
+)
+
+// HistoryDir is the directory, inside a filesystem catalog, holding one
+// <message code>.json version history per edited message
+const HistoryDir = "history"
+
+// FileStore implements CatalogStore by rewriting the JSON files of filesystem catalogs
+// Writes are serialized per process; run a single editing instance per catalog directory
+type FileStore struct {
+	catalogs map[string]config.CatalogConfig
+	mu       sync.Mutex
+	logger   interfaces.Logger
+}
+
+// NewFileStore creates a store editing the catalog files under each CatalogConfig.Path
+func NewFileStore(catalogs []config.CatalogConfig, log interfaces.Logger) CatalogStore {
+	store := &FileStore{catalogs: make(map[string]config.CatalogConfig), logger: log}
+	for _, catalog := range catalogs {
+		store.catalogs[catalog.Name] = catalog
+	}
+	return store
+}
+
+// catalogFiles is the decoded content of one catalog directory
+type catalogFiles struct {
+	structure map[string]interface{}
+	languages map[string]map[string]interface{}
+}
+
+// ListEntries returns every message of a catalog, sorted by code
+func (s *FileStore) ListEntries(ctx context.Context, catalogName string) ([]*CatalogEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	catalog, files, err := s.read(catalogName)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*CatalogEntry, 0, len(files.structure))
+	for _, code := range sortedKeys(files.structure) {
+		history, err := s.readHistory(&catalog, code)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, files.entry(catalogName, code, history))
+	}
+	return entries, nil
+}
+
+// GetEntry returns one message, or nil when the catalog does not define it
+func (s *FileStore) GetEntry(ctx context.Context, catalogName, messageCode string) (*CatalogEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	catalog, files, err := s.read(catalogName)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.readHistory(&catalog, messageCode)
+	if err != nil {
+		return nil, err
+	}
+	return files.entry(catalogName, messageCode, history), nil
+}
+
+// ListVersions returns the recorded versions of a message, oldest first
+func (s *FileStore) ListVersions(ctx context.Context, catalogName, messageCode string) ([]*EntryVersion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	catalog, exists := s.catalogs[catalogName]
+	if !exists {
+		return nil, catalogNotFound(catalogName)
+	}
+	return s.readHistory(&catalog, messageCode)
+}
+
+// UpdateEntry applies update to a message and rewrites the catalog files and its history
+func (s *FileStore) UpdateEntry(ctx context.Context, catalogName, messageCode string, update EntryUpdate) (*CatalogEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	catalog, files, err := s.read(catalogName)
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.readHistory(&catalog, messageCode)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := update(files.entry(catalogName, messageCode, history), lastVersion(history))
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return files.entry(catalogName, messageCode, history), nil
+	}
+	entry := versions[len(versions)-1].Entry
+
+	// Languages whose file content changes; a new language gets a new file
+	changed := make(map[string]bool)
+	if entry == nil {
+		delete(files.structure, messageCode)
+	} else {
+		files.structure[messageCode] = map[string]interface{}{
+			"message_code": messageCode,
+			"category":     entry.Category,
+			"severity":     entry.Severity,
+			"component":    entry.Component,
+		}
+	}
+	for language, data := range files.languages {
+		if _, exists := data[messageCode]; exists {
+			delete(data, messageCode)
+			changed[language] = true
+		}
+	}
+	if entry != nil {
+		for language, translation := range entry.Translations {
+			if files.languages[language] == nil {
+				files.languages[language] = make(map[string]interface{})
+			}
+			files.languages[language][messageCode] = map[string]interface{}{
+				"message":              translation.Message,
+				"detailed_description": translation.DetailedDescription,
+				"response_action":      translation.ResponseAction,
+			}
+			changed[language] = true
+		}
+	}
+
+	if err := writeJSONFile(filepath.Join(catalog.Path, catalog.StructureFile), files.structure); err != nil {
+		return nil, s.writeError(ctx, catalogName, err)
+	}
+	for _, language := range sortedLanguages(changed) {
+		fileName := languageFileName(&catalog, language)
+		if err := writeJSONFile(filepath.Join(catalog.Path, fileName), files.languages[language]); err != nil {
+			return nil, s.writeError(ctx, catalogName, err)
+		}
+	}
+	if err := writeJSONFile(s.historyPath(&catalog, messageCode), append(history, versions...)); err != nil {
+		return nil, s.writeError(ctx, catalogName, err)
+	}
+
+	return entry, nil
+}
+
+// read decodes the structure file and every language file of a catalog
+func (s *FileStore) read(catalogName string) (config.CatalogConfig, *catalogFiles, error) {
+	catalog, exists := s.catalogs[catalogName]
+	if !exists {
+		return catalog, nil, catalogNotFound(catalogName)
+	}
+
+	files := &catalogFiles{languages: make(map[string]map[string]interface{})}
+	if err := readJSONFile(filepath.Join(catalog.Path, catalog.StructureFile), &files.structure); err != nil {
+		return catalog, nil, err
+	}
+	if files.structure == nil {
+		files.structure = make(map[string]interface{})
+	}
+
+	entries, err := os.ReadDir(catalog.Path)
+	if err != nil {
+		return catalog, nil, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to list language files", 500, err)
+	}
+	for _, dirEntry := range entries {
+		language, ok := languageFromFileName(&catalog, dirEntry.Name())
+		if dirEntry.IsDir() || !ok {
+			continue
+		}
+		var data map[string]interface{}
+		if err := readJSONFile(filepath.Join(catalog.Path, dirEntry.Name()), &data); err != nil {
+			return catalog, nil, err
+		}
+		if data == nil {
+			data = make(map[string]interface{})
+		}
+		files.languages[language] = data
+	}
+
+	return catalog, files, nil
+}
+
+// entry builds a message from the decoded files, or returns nil when the code is not defined
+// Files carry no version, so it comes from the history (1 for a never edited message)
+func (f *catalogFiles) entry(catalogName, messageCode string, history []*EntryVersion) *CatalogEntry {
+	structure, exists := f.structure[messageCode].(map[string]interface{})
+	if !exists {
+		return nil
+	}
+
+	entry := &CatalogEntry{
+		CatalogName:  catalogName,
+		MessageCode:  messageCode,
+		Category:     getString(structure, "category"),
+		Severity:     getString(structure, "severity"),
+		Component:    getString(structure, "component"),
+		Translations: make(map[string]Translation),
+		Version:      1,
+	}
+	for language, data := range f.languages {
+		if text, exists := data[messageCode].(map[string]interface{}); exists {
+			entry.Translations[language] = Translation{
+				Message:             getString(text, "message"),
+				DetailedDescription: getString(text, "detailed_description"),
+				ResponseAction:      getString(text, "response_action"),
+			}
+		}
+	}
+	if len(history) > 0 {
+		latest := history[len(history)-1]
+		entry.Version = latest.Version
+		entry.UpdatedBy = latest.Author
+		entry.UpdatedAt = latest.CreatedAt
+	}
+	return entry
+}
+
+// historyPath returns the history file of a message
+func (s *FileStore) historyPath(catalog *config.CatalogConfig, messageCode string) string {
+	return filepath.Join(catalog.Path, HistoryDir, messageCode+".json")
+}
+
+// readHistory reads the versions of a message; a message without history has none
+func (s *FileStore) readHistory(catalog *config.CatalogConfig, messageCode string) ([]*EntryVersion, error) {
+	var history []*EntryVersion
+	if err := readJSONFile(s.historyPath(catalog, messageCode), &history); err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil && appErr.Code == errors.ErrCodeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return history, nil
+}
+
+// writeError logs a failed catalog write and wraps it in an AppError
+func (s *FileStore) writeError(ctx context.Context, catalogName string, err error) error {
+	s.logger.Error(ctx, "Failed to write catalog files", interfaces.Fields{
+		"catalog_name": catalogName,
+		"error":        err.Error(),
+	})
+	return errors.NewWithError(errors.ErrCodeInternalServer, "Failed to write catalog files", 500, err).
+		WithField("catalog_name", catalogName)
+}
+
+// readJSONFile decodes a JSON file; a missing file is an ErrCodeNotFound AppError
+func readJSONFile(filePath string, target interface{}) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.NewWithDetails(errors.ErrCodeNotFound, "Catalog file not found",
+				fmt.Sprintf("File '%s' not found", filePath), 404)
+		}
+		return errors.NewWithError(errors.ErrCodeInternalServer, "Failed to read catalog file", 500, err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return errors.NewWithError(errors.ErrCodeInternalServer, "Failed to parse catalog file", 500, err).
+			WithField("file", filePath)
+	}
+	return nil
+}
+
+// writeJSONFile replaces a file with indented JSON through a rename, so readers
+// (and the file watcher) never see a partial file
+func writeJSONFile(filePath string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filePath)
+}
+
+// lastVersion returns the highest recorded version, 0 without history
+func lastVersion(history []*EntryVersion) int {
+	if len(history) == 0 {
+		return 0
+	}
+	return history[len(history)-1].Version
+}
+
+func sortedLanguages(languages map[string]bool) []string {
+	result := make([]string, 0, len(languages))
+	for language := range languages {
+		result = append(result, language)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// DatabaseStore implements CatalogStore over the message_catalog_* tables
+// (see scripts/migrations/005 and 006); the message row is locked while it is edited
+type DatabaseStore struct {
+	db     interfaces.Database
+	logger interfaces.Logger
+}
+
+// NewDatabaseStore creates a store editing catalogs in the database
+func NewDatabaseStore(db interfaces.Database, log interfaces.Logger) CatalogStore {
+	return &DatabaseStore{
+		db:     db,
+		logger: log,
+	}
+}
+
+// ListEntries returns every message of a catalog, sorted by code
+func (s *DatabaseStore) ListEntries(ctx context.Context, catalogName string) ([]*CatalogEntry, error) {
+	var entries []*CatalogEntry
+	err := s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		entries, err = s.queryEntries(ctx, tx, catalogName, "", false)
+		return err
+	})
+	if err != nil {
+		return nil, s.queryError(ctx, "Failed to list catalog entries", catalogName, err)
+	}
+	return entries, nil
+}
+
+// GetEntry returns one message, or nil when it is not stored
+func (s *DatabaseStore) GetEntry(ctx context.Context, catalogName, messageCode string) (*CatalogEntry, error) {
+	var entries []*CatalogEntry
+	err := s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		entries, err = s.queryEntries(ctx, tx, catalogName, messageCode, false)
+		return err
+	})
+	if err != nil {
+		return nil, s.queryError(ctx, "Failed to get catalog entry", catalogName, err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
+
+// ListVersions returns the recorded versions of a message, oldest first
+func (s *DatabaseStore) ListVersions(ctx context.Context, catalogName, messageCode string) ([]*EntryVersion, error) {
+	query := `
+		SELECT version, action, author, created_at, snapshot
+		FROM message_catalog_versions
+		WHERE catalog_name = $1 AND message_code = $2
+		ORDER BY version
+	`
+
+	var versions []*EntryVersion
+	err := s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, catalogName, messageCode)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			version := &EntryVersion{}
+			var snapshot []byte
+			if err := rows.Scan(&version.Version, &version.Action, &version.Author, &version.CreatedAt, &snapshot); err != nil {
+				return err
+			}
+			if len(snapshot) > 0 && string(snapshot) != "null" {
+				if err := json.Unmarshal(snapshot, &version.Entry); err != nil {
+					return fmt.Errorf("failed to decode version %d: %w", version.Version, err)
+				}
+			}
+			versions = append(versions, version)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, s.queryError(ctx, "Failed to list catalog entry versions", catalogName, err)
+	}
+	return versions, nil
+}
+
+// UpdateEntry applies update to a message and stores the result and its versions in one transaction
+func (s *DatabaseStore) UpdateEntry(ctx context.Context, catalogName, messageCode string, update EntryUpdate) (*CatalogEntry, error) {
+	var entry *CatalogEntry
+	err := s.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		entries, err := s.queryEntries(ctx, tx, catalogName, messageCode, true)
+		if err != nil {
+			return err
+		}
+		var current *CatalogEntry
+		if len(entries) > 0 {
+			current = entries[0]
+		}
+
+		var last int
+		if err := tx.QueryRowContext(ctx, `
+			SELECT COALESCE(MAX(version), 0) FROM message_catalog_versions
+			WHERE catalog_name = $1 AND message_code = $2
+		`, catalogName, messageCode).Scan(&last); err != nil {
+			return err
+		}
+
+		versions, err := update(current, last)
+		if err != nil || len(versions) == 0 {
+			entry = current
+			return err
+		}
+		entry = versions[len(versions)-1].Entry
+
+		if err := s.saveEntry(ctx, tx, catalogName, messageCode, entry); err != nil {
+			return err
+		}
+		for _, version := range versions {
+			snapshot, err := json.Marshal(version.Entry)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO message_catalog_versions (catalog_name, message_code, version, action, author, created_at, snapshot)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+			`, catalogName, messageCode, version.Version, version.Action, version.Author, version.CreatedAt, snapshot); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// Two writers creating the same message both pass the version check; the second one loses here
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, errors.NewWithDetails(errors.ErrCodeConflict, "Message was modified concurrently",
+				fmt.Sprintf("Message '%s' in catalog '%s' changed while it was being saved", messageCode, catalogName), 409)
+		}
+		return nil, s.queryError(ctx, "Failed to update catalog entry", catalogName, err)
+	}
+	return entry, nil
+}
+
+// saveEntry replaces the rows of a message; a nil entry deletes it with its translations
+func (s *DatabaseStore) saveEntry(ctx context.Context, tx *sql.Tx, catalogName, messageCode string, entry *CatalogEntry) error {
+	if entry == nil {
+		_, err := tx.ExecContext(ctx, `DELETE FROM message_catalog_messages WHERE catalog_name = $1 AND message_code = $2`,
+			catalogName, messageCode)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO message_catalog_messages (catalog_name, message_code, category, severity, component, version, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (catalog_name, message_code) DO UPDATE SET
+			category = EXCLUDED.category,
+			severity = EXCLUDED.severity,
+			component = EXCLUDED.component,
+			version = EXCLUDED.version,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+	`, catalogName, messageCode, entry.Category, entry.Severity, entry.Component, entry.Version, entry.UpdatedBy, entry.UpdatedAt); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM message_catalog_translations WHERE catalog_name = $1 AND message_code = $2`,
+		catalogName, messageCode); err != nil {
+		return err
+	}
+	for language, translation := range entry.Translations {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO message_catalog_translations (catalog_name, message_code, language, message, detailed_description, response_action, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, catalogName, messageCode, language, translation.Message, translation.DetailedDescription, translation.ResponseAction, entry.UpdatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queryEntries loads the messages of a catalog (one message when messageCode is set) with their translations
+// forUpdate locks the message row until the transaction ends
+func (s *DatabaseStore) queryEntries(ctx context.Context, tx *sql.Tx, catalogName, messageCode string, forUpdate bool) ([]*CatalogEntry, error) {
+	query := `
+		SELECT message_code, category, severity, component, version, updated_by, updated_at
+		FROM message_catalog_messages
+		WHERE catalog_name = $1 AND ($2 = '' OR message_code = $2)
+		ORDER BY message_code
+	`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+
+	rows, err := tx.QueryContext(ctx, query, catalogName, messageCode)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*CatalogEntry
+	byCode := make(map[string]*CatalogEntry)
+	for rows.Next() {
+		entry := &CatalogEntry{CatalogName: catalogName, Translations: make(map[string]Translation)}
+		if err := rows.Scan(&entry.MessageCode, &entry.Category, &entry.Severity, &entry.Component,
+			&entry.Version, &entry.UpdatedBy, &entry.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		entries = append(entries, entry)
+		byCode[entry.MessageCode] = entry
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+		SELECT message_code, language, message, detailed_description, response_action
+		FROM message_catalog_translations
+		WHERE catalog_name = $1 AND ($2 = '' OR message_code = $2)
+	`, catalogName, messageCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var code, language string
+		var translation Translation
+		if err := rows.Scan(&code, &language, &translation.Message, &translation.DetailedDescription, &translation.ResponseAction); err != nil {
+			return nil, err
+		}
+		if entry, exists := byCode[code]; exists {
+			entry.Translations[language] = translation
+		}
+	}
+	return entries, rows.Err()
+}
+
+// queryError logs a failed catalog query and wraps it in an AppError
+// AppErrors (e.g. a version conflict raised by an EntryUpdate) are returned unchanged
+func (s *DatabaseStore) queryError(ctx context.Context, message, catalogName string, err error) error {
+	if appErr := errors.GetAppError(err); appErr != nil {
+		return appErr
+	}
+	s.logger.Error(ctx, message, interfaces.Fields{
+		"catalog_name": catalogName,
+		"error":        err.Error(),
+	})
+	return errors.NewWithError(errors.ErrCodeDatabaseQuery, message, 500, err).
+		WithField("catalog_name", catalogName)
+}
@@ -0,0 +1,321 @@
+package messagecatalog
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/interfaces"
+)
+
+// defaultWatchDebounce is used when WatchDebounce is not configured
+const defaultWatchDebounce = 500 * time.Millisecond
+
+// catalogFileChange identifies a changed catalog file
+// An empty language means the structure file changed
+type catalogFileChange struct {
+	catalogName string
+	language    string
+}
+
+// StartWatching watches the directory of every enabled filesystem catalog and
+// reloads the affected catalog or language when its files change
+//
+// Bursts of events (editors often write, rename and chmod) are debounced, the new
+// content is validated before it replaces the cached data, and the added, removed
+// and modified message codes are logged. The watcher stops on StopWatching or
+// when ctx is cancelled.
+func (s *MessageCatalogService) StartWatching(ctx context.Context) error {
+	if !s.config.WatchEnabled {
+		s.logger.Info(ctx, "Message catalog file watching disabled", interfaces.Fields{})
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	// Watch directories rather than files so atomic renames are seen
+	watched := make(map[string]bool)
+	for _, catalog := range s.config.Catalogs {
+		if !catalog.Enabled || !isFileSystemCatalog(&catalog) || watched[filepath.Clean(catalog.Path)] {
+			continue
+		}
+		if err := watcher.Add(catalog.Path); err != nil {
+			watcher.Close()
+			return err
+		}
+		watched[filepath.Clean(catalog.Path)] = true
+	}
+
+	debounce := defaultWatchDebounce
+	if s.config.WatchDebounce > 0 {
+		debounce = time.Duration(s.config.WatchDebounce) * time.Millisecond
+	}
+
+	s.logger.Info(ctx, "Watching message catalog files", interfaces.Fields{
+		"directories": len(watched),
+		"debounce":    debounce.String(),
+	})
+
+	go s.watchLoop(ctx, watcher, debounce)
+	return nil
+}
+
+// StopWatching stops the catalog file watcher
+func (s *MessageCatalogService) StopWatching() {
+	s.stopWatchOnce.Do(func() { close(s.stopWatch) })
+}
+
+// watchLoop collects file events and applies them once the files have been quiet for debounce
+func (s *MessageCatalogService) watchLoop(ctx context.Context, watcher *fsnotify.Watcher, debounce time.Duration) {
+	defer watcher.Close()
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	pending := make(map[catalogFileChange]bool)
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			for _, change := range s.changesForFile(event.Name) {
+				pending[change] = true
+			}
+			if len(pending) > 0 {
+				timer.Reset(debounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			s.logger.Warn(ctx, "Message catalog watcher error", interfaces.Fields{
+				"error": err.Error(),
+			})
+		case <-timer.C:
+			for change := range pending {
+				s.applyFileChange(ctx, change)
+			}
+			pending = make(map[catalogFileChange]bool)
+		case <-s.stopWatch:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// changesForFile maps a file path to the catalogs and languages it belongs to
+func (s *MessageCatalogService) changesForFile(path string) []catalogFileChange {
+	dir := filepath.Clean(filepath.Dir(path))
+	name := filepath.Base(path)
+
+	var changes []catalogFileChange
+	for i := range s.config.Catalogs {
+		catalog := &s.config.Catalogs[i]
+		if !catalog.Enabled || !isFileSystemCatalog(catalog) || filepath.Clean(catalog.Path) != dir {
+			continue
+		}
+		if name == catalog.StructureFile {
+			changes = append(changes, catalogFileChange{catalogName: catalog.Name})
+			continue
+		}
+		if language, ok := languageFromFileName(catalog, name); ok {
+			changes = append(changes, catalogFileChange{catalogName: catalog.Name, language: language})
+		}
+	}
+	return changes
+}
+
+// applyFileChange reloads what a file change affects and logs the differences
+// It holds the catalog lock so it never interleaves with another reload
+func (s *MessageCatalogService) applyFileChange(ctx context.Context, change catalogFileChange) {
+	catalogConfig := s.findCatalogConfig(change.catalogName)
+	if catalogConfig == nil {
+		return
+	}
+
+	lock := s.catalogLock(change.catalogName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Structure changes affect every language: reload the whole catalog
+	if change.language == "" {
+		if !s.fileChangePassesLint(ctx, change) {
+			return
+		}
+		before := s.cachedLanguageMessages(ctx, change.catalogName, s.config.DefaultLanguage)
+		if err := s.reloadCatalog(ctx, catalogConfig); err != nil {
+			s.logger.Error(ctx, "Invalid catalog structure file, keeping previous data", interfaces.Fields{
+				"catalog_name": change.catalogName,
+				"file":         catalogConfig.StructureFile,
+				"error":        err.Error(),
+			})
+			return
+		}
+		s.logCatalogChanges(ctx, change, before, s.cachedLanguageMessages(ctx, change.catalogName, s.config.DefaultLanguage))
+		return
+	}
+
+	// Added or removed language files change the fallback chains
+	s.forgetCatalogLanguages(change.catalogName)
+
+	// Only languages already in use are reloaded; others load on first request
+	before := s.cachedLanguageMessages(ctx, change.catalogName, change.language)
+	if before == nil && change.language != s.config.DefaultLanguage {
+		s.logger.Debug(ctx, "Catalog language file changed but not loaded", interfaces.Fields{
+			"catalog_name": change.catalogName,
+			"language":     change.language,
+		})
+		return
+	}
+
+	if !s.fileChangePassesLint(ctx, change) {
+		return
+	}
+
+	entry, found, err := s.loadLanguageMessages(ctx, catalogConfig, change.language)
+	if err != nil {
+		s.logger.Error(ctx, "Invalid catalog language file, keeping previous data", interfaces.Fields{
+			"catalog_name": change.catalogName,
+			"language":     change.language,
+			"error":        err.Error(),
+		})
+		return
+	}
+
+	if !found && change.language != s.config.DefaultLanguage {
+		// Language file removed: stop serving the language
+		s.swapCatalogLanguages(ctx, change.catalogName, nil, []string{change.language})
+		s.logCatalogChanges(ctx, change, before, nil)
+		return
+	}
+
+	s.swapCatalogLanguages(ctx, change.catalogName, map[string]*cachedLanguage{change.language: entry}, nil)
+	s.logCatalogChanges(ctx, change, before, entry.messages)
+}
+
+// fileChangePassesLint runs the catalog lint rules before a changed file is swapped in
+// A structure change is checked against the structure and default language, a
+// language change against that language; on lint errors the previous data is kept
+func (s *MessageCatalogService) fileChangePassesLint(ctx context.Context, change catalogFileChange) bool {
+	report := NewLinter(s.loader, s.config.DefaultLanguage).Lint(ctx, []string{change.catalogName})
+
+	var problems []string
+	for _, issue := range report.Issues {
+		if issue.Level != LintError {
+			continue
+		}
+		affected := strings.EqualFold(issue.Language, change.language)
+		if change.language == "" {
+			affected = affected || strings.EqualFold(issue.Language, s.config.DefaultLanguage)
+		}
+		if affected {
+			problems = append(problems, issue.String())
+		}
+	}
+	if len(problems) == 0 {
+		return true
+	}
+
+	language := change.language
+	if language == "" {
+		language = s.config.DefaultLanguage
+	}
+	s.logger.Error(ctx, "Catalog file change failed validation, keeping previous data", interfaces.Fields{
+		"catalog_name": change.catalogName,
+		"language":     language,
+		"structure":    change.language == "",
+		"errors":       problems,
+	})
+	return false
+}
+
+// cachedLanguageMessages returns the cached messages of a catalog language, or nil
+func (s *MessageCatalogService) cachedLanguageMessages(ctx context.Context, catalogName, language string) map[string]*Message {
+	if entry := s.cachedLanguage(ctx, catalogName, language); entry != nil {
+		return entry.messages
+	}
+	return nil
+}
+
+// logCatalogChanges logs the message codes added, removed and modified by a reload
+func (s *MessageCatalogService) logCatalogChanges(ctx context.Context, change catalogFileChange, before, after map[string]*Message) {
+	added, removed, modified := diffMessages(before, after)
+
+	language := change.language
+	if language == "" {
+		language = s.config.DefaultLanguage
+	}
+
+	s.logger.Info(ctx, "Catalog file change applied", interfaces.Fields{
+		"catalog_name":    change.catalogName,
+		"language":        language,
+		"structure":       change.language == "",
+		"added_codes":     added,
+		"removed_codes":   removed,
+		"modified_codes":  modified,
+		"unchanged_count": len(after) - len(added) - len(modified),
+	})
+}
+
+// diffMessages compares two message sets by code
+func diffMessages(before, after map[string]*Message) (added, removed, modified []string) {
+	added, removed, modified = []string{}, []string{}, []string{}
+
+	for code, message := range after {
+		previous, exists := before[code]
+		switch {
+		case !exists:
+			added = append(added, code)
+		case !sameMessage(previous, message):
+			modified = append(modified, code)
+		}
+	}
+	for code := range before {
+		if _, exists := after[code]; !exists {
+			removed = append(removed, code)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(modified)
+	return added, removed, modified
+}
+
+// sameMessage reports whether two messages have the same structure and content
+func sameMessage(a, b *Message) bool {
+	return a.Category == b.Category &&
+		a.Severity == b.Severity &&
+		a.Component == b.Component &&
+		a.Message == b.Message &&
+		a.DetailedDescription == b.DetailedDescription &&
+		a.ResponseAction == b.ResponseAction
+}
+
+// languageFromFileName extracts the language code from a language file name
+// using the catalog's LanguageFilePattern (e.g. "messagecatelog-{lang}.json")
+func languageFromFileName(catalog *config.CatalogConfig, name string) (string, bool) {
+	parts := strings.SplitN(catalog.LanguageFilePattern, "{lang}", 2)
+	if len(parts) != 2 {
+		return "", false
+	}
+	prefix, suffix := parts[0], parts[1]
+	if len(name) <= len(prefix)+len(suffix) || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+		return "", false
+	}
+	return name[len(prefix) : len(name)-len(suffix)], true
+}
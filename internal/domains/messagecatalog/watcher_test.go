@@ -0,0 +1,93 @@
+package messagecatalog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/pkg/config"
+)
+
+func TestDiffMessages(t *testing.T) {
+	before := map[string]*Message{
+		"A": {MessageCode: "A", Message: "one"},
+		"B": {MessageCode: "B", Message: "two"},
+	}
+	after := map[string]*Message{
+		"B": {MessageCode: "B", Message: "deux"},
+		"C": {MessageCode: "C", Message: "three"},
+	}
+
+	added, removed, modified := diffMessages(before, after)
+
+	assert.Equal(t, []string{"C"}, added)
+	assert.Equal(t, []string{"A"}, removed)
+	assert.Equal(t, []string{"B"}, modified)
+}
+
+func TestLanguageFromFileName(t *testing.T) {
+	catalog := &config.CatalogConfig{LanguageFilePattern: "messagecatelog-{lang}.json"}
+
+	language, ok := languageFromFileName(catalog, "messagecatelog-fr-CA.json")
+	assert.True(t, ok)
+	assert.Equal(t, "fr-CA", language)
+
+	_, ok = languageFromFileName(catalog, "messagecatelog.json")
+	assert.False(t, ok)
+}
+
+func TestStartWatching_ReloadsChangedLanguageFile(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "first")
+	service := newTestService(t, dir, 0)
+	service.config.WatchEnabled = true
+	service.config.WatchDebounce = 20
+
+	require.NoError(t, service.StartWatching(context.Background()))
+	defer service.StopWatching()
+
+	// Invalid content is rejected and the previous data kept
+	languageFile := filepath.Join(dir, "messagecatelog-en-US.json")
+	require.NoError(t, os.WriteFile(languageFile, []byte(`{broken`), 0o644))
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, "first", getTestMessage(t, service))
+
+	writeCatalog(t, dir, "edited")
+	assert.Eventually(t, func() bool {
+		return getTestMessage(t, service) == "edited"
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestApplyFileChange_KeepsPreviousDataOnLintErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		change  catalogFileChange
+	}{
+		{"invalid pattern", "messagecatelog-en-US.json", `{"TST0001": {"message": "{count, plural, one {# item}"}}`, catalogFileChange{catalogName: "test", language: "en-US"}},
+		{"missing message", "messagecatelog-en-US.json", `{"TST0001": {"detailed_description": "edited"}}`, catalogFileChange{catalogName: "test", language: "en-US"}},
+		{"invalid severity", "messagecatelog.json", `{"TST0001": {"message_code": "TST0001", "category": "Test", "severity": "URGENT", "component": "Test"}}`, catalogFileChange{catalogName: "test"}},
+		{"untranslated code", "messagecatelog.json", `{"TST0001": {"message_code": "TST0001", "category": "Test", "severity": "LOW", "component": "Test"}, "TST0002": {"message_code": "TST0002", "category": "Test", "severity": "LOW", "component": "Test"}}`, catalogFileChange{catalogName: "test"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeCatalog(t, dir, "first")
+			service := newTestService(t, dir, 0)
+
+			require.NoError(t, os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.content), 0o644))
+			service.applyFileChange(context.Background(), tt.change)
+
+			assert.Equal(t, "first", getTestMessage(t, service))
+			cached := service.cachedLanguageMessages(context.Background(), "test", "en-US")
+			assert.Len(t, cached, 1)
+			assert.Equal(t, "LOW", cached["TST0001"].Severity)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package productregistration
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Sort fields accepted by the product list
+const (
+	SortName      = "name"
+	SortPrice     = "price"
+	SortStock     = "stock"
+	SortUpdatedAt = "updated_at"
+	SortCreatedAt = "created_at"
+	SortRelevance = "relevance" // Full-text searches only
+)
+
+// Sort orders accepted by the product list
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// sortColumns maps each sort field to its column; relevance is computed by the search
+// Every column is paired with id as a tiebreaker so the order is total
+var sortColumns = map[string]string{
+	SortName:      "name",
+	SortPrice:     "price",
+	SortStock:     "stock",
+	SortUpdatedAt: "updated_at",
+	SortCreatedAt: "created_at",
+}
+
+// defaultOrder returns the order used when none is requested:
+// newest and most relevant first, ascending otherwise
+func defaultOrder(sort string) string {
+	if sort == SortUpdatedAt || sort == SortCreatedAt || sort == SortRelevance {
+		return OrderDesc
+	}
+	return OrderAsc
+}
+
+// ProductCursor is a keyset position in a sorted product list
+// Clients only see it encoded; the sort and order are kept in the cursor so
+// a page continues the listing it was taken from
+type ProductCursor struct {
+	Sort     string `json:"s"`
+	Order    string `json:"o"`
+	Value    string `json:"v"`           // Sort column value of the boundary row
+	ID       int64  `json:"id"`          // ID of the boundary row
+	Backward bool   `json:"b,omitempty"` // Walk towards the start of the list (previous page)
+}
+
+// newProductCursor returns the cursor positioned at product
+func newProductCursor(sort, order string, product *ProductRegistration, backward bool) *ProductCursor {
+	return &ProductCursor{
+		Sort:     sort,
+		Order:    order,
+		Value:    sortValue(sort, product),
+		ID:       product.ID,
+		Backward: backward,
+	}
+}
+
+// Encode returns the opaque URL-safe form of the cursor
+func (c *ProductCursor) Encode() string {
+	data, _ := json.Marshal(c) // Only strings, an integer and a bool; cannot fail
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeProductCursor parses a cursor returned by a previous list call
+func DecodeProductCursor(encoded string) (*ProductCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("cursor is not valid base64: %w", err)
+	}
+
+	cursor := &ProductCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, fmt.Errorf("cursor is malformed: %w", err)
+	}
+	if _, found := sortColumns[cursor.Sort]; !found && cursor.Sort != SortRelevance {
+		return nil, fmt.Errorf("cursor has unknown sort field %q", cursor.Sort)
+	}
+	if cursor.Order != OrderAsc && cursor.Order != OrderDesc {
+		return nil, fmt.Errorf("cursor has unknown order %q", cursor.Order)
+	}
+	if err := validateSortValue(cursor.Sort, cursor.Value); err != nil {
+		return nil, fmt.Errorf("cursor has invalid %s value: %w", cursor.Sort, err)
+	}
+	return cursor, nil
+}
+
+// sortValue returns the sort column value of product in the form stored in cursors
+// Prices and timestamps keep full precision so no row is skipped or repeated
+func sortValue(sort string, product *ProductRegistration) string {
+	switch sort {
+	case SortName:
+		return product.Name
+	case SortPrice:
+		return strconv.FormatFloat(product.Price, 'f', -1, 64)
+	case SortStock:
+		return strconv.Itoa(product.Stock)
+	case SortUpdatedAt:
+		return product.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case SortRelevance:
+		// Ranks are single precision in the database; formatting them as such round-trips exactly
+		if product.Search == nil {
+			return "0"
+		}
+		return strconv.FormatFloat(product.Search.Rank, 'g', -1, 32)
+	default:
+		return product.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// validateSortValue checks that a cursor value parses as the type of its sort column
+func validateSortValue(sort, value string) error {
+	var err error
+	switch sort {
+	case SortPrice, SortRelevance:
+		_, err = strconv.ParseFloat(value, 64)
+	case SortStock:
+		_, err = strconv.Atoi(value)
+	case SortUpdatedAt, SortCreatedAt:
+		_, err = time.Parse(time.RFC3339Nano, value)
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+package productregistration
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductCursor_RoundTrip(t *testing.T) {
+	product := &ProductRegistration{
+		ID:        42,
+		Name:      "Café crème",
+		Price:     19.999999,
+		Stock:     7,
+		CreatedAt: time.Date(2026, 10, 18, 9, 30, 0, 123456789, time.FixedZone("CEST", 2*3600)),
+		UpdatedAt: time.Date(2026, 10, 18, 10, 0, 0, 1, time.UTC),
+		Search:    &ProductSearchMatch{Rank: 0.0759909},
+	}
+
+	tests := []struct {
+		sort     string
+		order    string
+		backward bool
+		value    string
+	}{
+		{SortName, OrderAsc, false, "Café crème"},
+		{SortPrice, OrderDesc, true, "19.999999"},
+		{SortStock, OrderAsc, false, "7"},
+		{SortUpdatedAt, OrderDesc, false, "2026-10-18T10:00:00.000000001Z"},
+		{SortCreatedAt, OrderDesc, true, "2026-10-18T07:30:00.123456789Z"},
+		{SortRelevance, OrderDesc, false, "0.0759909"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			cursor := newProductCursor(tt.sort, tt.order, product, tt.backward)
+			assert.Equal(t, tt.value, cursor.Value)
+
+			encoded := cursor.Encode()
+			assert.NotContains(t, encoded, "=")
+
+			decoded, err := DecodeProductCursor(encoded)
+			require.NoError(t, err)
+			assert.Equal(t, cursor, decoded)
+		})
+	}
+}
+
+func TestDecodeProductCursor_RejectsTamperedCursors(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"not base64", "not a cursor!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"s":"name","o":"asc","v":"a","id":1}`))},
+		{"not json", encode("name:asc:a:1")},
+		{"wrong field type", encode(`{"s":"name","o":"asc","v":"a","id":"1"}`)},
+		{"unknown sort", encode(`{"s":"password","o":"asc","v":"a","id":1}`)},
+		{"sql in sort", encode(`{"s":"name; DROP TABLE products","o":"asc","v":"a","id":1}`)},
+		{"unknown order", encode(`{"s":"name","o":"sideways","v":"a","id":1}`)},
+		{"missing order", encode(`{"s":"name","v":"a","id":1}`)},
+		{"price not a number", encode(`{"s":"price","o":"asc","v":"1 OR 1=1","id":1}`)},
+		{"stock not an integer", encode(`{"s":"stock","o":"asc","v":"2.5","id":1}`)},
+		{"timestamp not RFC 3339", encode(`{"s":"created_at","o":"desc","v":"yesterday","id":1}`)},
+		{"relevance not a number", encode(`{"s":"relevance","o":"desc","v":"high","id":1}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := DecodeProductCursor(tt.encoded)
+
+			assert.Error(t, err)
+			assert.Nil(t, cursor)
+		})
+	}
+}
+
+func TestDefaultOrder(t *testing.T) {
+	tests := map[string]string{
+		SortName:      OrderAsc,
+		SortPrice:     OrderAsc,
+		SortStock:     OrderAsc,
+		SortUpdatedAt: OrderDesc,
+		SortCreatedAt: OrderDesc,
+		SortRelevance: OrderDesc,
+	}
+
+	for sort, expected := range tests {
+		assert.Equal(t, expected, defaultOrder(sort), sort)
+	}
+}
@@ -0,0 +1,177 @@
+package productregistration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultLowStockThreshold is the highest stock counted as low when the request gives none
+const defaultLowStockThreshold = 10
+
+// maxFilterCategories bounds the categories of one list request
+const maxFilterCategories = 20
+
+// newProductFilter normalizes the filters of a list request and checks that ranges are not inverted
+func newProductFilter(req *ProductListRequest) (ProductFilter, error) {
+	filter := ProductFilter{
+		IsActive:    req.IsActive,
+		Search:      req.Search,
+		SearchMode:  req.SearchMode,
+		SKUPrefix:   req.SKUPrefix,
+		MinPrice:    req.MinPrice,
+		MaxPrice:    req.MaxPrice,
+		MinStock:    req.MinStock,
+		MaxStock:    req.MaxStock,
+		StockStatus: req.StockStatus,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		UpdatedFrom: req.UpdatedFrom,
+		UpdatedTo:   req.UpdatedTo,
+	}
+
+	// category=a&category=b and category=a,b select the same products
+	for _, value := range req.Categories {
+		for _, category := range strings.Split(value, ",") {
+			if category = strings.TrimSpace(category); category != "" {
+				filter.Categories = append(filter.Categories, category)
+			}
+		}
+	}
+
+	if filter.SearchMode == "" {
+		filter.SearchMode = SearchModeSubstring
+	}
+
+	if len(filter.Categories) > maxFilterCategories {
+		return filter, fmt.Errorf("at most %d categories can be selected", maxFilterCategories)
+	}
+
+	if filter.StockStatus != "" {
+		filter.LowStockThreshold = req.LowStockThreshold
+		if filter.LowStockThreshold <= 0 {
+			filter.LowStockThreshold = defaultLowStockThreshold
+		}
+	}
+
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return filter, fmt.Errorf("min_price %v is greater than max_price %v", *filter.MinPrice, *filter.MaxPrice)
+	}
+	if filter.MinStock != nil && filter.MaxStock != nil && *filter.MinStock > *filter.MaxStock {
+		return filter, fmt.Errorf("min_stock %d is greater than max_stock %d", *filter.MinStock, *filter.MaxStock)
+	}
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedFrom.Before(filter.CreatedTo) {
+		return filter, fmt.Errorf("created_from must be before created_to")
+	}
+	if !filter.UpdatedFrom.IsZero() && !filter.UpdatedTo.IsZero() && !filter.UpdatedFrom.Before(filter.UpdatedTo) {
+		return filter, fmt.Errorf("updated_from must be before updated_to")
+	}
+	return filter, nil
+}
+
+// whereBuilder collects SQL conditions and their positional arguments
+// Values are only ever passed as arguments, never formatted into the SQL
+type whereBuilder struct {
+	conditions []string
+	args       []interface{}
+}
+
+// arg registers a value and returns its placeholder
+func (b *whereBuilder) arg(value interface{}) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// add appends a condition built with placeholders from arg
+func (b *whereBuilder) add(condition string) {
+	b.conditions = append(b.conditions, condition)
+}
+
+// clause returns the WHERE clause, or "" without conditions
+func (b *whereBuilder) clause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// fullTextSearch reports whether the filter searches in full-text mode
+func (f *ProductFilter) fullTextSearch() bool {
+	return f.Search != "" && f.SearchMode == SearchModeFullText
+}
+
+// apply adds the conditions of the filter to b
+// A full-text search depends on the backend and is added by the repository
+func (f *ProductFilter) apply(b *whereBuilder) {
+	if f.Deleted {
+		b.add("deleted_at IS NOT NULL")
+	} else {
+		b.add("deleted_at IS NULL")
+	}
+
+	if len(f.Categories) == 1 {
+		b.add("category = " + b.arg(f.Categories[0]))
+	} else if len(f.Categories) > 1 {
+		placeholders := make([]string, len(f.Categories))
+		for i, category := range f.Categories {
+			placeholders[i] = b.arg(category)
+		}
+		b.add("category IN (" + strings.Join(placeholders, ", ") + ")")
+	}
+
+	if f.IsActive != nil {
+		b.add("is_active = " + b.arg(*f.IsActive))
+	}
+
+	if f.Search != "" && !f.fullTextSearch() {
+		placeholder := b.arg("%" + f.Search + "%")
+		b.add(fmt.Sprintf("(name ILIKE %s OR description ILIKE %s OR sku ILIKE %s)", placeholder, placeholder, placeholder))
+	}
+
+	if f.SKUPrefix != "" {
+		b.add(fmt.Sprintf("sku LIKE %s ESCAPE '%s'", b.arg(escapeLike(f.SKUPrefix)+"%"), likeEscape))
+	}
+
+	if f.MinPrice != nil {
+		b.add("price >= " + b.arg(*f.MinPrice))
+	}
+	if f.MaxPrice != nil {
+		b.add("price <= " + b.arg(*f.MaxPrice))
+	}
+	if f.MinStock != nil {
+		b.add("stock >= " + b.arg(*f.MinStock))
+	}
+	if f.MaxStock != nil {
+		b.add("stock <= " + b.arg(*f.MaxStock))
+	}
+
+	switch f.StockStatus {
+	case StockStatusInStock:
+		b.add("stock > 0")
+	case StockStatusLowStock:
+		b.add("stock BETWEEN 1 AND " + b.arg(f.LowStockThreshold))
+	case StockStatusOutOfStock:
+		b.add("stock = 0")
+	}
+
+	if !f.CreatedFrom.IsZero() {
+		b.add("created_at >= " + b.arg(f.CreatedFrom))
+	}
+	if !f.CreatedTo.IsZero() {
+		b.add("created_at < " + b.arg(f.CreatedTo))
+	}
+	if !f.UpdatedFrom.IsZero() {
+		b.add("updated_at >= " + b.arg(f.UpdatedFrom))
+	}
+	if !f.UpdatedTo.IsZero() {
+		b.add("updated_at < " + b.arg(f.UpdatedTo))
+	}
+}
+
+// likeEscape is the LIKE escape character; unlike a backslash it needs no
+// escaping in the string literals of any SQL backend
+const likeEscape = "!"
+
+// escapeLike escapes the LIKE wildcards in value so it matches literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_").Replace(value)
+}
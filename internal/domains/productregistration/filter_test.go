@@ -0,0 +1,244 @@
+package productregistration
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bindListRequest binds a product list query string the way the list handler does
+func bindListRequest(rawQuery string) (*ProductListRequest, error) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/products?"+rawQuery, nil)
+
+	req := &ProductListRequest{}
+	return req, c.ShouldBindQuery(req)
+}
+
+func TestProductListRequest_Binding(t *testing.T) {
+	tests := []struct {
+		query string
+		valid bool
+	}{
+		{"", true},
+		{"category=a&category=b,c&is_active=true&min_price=1.5&max_stock=3", true},
+		{"stock_status=low_stock&low_stock_threshold=5", true},
+		{"created_from=2026-01-01T00:00:00Z&updated_to=2026-10-18T12:00:00%2B02:00", true},
+		{"search=chair&search_mode=fulltext&sort=relevance&order=desc", true},
+		{"limit=0", true},
+		{"limit=101", false},
+		{"page=-1", false},
+		{"search_mode=regex", false},
+		{"stock_status=plenty", false},
+		{"low_stock_threshold=-1", false},
+		{"min_price=-0.01", false},
+		{"min_stock=abc", false},
+		{"created_from=yesterday", false},
+		{"sort=password", false},
+		{"order=sideways", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := bindListRequest(tt.query)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestNewProductFilter_Normalizes(t *testing.T) {
+	tests := []struct {
+		query      string
+		categories []string
+		searchMode string
+		threshold  int
+	}{
+		{"", nil, SearchModeSubstring, 0},
+		{"category=a&category=b", []string{"a", "b"}, SearchModeSubstring, 0},
+		{"category=a,+b+,,c&category=d", []string{"a", "b", "c", "d"}, SearchModeSubstring, 0},
+		{"category=,", nil, SearchModeSubstring, 0},
+		{"search_mode=fulltext", nil, SearchModeFullText, 0},
+		{"stock_status=low_stock", nil, SearchModeSubstring, defaultLowStockThreshold},
+		{"stock_status=in_stock&low_stock_threshold=3", nil, SearchModeSubstring, 3},
+		{"low_stock_threshold=3", nil, SearchModeSubstring, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req, err := bindListRequest(tt.query)
+			require.NoError(t, err)
+
+			filter, err := newProductFilter(req)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.categories, filter.Categories)
+			assert.Equal(t, tt.searchMode, filter.SearchMode)
+			assert.Equal(t, tt.threshold, filter.LowStockThreshold)
+		})
+	}
+}
+
+func TestNewProductFilter_RejectsInvertedRanges(t *testing.T) {
+	tests := []struct {
+		query string
+		valid bool
+	}{
+		{"min_price=10&max_price=10", true},
+		{"min_price=10.01&max_price=10", false},
+		{"min_stock=5&max_stock=5", true},
+		{"min_stock=6&max_stock=5", false},
+		{"created_from=2026-01-01T00:00:00Z&created_to=2026-01-02T00:00:00Z", true},
+		{"created_from=2026-01-01T00:00:00Z&created_to=2026-01-01T00:00:00Z", false},
+		{"updated_from=2026-01-02T00:00:00Z&updated_to=2026-01-01T00:00:00Z", false},
+		{"category=" + strings.Repeat("c,", maxFilterCategories), true},
+		{"category=" + strings.Repeat("c,", maxFilterCategories+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req, err := bindListRequest(tt.query)
+			require.NoError(t, err)
+
+			_, err = newProductFilter(req)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestProductFilter_Apply(t *testing.T) {
+	active := true
+	minPrice, maxStock := 1.5, 3
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter ProductFilter
+		clause string
+		args   []interface{}
+	}{
+		{
+			name:   "no filters",
+			clause: "WHERE deleted_at IS NULL",
+		},
+		{
+			name:   "deleted",
+			filter: ProductFilter{Deleted: true},
+			clause: "WHERE deleted_at IS NOT NULL",
+		},
+		{
+			name:   "one category",
+			filter: ProductFilter{Categories: []string{"chairs"}, IsActive: &active},
+			clause: "WHERE deleted_at IS NULL AND category = $1 AND is_active = $2",
+			args:   []interface{}{"chairs", true},
+		},
+		{
+			name:   "several categories",
+			filter: ProductFilter{Categories: []string{"chairs", "tables"}},
+			clause: "WHERE deleted_at IS NULL AND category IN ($1, $2)",
+			args:   []interface{}{"chairs", "tables"},
+		},
+		{
+			name:   "substring search reuses its placeholder",
+			filter: ProductFilter{Search: "oak", SearchMode: SearchModeSubstring},
+			clause: "WHERE deleted_at IS NULL AND (name ILIKE $1 OR description ILIKE $1 OR sku ILIKE $1)",
+			args:   []interface{}{"%oak%"},
+		},
+		{
+			name:   "full-text search is left to the repository",
+			filter: ProductFilter{Search: "oak", SearchMode: SearchModeFullText},
+			clause: "WHERE deleted_at IS NULL",
+		},
+		{
+			name:   "sku prefix escapes wildcards",
+			filter: ProductFilter{SKUPrefix: "A_1%!"},
+			clause: "WHERE deleted_at IS NULL AND sku LIKE $1 ESCAPE '!'",
+			args:   []interface{}{"A!_1!%!!%"},
+		},
+		{
+			name:   "ranges",
+			filter: ProductFilter{MinPrice: &minPrice, MaxStock: &maxStock, CreatedFrom: from, UpdatedTo: from},
+			clause: "WHERE deleted_at IS NULL AND price >= $1 AND stock <= $2 AND created_at >= $3 AND updated_at < $4",
+			args:   []interface{}{1.5, 3, from, from},
+		},
+		{
+			name:   "low stock",
+			filter: ProductFilter{StockStatus: StockStatusLowStock, LowStockThreshold: 10},
+			clause: "WHERE deleted_at IS NULL AND stock BETWEEN 1 AND $1",
+			args:   []interface{}{10},
+		},
+		{
+			name:   "out of stock",
+			filter: ProductFilter{StockStatus: StockStatusOutOfStock},
+			clause: "WHERE deleted_at IS NULL AND stock = 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where := &whereBuilder{}
+			tt.filter.apply(where)
+
+			assert.Equal(t, tt.clause, where.clause())
+			assert.Equal(t, tt.args, where.args)
+		})
+	}
+}
+
+func TestNewProductListQuery(t *testing.T) {
+	nameCursor := (&ProductCursor{Sort: SortName, Order: OrderDesc, Value: "m", ID: 9}).Encode()
+
+	tests := []struct {
+		query  string
+		sort   string
+		order  string
+		limit  int
+		offset int
+		err    bool
+	}{
+		{query: "", sort: SortCreatedAt, order: OrderDesc, limit: defaultListLimit},
+		{query: "sort=price", sort: SortPrice, order: OrderAsc, limit: defaultListLimit},
+		{query: "sort=name&order=desc&limit=5&page=3", sort: SortName, order: OrderDesc, limit: 5, offset: 10},
+		{query: "search=oak&search_mode=fulltext", sort: SortRelevance, order: OrderDesc, limit: defaultListLimit},
+		{query: "search=oak&sort=relevance", err: true},
+		{query: "cursor=" + nameCursor + "&page=3", sort: SortName, order: OrderDesc, limit: defaultListLimit},
+		{query: "cursor=" + nameCursor + "&sort=name&order=desc", sort: SortName, order: OrderDesc, limit: defaultListLimit},
+		{query: "cursor=" + nameCursor + "&sort=price", err: true},
+		{query: "cursor=" + nameCursor + "&order=asc", err: true},
+		{query: "cursor=garbage", err: true},
+		{query: "min_stock=6&max_stock=5", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req, err := bindListRequest(tt.query)
+			require.NoError(t, err)
+
+			query, err := newProductListQuery(req)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.sort, query.Sort)
+			assert.Equal(t, tt.order, query.Order)
+			assert.Equal(t, tt.limit, query.Limit)
+			assert.Equal(t, tt.offset, query.Offset)
+			assert.True(t, query.CountTotal)
+		})
+	}
+}
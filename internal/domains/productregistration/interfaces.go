@@ -2,20 +2,43 @@ package productregistration
 
 import (
 	"context"
+	"time"
+
+	"tushartemplategin/pkg/audit"
 )
 
 // Service defines the interface for product registration business logic
+// Writes take the Precondition of the request's If-Match header
 type Service interface {
 	// CRUD operations
 	CreateProduct(ctx context.Context, req *CreateProductRequest) (*ProductRegistration, error)
 	GetProduct(ctx context.Context, id int64) (*ProductRegistration, error)
-	UpdateProduct(ctx context.Context, id int64, req *UpdateProductRequest) (*ProductRegistration, error)
-	DeleteProduct(ctx context.Context, id int64) error
+	UpdateProduct(ctx context.Context, id int64, req *UpdateProductRequest, ifMatch Precondition) (*ProductRegistration, error)
+	DeleteProduct(ctx context.Context, id int64, ifMatch Precondition) error
 	ListProducts(ctx context.Context, req *ProductListRequest) (*ProductListResponse, error)
 
+	// Soft deletion
+	RestoreProduct(ctx context.Context, id int64) (*ProductRegistration, error)
+	ListDeletedProducts(ctx context.Context, req *ProductListRequest) (*ProductListResponse, error)
+	PurgeDeleted(ctx context.Context) (*ProductPurgeResponse, error)
+	StartRetention(ctx context.Context)
+	StopRetention()
+
 	// Additional business operations
 	GetProductBySKU(ctx context.Context, sku string) (*ProductRegistration, error)
-	UpdateStock(ctx context.Context, id int64, stock int) error
+	UpdateStock(ctx context.Context, id int64, stock int, ifMatch Precondition) (int, error)
+
+	// Stock adjustments, reservations and ledger
+	AdjustStock(ctx context.Context, id int64, req *StockAdjustmentRequest) (*StockAdjustmentResponse, error)
+	ReserveStock(ctx context.Context, id int64, req *CreateReservationRequest) (*StockReservation, error)
+	GetReservation(ctx context.Context, id, reservationID int64) (*StockReservation, error)
+	CommitReservation(ctx context.Context, id, reservationID int64) (*StockReservation, error)
+	ReleaseReservation(ctx context.Context, id, reservationID int64) (*StockReservation, error)
+	ExpireReservations(ctx context.Context) (int64, error)
+	GetStockMovements(ctx context.Context, id int64, limit int) ([]*StockMovement, error)
+
+	// Audit trail
+	GetProductHistory(ctx context.Context, id int64, limit int) ([]*audit.Event, error)
 }
 
 // Repository defines the interface for product registration data access
@@ -23,13 +46,29 @@ type Repository interface {
 	// CRUD operations
 	Create(ctx context.Context, product *ProductRegistration) (*ProductRegistration, error)
 	GetByID(ctx context.Context, id int64) (*ProductRegistration, error)
-	Update(ctx context.Context, id int64, product *ProductRegistration) (*ProductRegistration, error)
-	Delete(ctx context.Context, id int64) error
-	List(ctx context.Context, req *ProductListRequest) ([]*ProductRegistration, int64, error)
+	Update(ctx context.Context, id int64, product *ProductRegistration, expectedVersion int) (*ProductRegistration, error)
+	Delete(ctx context.Context, id int64, expectedVersion int) error
+	List(ctx context.Context, query *ProductListQuery) (*ProductPage, error)
+
+	// Soft deletion
+	Restore(ctx context.Context, id int64) (*ProductRegistration, error)
+	PurgeDeleted(ctx context.Context, cutoff time.Time) (int64, error)
 
 	// Additional data operations
 	GetBySKU(ctx context.Context, sku string) (*ProductRegistration, error)
-	UpdateStock(ctx context.Context, id int64, stock int) error
+	UpdateStock(ctx context.Context, id int64, stock int, expectedVersion int) (int, error)
 	Exists(ctx context.Context, id int64) (bool, error)
 	SKUExists(ctx context.Context, sku string, excludeID *int64) (bool, error)
+
+	// Stock adjustments, reservations and ledger
+	AdjustStock(ctx context.Context, id int64, delta int, reference string) (*StockMovement, int, error)
+	Reserve(ctx context.Context, id int64, quantity int, reference string, expiresAt time.Time) (*StockReservation, error)
+	GetReservation(ctx context.Context, id, reservationID int64) (*StockReservation, error)
+	CommitReservation(ctx context.Context, id, reservationID int64) (*StockReservation, error)
+	ReleaseReservation(ctx context.Context, id, reservationID int64) (*StockReservation, error)
+	ExpireReservations(ctx context.Context, now time.Time, limit int) (int64, error)
+	StockMovements(ctx context.Context, id int64, limit int) ([]*StockMovement, error)
+
+	// Audit trail
+	History(ctx context.Context, id int64, limit int) ([]*audit.Event, error)
 }
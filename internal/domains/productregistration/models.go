@@ -2,6 +2,8 @@ package productregistration
 
 import (
 	"time"
+
+	"tushartemplategin/pkg/audit"
 )
 
 // ProductRegistration represents a product registration entity
@@ -16,6 +18,24 @@ type ProductRegistration struct {
 	IsActive    bool      `json:"is_active" db:"is_active"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	// Version counts the writes to the product; it is the ETag of the product
+	Version int `json:"version" db:"version"`
+
+	// DeletedAt is set while the product is soft-deleted
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Search is only set on products listed by a full-text search
+	Search *ProductSearchMatch `json:"search,omitempty" db:"-"`
+}
+
+// ProductSearchMatch describes why a product matched a full-text search
+// Name and Snippet are HTML: the product texts are escaped and <mark></mark>
+// around matched words is the only markup
+type ProductSearchMatch struct {
+	Rank    float64 `json:"rank"`
+	Name    string  `json:"name"`              // HTML-escaped name with matches in <mark> tags
+	Snippet string  `json:"snippet,omitempty"` // HTML-escaped excerpt of the description, marked the same way
 }
 
 // CreateProductRequest represents the request payload for creating a product
@@ -41,23 +61,206 @@ type UpdateProductRequest struct {
 }
 
 // ProductListResponse represents the response for listing products
+// Total is omitted when the request skipped the count; Page is only set for
+// offset pagination
 type ProductListResponse struct {
-	Products []ProductRegistration `json:"products"`
-	Total    int64                 `json:"total"`
-	Page     int                   `json:"page"`
-	Limit    int                   `json:"limit"`
+	Products   []ProductRegistration `json:"products"`
+	Total      *int64                `json:"total,omitempty"`
+	Page       int                   `json:"page,omitempty"`
+	Limit      int                   `json:"limit"`
+	Sort       string                `json:"sort"`
+	Order      string                `json:"order"`
+	HasMore    bool                  `json:"has_more"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+	PrevCursor string                `json:"prev_cursor,omitempty"`
 }
 
 // ProductListRequest represents the request parameters for listing products
+// A cursor from a previous response takes precedence over page, sort and order.
+// category may be repeated or comma-separated; date ranges are RFC 3339, with the
+// lower bound inclusive and the upper bound exclusive
 type ProductListRequest struct {
-	Page     int    `form:"page" binding:"omitempty,min=1"`
-	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
-	Category string `form:"category" binding:"omitempty"`
-	IsActive *bool  `form:"is_active" binding:"omitempty"`
-	Search   string `form:"search" binding:"omitempty"`
+	Page              int       `form:"page" binding:"omitempty,min=1"`
+	Limit             int       `form:"limit" binding:"omitempty,min=1,max=100"`
+	Categories        []string  `form:"category" binding:"omitempty,max=20,dive,max=100"`
+	IsActive          *bool     `form:"is_active" binding:"omitempty"`
+	Search            string    `form:"search" binding:"omitempty"`
+	SearchMode        string    `form:"search_mode" binding:"omitempty,oneof=substring fulltext"`
+	SKUPrefix         string    `form:"sku_prefix" binding:"omitempty,max=50"`
+	MinPrice          *float64  `form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice          *float64  `form:"max_price" binding:"omitempty,min=0"`
+	MinStock          *int      `form:"min_stock" binding:"omitempty,min=0"`
+	MaxStock          *int      `form:"max_stock" binding:"omitempty,min=0"`
+	StockStatus       string    `form:"stock_status" binding:"omitempty,oneof=in_stock low_stock out_of_stock"`
+	LowStockThreshold int       `form:"low_stock_threshold" binding:"omitempty,min=1"`
+	CreatedFrom       time.Time `form:"created_from"`
+	CreatedTo         time.Time `form:"created_to"`
+	UpdatedFrom       time.Time `form:"updated_from"`
+	UpdatedTo         time.Time `form:"updated_to"`
+	Cursor            string    `form:"cursor" binding:"omitempty"`
+	Sort              string    `form:"sort" binding:"omitempty,oneof=name price stock updated_at created_at relevance"`
+	Order             string    `form:"order" binding:"omitempty,oneof=asc desc"`
+	SkipTotal         bool      `form:"skip_total"`
+}
+
+// Search modes accepted by the product list
+const (
+	SearchModeSubstring = "substring" // Case-insensitive substring of the name, description or SKU
+	SearchModeFullText  = "fulltext"  // Web search syntax over the product texts, ranked by relevance
+)
+
+// Stock statuses accepted by the product list filter
+const (
+	StockStatusInStock    = "in_stock"
+	StockStatusLowStock   = "low_stock"
+	StockStatusOutOfStock = "out_of_stock"
+)
+
+// ProductFilter selects the products of a list query; zero fields do not filter
+type ProductFilter struct {
+	Categories        []string
+	IsActive          *bool
+	Search            string
+	SearchMode        string
+	SKUPrefix         string
+	MinPrice          *float64
+	MaxPrice          *float64
+	MinStock          *int
+	MaxStock          *int
+	StockStatus       string
+	LowStockThreshold int // Highest stock counted as low; set whenever StockStatus is
+	CreatedFrom       time.Time
+	CreatedTo         time.Time
+	UpdatedFrom       time.Time
+	UpdatedTo         time.Time
+	Deleted           bool // List soft-deleted products instead of live ones
+}
+
+// ProductListQuery is a validated product list request as run by the repository
+type ProductListQuery struct {
+	Filter     ProductFilter
+	Sort       string
+	Order      string
+	Limit      int
+	Offset     int            // Used only without a cursor
+	Cursor     *ProductCursor // Rows strictly after (or before, when backward) this position
+	CountTotal bool
+}
+
+// ProductPage is one page of products in list order
+type ProductPage struct {
+	Products []*ProductRegistration
+	Total    int64 // Only counted when the query asks for it
+	HasMore  bool  // More rows follow in the direction of the query
 }
 
 // ProductResponse represents the response for a single product
 type ProductResponse struct {
 	Product ProductRegistration `json:"product"`
 }
+
+// ProductPurgeResponse represents the result of a purge of soft-deleted products
+type ProductPurgeResponse struct {
+	Purged int64     `json:"purged"`
+	Cutoff time.Time `json:"cutoff"`
+}
+
+// ProductHistoryRequest represents the query parameters for product history
+type ProductHistoryRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// ProductHistoryResponse represents the audit trail of a product
+type ProductHistoryResponse struct {
+	ProductID int64          `json:"product_id"`
+	Events    []*audit.Event `json:"events"`
+}
+
+// StockMovementReason explains a change of stock in the movement ledger
+type StockMovementReason string
+
+// Stock movement reasons
+const (
+	StockMovementInitial StockMovementReason = "initial" // Stock of a new product
+	StockMovementSet     StockMovementReason = "set"     // Absolute stock from an update or a stock update
+	StockMovementAdjust  StockMovementReason = "adjust"  // Relative adjustment
+	StockMovementReserve StockMovementReason = "reserve" // Held by a reservation
+	StockMovementRelease StockMovementReason = "release" // Returned by a released reservation
+	StockMovementExpire  StockMovementReason = "expire"  // Returned by an expired reservation
+)
+
+// StockMovement is one entry of the stock ledger of a product
+type StockMovement struct {
+	ID            int64               `json:"id" db:"id"`
+	ProductID     int64               `json:"product_id" db:"product_id"`
+	Delta         int                 `json:"delta" db:"delta"`
+	StockAfter    int                 `json:"stock_after" db:"stock_after"`
+	Reason        StockMovementReason `json:"reason" db:"reason"`
+	ReservationID *int64              `json:"reservation_id,omitempty" db:"reservation_id"`
+	Reference     string              `json:"reference,omitempty" db:"reference"`
+	Actor         string              `json:"actor" db:"actor"`
+	CorrelationID string              `json:"correlation_id,omitempty" db:"correlation_id"`
+	CreatedAt     time.Time           `json:"created_at" db:"created_at"`
+}
+
+// ReservationStatus is the state of a stock reservation
+type ReservationStatus string
+
+// Reservation statuses; only active reservations can change
+const (
+	ReservationActive    ReservationStatus = "active"
+	ReservationCommitted ReservationStatus = "committed"
+	ReservationReleased  ReservationStatus = "released"
+	ReservationExpired   ReservationStatus = "expired"
+)
+
+// StockReservation holds stock of a product for a checkout
+// The quantity leaves the product stock when reserved and comes back if the
+// reservation is released or expires; committing it makes the change final
+type StockReservation struct {
+	ID        int64             `json:"id" db:"id"`
+	ProductID int64             `json:"product_id" db:"product_id"`
+	Quantity  int               `json:"quantity" db:"quantity"`
+	Status    ReservationStatus `json:"status" db:"status"`
+	Reference string            `json:"reference,omitempty" db:"reference"`
+	ExpiresAt time.Time         `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// StockAdjustmentRequest represents the request payload for a relative stock change
+type StockAdjustmentRequest struct {
+	Delta     int    `json:"delta" binding:"required,ne=0,min=-2147483647,max=2147483647"`
+	Reference string `json:"reference" binding:"max=255"` // Caller's identifier, e.g. an order or a delivery
+}
+
+// StockAdjustmentResponse represents the result of a stock adjustment and its ledger entry
+type StockAdjustmentResponse struct {
+	ProductID int64          `json:"product_id"`
+	Stock     int            `json:"stock"`
+	Version   int            `json:"version"`
+	Movement  *StockMovement `json:"movement"`
+}
+
+// CreateReservationRequest represents the request payload for reserving stock
+type CreateReservationRequest struct {
+	Quantity   int    `json:"quantity" binding:"required,min=1,max=2147483647"`
+	TTLSeconds int    `json:"ttl_seconds" binding:"omitempty,min=1,max=31536000"` // Defaults to products.reservation_ttl; at most a year
+	Reference  string `json:"reference" binding:"max=255"`
+}
+
+// ReservationResponse represents the response payload for a single reservation
+type ReservationResponse struct {
+	Reservation StockReservation `json:"reservation"`
+}
+
+// StockMovementsRequest represents the query parameters for the stock ledger
+type StockMovementsRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+// StockMovementsResponse represents the stock ledger of a product
+type StockMovementsResponse struct {
+	ProductID int64            `json:"product_id"`
+	Movements []*StockMovement `json:"movements"`
+}
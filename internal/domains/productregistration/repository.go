@@ -4,24 +4,75 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"tushartemplategin/pkg/audit"
+	"tushartemplategin/pkg/errors"
 	"tushartemplategin/pkg/interfaces"
 )
 
+// auditEntityType is the entity type recorded in the audit trail for products
+const auditEntityType = "product"
+
 // ProductRepository implements the Repository interface for product data access
 type ProductRepository struct {
-	db     interfaces.Database
-	logger interfaces.Logger
+	db             interfaces.Database
+	recorder       audit.Recorder
+	fullTextSearch bool
+	logger         interfaces.Logger
 }
 
 // NewProductRepository creates a new product repository
-func NewProductRepository(db interfaces.Database, log interfaces.Logger) Repository {
+// Mutations are recorded through the audit recorder in the same transaction
+// as the change; pass nil to disable auditing. fullTextSearch enables the
+// PostgreSQL text search of search_vector (migration 009); without it
+// full-text searches fall back to portable LIKE matching
+func NewProductRepository(db interfaces.Database, recorder audit.Recorder, fullTextSearch bool, log interfaces.Logger) Repository {
 	return &ProductRepository{
-		db:     db,
-		logger: log,
+		db:             db,
+		recorder:       recorder,
+		fullTextSearch: fullTextSearch,
+		logger:         log,
+	}
+}
+
+// recordAudit writes an audit event for a product change inside tx
+func (r *ProductRepository) recordAudit(ctx context.Context, tx *sql.Tx, id int64, action audit.Action, before, after *ProductRegistration) error {
+	if r.recorder == nil {
+		return nil
 	}
+	var beforeValue, afterValue interface{}
+	if before != nil {
+		beforeValue = before
+	}
+	if after != nil {
+		afterValue = after
+	}
+	event := audit.NewEvent(ctx, auditEntityType, strconv.FormatInt(id, 10), action, beforeValue, afterValue)
+	return r.recorder.Record(ctx, tx, event)
+}
+
+// getByIDForUpdate loads a product and locks its row for the rest of tx
+func (r *ProductRepository) getByIDForUpdate(ctx context.Context, tx *sql.Tx, id int64) (*ProductRegistration, error) {
+	query := `
+		SELECT id, name, description, category, price, sku, stock, is_active, created_at, updated_at, version
+		FROM products
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`
+
+	product := &ProductRegistration{}
+	if err := tx.QueryRowContext(ctx, query, id).Scan(
+		&product.ID, &product.Name, &product.Description, &product.Category,
+		&product.Price, &product.SKU, &product.Stock, &product.IsActive,
+		&product.CreatedAt, &product.UpdatedAt, &product.Version,
+	); err != nil {
+		return nil, err
+	}
+	return product, nil
 }
 
 // Create creates a new product in the database
@@ -29,7 +80,7 @@ func (r *ProductRepository) Create(ctx context.Context, product *ProductRegistra
 	query := `
 		INSERT INTO products (name, description, category, price, sku, stock, is_active, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, created_at, updated_at
+		RETURNING id, created_at, updated_at, version
 	`
 
 	now := time.Now()
@@ -37,10 +88,16 @@ func (r *ProductRepository) Create(ctx context.Context, product *ProductRegistra
 	product.UpdatedAt = now
 
 	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
-		return tx.QueryRowContext(ctx, query,
+		if err := tx.QueryRowContext(ctx, query,
 			product.Name, product.Description, product.Category, product.Price,
 			product.SKU, product.Stock, product.IsActive, product.CreatedAt, product.UpdatedAt,
-		).Scan(&product.ID, &product.CreatedAt, &product.UpdatedAt)
+		).Scan(&product.ID, &product.CreatedAt, &product.UpdatedAt, &product.Version); err != nil {
+			return err
+		}
+		if _, err := r.recordMovement(ctx, tx, product, product.Stock, StockMovementInitial, nil, ""); err != nil {
+			return err
+		}
+		return r.recordAudit(ctx, tx, product.ID, audit.ActionCreate, nil, product)
 	}); err != nil {
 		r.logger.Error(ctx, "Failed to create product", interfaces.Fields{
 			"error": err.Error(),
@@ -58,11 +115,12 @@ func (r *ProductRepository) Create(ctx context.Context, product *ProductRegistra
 }
 
 // GetByID retrieves a product by its ID
+// Returns nil without an error when the product does not exist or is soft-deleted
 func (r *ProductRepository) GetByID(ctx context.Context, id int64) (*ProductRegistration, error) {
 	query := `
-		SELECT id, name, description, category, price, sku, stock, is_active, created_at, updated_at
+		SELECT id, name, description, category, price, sku, stock, is_active, created_at, updated_at, version
 		FROM products
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	product := &ProductRegistration{}
@@ -70,11 +128,11 @@ func (r *ProductRepository) GetByID(ctx context.Context, id int64) (*ProductRegi
 		return tx.QueryRowContext(ctx, query, id).Scan(
 			&product.ID, &product.Name, &product.Description, &product.Category,
 			&product.Price, &product.SKU, &product.Stock, &product.IsActive,
-			&product.CreatedAt, &product.UpdatedAt,
+			&product.CreatedAt, &product.UpdatedAt, &product.Version,
 		)
 	}); err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("product with id %d not found", id)
+			return nil, nil
 		}
 		r.logger.Error(ctx, "Failed to get product by ID", interfaces.Fields{
 			"error": err.Error(),
@@ -86,26 +144,45 @@ func (r *ProductRepository) GetByID(ctx context.Context, id int64) (*ProductRegi
 	return product, nil
 }
 
-// Update updates an existing product
-func (r *ProductRepository) Update(ctx context.Context, id int64, product *ProductRegistration) (*ProductRegistration, error) {
+// Update updates an existing product and increments its version
+// A positive expectedVersion must match the locked row or the update fails with
+// a PRODUCT_VERSION_CONFLICT AppError; a missing product fails with PRODUCT_NOT_FOUND
+func (r *ProductRepository) Update(ctx context.Context, id int64, product *ProductRegistration, expectedVersion int) (*ProductRegistration, error) {
 	query := `
 		UPDATE products 
 		SET name = $1, description = $2, category = $3, price = $4, sku = $5, 
-		    stock = $6, is_active = $7, updated_at = $8
+		    stock = $6, is_active = $7, updated_at = $8, version = version + 1
 		WHERE id = $9
-		RETURNING created_at, updated_at
+		RETURNING created_at, updated_at, version
 	`
 
 	product.UpdatedAt = time.Now()
 
 	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
-		return tx.QueryRowContext(ctx, query,
+		before, err := r.getByIDForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(id, expectedVersion, before.Version); err != nil {
+			return err
+		}
+		if err := tx.QueryRowContext(ctx, query,
 			product.Name, product.Description, product.Category, product.Price,
 			product.SKU, product.Stock, product.IsActive, product.UpdatedAt, id,
-		).Scan(&product.CreatedAt, &product.UpdatedAt)
+		).Scan(&product.CreatedAt, &product.UpdatedAt, &product.Version); err != nil {
+			return err
+		}
+		product.ID = id
+		if _, err := r.recordMovement(ctx, tx, product, product.Stock-before.Stock, StockMovementSet, nil, ""); err != nil {
+			return err
+		}
+		return r.recordAudit(ctx, tx, id, audit.ActionUpdate, before, product)
 	}); err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			return nil, appErr
+		}
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("product with id %d not found", id)
+			return nil, productNotFound(id)
 		}
 		r.logger.Error(ctx, "Failed to update product", interfaces.Fields{
 			"error": err.Error(),
@@ -114,7 +191,6 @@ func (r *ProductRepository) Update(ctx context.Context, id int64, product *Produ
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
 
-	product.ID = id
 	r.logger.Info(ctx, "Product updated successfully", interfaces.Fields{
 		"id":  product.ID,
 		"sku": product.SKU,
@@ -123,20 +199,40 @@ func (r *ProductRepository) Update(ctx context.Context, id int64, product *Produ
 	return product, nil
 }
 
-// Delete removes a product from the database
-func (r *ProductRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM products WHERE id = $1`
+// Delete soft-deletes a product: it disappears from reads and lists but can be
+// restored until it is purged. A positive expectedVersion must match as in Update
+func (r *ProductRepository) Delete(ctx context.Context, id int64, expectedVersion int) error {
+	query := `UPDATE products SET deleted_at = $1, version = version + 1 WHERE id = $2 AND deleted_at IS NULL`
 
 	var rowsAffected int64
 	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
-		result, execErr := tx.ExecContext(ctx, query, id)
+		before, err := r.getByIDForUpdate(ctx, tx, id)
+		if err == sql.ErrNoRows {
+			return nil // rowsAffected stays 0 and is reported as not found
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(id, expectedVersion, before.Version); err != nil {
+			return err
+		}
+		now := time.Now()
+		result, execErr := tx.ExecContext(ctx, query, now, id)
 		if execErr != nil {
 			return execErr
 		}
 		var raErr error
-		rowsAffected, raErr = result.RowsAffected()
-		return raErr
+		if rowsAffected, raErr = result.RowsAffected(); raErr != nil {
+			return raErr
+		}
+		after := *before
+		after.DeletedAt = &now
+		after.Version++
+		return r.recordAudit(ctx, tx, id, audit.ActionDelete, before, &after)
 	}); err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			return appErr
+		}
 		r.logger.Error(ctx, "Failed to delete product", interfaces.Fields{
 			"error": err.Error(),
 			"id":    id,
@@ -145,7 +241,7 @@ func (r *ProductRepository) Delete(ctx context.Context, id int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("product with id %d not found", id)
+		return productNotFound(id)
 	}
 
 	r.logger.Info(ctx, "Product deleted successfully", interfaces.Fields{
@@ -155,69 +251,167 @@ func (r *ProductRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-// List retrieves a list of products with pagination and filtering
-func (r *ProductRepository) List(ctx context.Context, req *ProductListRequest) ([]*ProductRegistration, int64, error) {
-	// Set default values
-	if req.Page <= 0 {
-		req.Page = 1
+// Restore clears the deletion of a soft-deleted product
+// Returns nil without error when no deleted product has the ID
+func (r *ProductRepository) Restore(ctx context.Context, id int64) (*ProductRegistration, error) {
+	lockQuery := `
+		SELECT id, name, description, category, price, sku, stock, is_active, created_at, updated_at, version, deleted_at
+		FROM products
+		WHERE id = $1 AND deleted_at IS NOT NULL
+		FOR UPDATE
+	`
+	query := `UPDATE products SET deleted_at = NULL, version = version + 1 WHERE id = $1 RETURNING updated_at, version`
+
+	var product *ProductRegistration
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		before := &ProductRegistration{}
+		if err := tx.QueryRowContext(ctx, lockQuery, id).Scan(
+			&before.ID, &before.Name, &before.Description, &before.Category,
+			&before.Price, &before.SKU, &before.Stock, &before.IsActive,
+			&before.CreatedAt, &before.UpdatedAt, &before.Version, &before.DeletedAt,
+		); err != nil {
+			if err == sql.ErrNoRows {
+				return nil // product stays nil and is reported as not found
+			}
+			return err
+		}
+
+		after := *before
+		after.DeletedAt = nil
+		if err := tx.QueryRowContext(ctx, query, id).Scan(&after.UpdatedAt, &after.Version); err != nil {
+			return err
+		}
+		product = &after
+		return r.recordAudit(ctx, tx, id, audit.ActionRestore, before, &after)
+	}); err != nil {
+		r.logger.Error(ctx, "Failed to restore product", interfaces.Fields{
+			"error": err.Error(),
+			"id":    id,
+		})
+		return nil, fmt.Errorf("failed to restore product: %w", err)
 	}
-	if req.Limit <= 0 {
-		req.Limit = 10
+
+	if product != nil {
+		r.logger.Info(ctx, "Product restored successfully", interfaces.Fields{
+			"id":  id,
+			"sku": product.SKU,
+		})
 	}
 
-	offset := (req.Page - 1) * req.Limit
+	return product, nil
+}
+
+// PurgeDeleted permanently removes products soft-deleted before cutoff
+// Each purged product is recorded in the audit trail in the same transaction
+func (r *ProductRepository) PurgeDeleted(ctx context.Context, cutoff time.Time) (int64, error) {
+	query := `
+		DELETE FROM products
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+		RETURNING id, name, description, category, price, sku, stock, is_active, created_at, updated_at, version, deleted_at
+	`
+
+	var purged int64
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, cutoff)
+		if err != nil {
+			return err
+		}
 
-	// Build WHERE clause
-	whereConditions := []string{}
-	args := []interface{}{}
-	argIndex := 1
+		// Read every row before recording: the transaction cannot run another
+		// statement while the result set is open
+		var products []*ProductRegistration
+		for rows.Next() {
+			product := &ProductRegistration{}
+			if err := rows.Scan(
+				&product.ID, &product.Name, &product.Description, &product.Category,
+				&product.Price, &product.SKU, &product.Stock, &product.IsActive,
+				&product.CreatedAt, &product.UpdatedAt, &product.Version, &product.DeletedAt,
+			); err != nil {
+				rows.Close()
+				return err
+			}
+			products = append(products, product)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
 
-	if req.Category != "" {
-		whereConditions = append(whereConditions, fmt.Sprintf("category = $%d", argIndex))
-		args = append(args, req.Category)
-		argIndex++
+		for _, product := range products {
+			if err := r.recordAudit(ctx, tx, product.ID, audit.ActionPurge, product, nil); err != nil {
+				return err
+			}
+		}
+		purged = int64(len(products))
+		return nil
+	}); err != nil {
+		r.logger.Error(ctx, "Failed to purge deleted products", interfaces.Fields{
+			"error":  err.Error(),
+			"cutoff": cutoff,
+		})
+		return 0, fmt.Errorf("failed to purge deleted products: %w", err)
 	}
 
-	if req.IsActive != nil {
-		whereConditions = append(whereConditions, fmt.Sprintf("is_active = $%d", argIndex))
-		args = append(args, *req.IsActive)
-		argIndex++
+	return purged, nil
+}
+
+// List retrieves one page of products with filtering
+// With a cursor the page is read by keyset, so its cost does not grow with the
+// position in the list; without one it falls back to OFFSET. One extra row is
+// fetched to tell whether more follow. The total is counted only on request.
+func (r *ProductRepository) List(ctx context.Context, query *ProductListQuery) (*ProductPage, error) {
+	// Build WHERE clause; the count ignores the cursor
+	where := &whereBuilder{}
+	query.Filter.apply(where)
+	ranking := r.applyFullTextSearch(&query.Filter, where)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM products %s", where.clause())
+	countArgs := append([]interface{}{}, where.args...)
+
+	// Walking backward reverses the order; the page is flipped back after reading
+	column := sortColumns[query.Sort]
+	if query.Sort == SortRelevance && ranking != nil {
+		column = ranking.rank
+	}
+	descending := query.Order == OrderDesc
+	if query.Cursor != nil && query.Cursor.Backward {
+		descending = !descending
+	}
+	direction, comparison := "ASC", ">"
+	if descending {
+		direction, comparison = "DESC", "<"
 	}
 
-	if req.Search != "" {
-		searchPattern := "%" + req.Search + "%"
-		whereConditions = append(whereConditions, fmt.Sprintf("(name ILIKE $%d OR description ILIKE $%d OR sku ILIKE $%d)", argIndex, argIndex, argIndex))
-		args = append(args, searchPattern)
-		argIndex++
+	if query.Cursor != nil {
+		where.add(fmt.Sprintf("(%s, id) %s (%s, %s)", column, comparison, where.arg(query.Cursor.Value), where.arg(query.Cursor.ID)))
 	}
 
-	whereClause := ""
-	if len(whereConditions) > 0 {
-		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	columns := "id, name, description, category, price, sku, stock, is_active, created_at, updated_at, version, deleted_at"
+	if ranking != nil {
+		columns += ", " + strings.Join(ranking.columns, ", ")
 	}
 
-	// Count total records and fetch rows within a single transaction
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM products %s", whereClause)
-	query := fmt.Sprintf(`
-		SELECT id, name, description, category, price, sku, stock, is_active, created_at, updated_at
+	listQuery := fmt.Sprintf(`
+		SELECT %s
 		FROM products
 		%s
-		ORDER BY created_at DESC
-		LIMIT $%d OFFSET $%d
-	`, whereClause, argIndex, argIndex+1)
+		ORDER BY %s %s, id %s
+		LIMIT %s
+	`, columns, where.clause(), column, direction, direction, where.arg(query.Limit+1))
 
-	args = append(args, req.Limit, offset)
+	if query.Cursor == nil && query.Offset > 0 {
+		listQuery += " OFFSET " + where.arg(query.Offset)
+	}
 
-	var (
-		total    int64
-		products []*ProductRegistration
-	)
+	page := &ProductPage{Products: []*ProductRegistration{}}
 
+	// Count total records and fetch rows within a single transaction
 	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
-		if err := tx.QueryRowContext(ctx, countQuery, args[:len(args)-2]...).Scan(&total); err != nil { // exclude limit/offset for count
-			return err
+		if query.CountTotal {
+			if err := tx.QueryRowContext(ctx, countQuery, countArgs...).Scan(&page.Total); err != nil {
+				return err
+			}
 		}
-		rows, qerr := tx.QueryContext(ctx, query, args...)
+		rows, qerr := tx.QueryContext(ctx, listQuery, where.args...)
 		if qerr != nil {
 			return qerr
 		}
@@ -225,32 +419,58 @@ func (r *ProductRepository) List(ctx context.Context, req *ProductListRequest) (
 
 		for rows.Next() {
 			product := &ProductRegistration{}
-			if err := rows.Scan(
+			dest := []interface{}{
 				&product.ID, &product.Name, &product.Description, &product.Category,
 				&product.Price, &product.SKU, &product.Stock, &product.IsActive,
-				&product.CreatedAt, &product.UpdatedAt,
-			); err != nil {
+				&product.CreatedAt, &product.UpdatedAt, &product.Version, &product.DeletedAt,
+			}
+			if ranking != nil {
+				product.Search = &ProductSearchMatch{}
+				dest = append(dest, &product.Search.Rank)
+				if len(ranking.columns) > 1 {
+					dest = append(dest, &product.Search.Name, &product.Search.Snippet)
+				}
+			}
+			if err := rows.Scan(dest...); err != nil {
 				return err
 			}
-			products = append(products, product)
+			switch {
+			case ranking == nil:
+			case len(ranking.columns) > 1:
+				product.Search.Name = markHeadline(product.Search.Name)
+				product.Search.Snippet = markHeadline(product.Search.Snippet)
+			default:
+				product.Search.Name = highlight(product.Name, ranking.pattern)
+				product.Search.Snippet = snippet(product.Description, ranking.pattern)
+			}
+			page.Products = append(page.Products, product)
 		}
 		return rows.Err()
 	}); err != nil {
 		r.logger.Error(ctx, "Failed to list products", interfaces.Fields{
 			"error": err.Error(),
 		})
-		return nil, 0, fmt.Errorf("failed to list products: %w", err)
+		return nil, fmt.Errorf("failed to list products: %w", err)
 	}
 
-	return products, total, nil
+	if len(page.Products) > query.Limit {
+		page.HasMore = true
+		page.Products = page.Products[:query.Limit]
+	}
+	if query.Cursor != nil && query.Cursor.Backward {
+		slices.Reverse(page.Products)
+	}
+
+	return page, nil
 }
 
 // GetBySKU retrieves a product by its SKU
+// Returns nil without an error when no live product has the SKU
 func (r *ProductRepository) GetBySKU(ctx context.Context, sku string) (*ProductRegistration, error) {
 	query := `
-		SELECT id, name, description, category, price, sku, stock, is_active, created_at, updated_at
+		SELECT id, name, description, category, price, sku, stock, is_active, created_at, updated_at, version
 		FROM products
-		WHERE sku = $1
+		WHERE sku = $1 AND deleted_at IS NULL
 	`
 
 	product := &ProductRegistration{}
@@ -258,11 +478,11 @@ func (r *ProductRepository) GetBySKU(ctx context.Context, sku string) (*ProductR
 		return tx.QueryRowContext(ctx, query, sku).Scan(
 			&product.ID, &product.Name, &product.Description, &product.Category,
 			&product.Price, &product.SKU, &product.Stock, &product.IsActive,
-			&product.CreatedAt, &product.UpdatedAt,
+			&product.CreatedAt, &product.UpdatedAt, &product.Version,
 		)
 	}); err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("product with sku %s not found", sku)
+			return nil, nil
 		}
 		r.logger.Error(ctx, "Failed to get product by SKU", interfaces.Fields{
 			"error": err.Error(),
@@ -274,47 +494,68 @@ func (r *ProductRepository) GetBySKU(ctx context.Context, sku string) (*ProductR
 	return product, nil
 }
 
-// UpdateStock updates the stock quantity of a product
-func (r *ProductRepository) UpdateStock(ctx context.Context, id int64, stock int) error {
+// UpdateStock updates the stock quantity of a product and returns its new version
+// A positive expectedVersion must match as in Update
+func (r *ProductRepository) UpdateStock(ctx context.Context, id int64, stock int, expectedVersion int) (int, error) {
 	query := `
 		UPDATE products 
-		SET stock = $1, updated_at = $2
+		SET stock = $1, updated_at = $2, version = version + 1
 		WHERE id = $3
+		RETURNING version
 	`
 
-	var rowsAffected int64
+	version := 0
 	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
-		result, execErr := tx.ExecContext(ctx, query, stock, time.Now(), id)
-		if execErr != nil {
-			return execErr
+		before, err := r.getByIDForUpdate(ctx, tx, id)
+		if err == sql.ErrNoRows {
+			return nil // version stays 0 and is reported as not found
 		}
-		var raErr error
-		rowsAffected, raErr = result.RowsAffected()
-		return raErr
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(id, expectedVersion, before.Version); err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := tx.QueryRowContext(ctx, query, stock, now, id).Scan(&version); err != nil {
+			return err
+		}
+		after := *before
+		after.Stock = stock
+		after.UpdatedAt = now
+		after.Version = version
+		if _, err := r.recordMovement(ctx, tx, &after, stock-before.Stock, StockMovementSet, nil, ""); err != nil {
+			return err
+		}
+		return r.recordAudit(ctx, tx, id, audit.ActionStockUpdate, before, &after)
 	}); err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			return 0, appErr
+		}
 		r.logger.Error(ctx, "Failed to update product stock", interfaces.Fields{
 			"error": err.Error(),
 			"id":    id,
 			"stock": stock,
 		})
-		return fmt.Errorf("failed to update product stock: %w", err)
+		return 0, fmt.Errorf("failed to update product stock: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("product with id %d not found", id)
+	if version == 0 {
+		return 0, productNotFound(id)
 	}
 
 	r.logger.Info(ctx, "Product stock updated successfully", interfaces.Fields{
-		"id":    id,
-		"stock": stock,
+		"id":      id,
+		"stock":   stock,
+		"version": version,
 	})
 
-	return nil
+	return version, nil
 }
 
-// Exists checks if a product exists by ID
+// Exists checks if a product exists by ID; soft-deleted products do not
 func (r *ProductRepository) Exists(ctx context.Context, id int64) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM products WHERE id = $1)`
+	query := `SELECT EXISTS(SELECT 1 FROM products WHERE id = $1 AND deleted_at IS NULL)`
 
 	var exists bool
 	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
@@ -331,6 +572,7 @@ func (r *ProductRepository) Exists(ctx context.Context, id int64) (bool, error)
 }
 
 // SKUExists checks if a SKU already exists (optionally excluding a specific product ID)
+// Soft-deleted products keep their SKU until purged, so restoring one never conflicts
 func (r *ProductRepository) SKUExists(ctx context.Context, sku string, excludeID *int64) (bool, error) {
 	var query string
 	var args []interface{}
@@ -356,3 +598,11 @@ func (r *ProductRepository) SKUExists(ctx context.Context, sku string, excludeID
 
 	return exists, nil
 }
+
+// History returns the audit trail for a product, newest first
+func (r *ProductRepository) History(ctx context.Context, id int64, limit int) ([]*audit.Event, error) {
+	if r.recorder == nil {
+		return []*audit.Event{}, nil
+	}
+	return r.recorder.ListByEntity(ctx, auditEntityType, strconv.FormatInt(id, 10), limit)
+}
@@ -1,9 +1,12 @@
 package productregistration
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"tushartemplategin/pkg/errors"
@@ -11,7 +14,10 @@ import (
 )
 
 // RegisterRoutes registers all product registration-related routes to the given router group
-func RegisterRoutes(router *gin.RouterGroup) {
+// adminAuth guards every change to products and stock, the audit history, the stock
+// ledger and the listing, restore and purge of deleted products; the principal it authenticates is
+// recorded as the actor of audit events and stock movements
+func RegisterRoutes(router *gin.RouterGroup, adminAuth gin.HandlerFunc) {
 	// Create a product registration group under the main API group
 	// This will create routes like /api/v1/products, /api/v1/products/:id, etc.
 	productGroup := router.Group("/products")
@@ -19,26 +25,56 @@ func RegisterRoutes(router *gin.RouterGroup) {
 		// Register product endpoints with their handlers
 		// Each endpoint is clearly defined and easy to maintain
 
-		// POST /products - Create a new product
-		productGroup.POST("", createProductHandler)
+		// POST /products - Create a new product (authenticated)
+		productGroup.POST("", adminAuth, createProductHandler)
 
 		// GET /products - List all products with pagination and filtering
 		productGroup.GET("", listProductsHandler)
 
+		// GET /products/deleted - List soft-deleted products (authenticated)
+		productGroup.GET("/deleted", adminAuth, listDeletedProductsHandler)
+
+		// POST /products/purge - Permanently remove products deleted before the retention period (authenticated)
+		productGroup.POST("/purge", adminAuth, purgeDeletedProductsHandler)
+
 		// GET /products/:id - Get a specific product by ID
 		productGroup.GET("/:id", getProductHandler)
 
-		// PUT /products/:id - Update a specific product
-		productGroup.PUT("/:id", updateProductHandler)
+		// PUT /products/:id - Update a specific product (authenticated)
+		productGroup.PUT("/:id", adminAuth, updateProductHandler)
 
-		// DELETE /products/:id - Delete a specific product
-		productGroup.DELETE("/:id", deleteProductHandler)
+		// DELETE /products/:id - Soft-delete a specific product (authenticated)
+		productGroup.DELETE("/:id", adminAuth, deleteProductHandler)
+
+		// POST /products/:id/restore - Restore a soft-deleted product (authenticated)
+		productGroup.POST("/:id/restore", adminAuth, restoreProductHandler)
 
 		// GET /products/sku/:sku - Get a product by SKU
 		productGroup.GET("/sku/:sku", getProductBySKUHandler)
 
-		// PATCH /products/:id/stock - Update product stock
-		productGroup.PATCH("/:id/stock", updateStockHandler)
+		// PATCH /products/:id/stock - Update product stock (authenticated)
+		productGroup.PATCH("/:id/stock", adminAuth, updateStockHandler)
+
+		// POST /products/:id/stock/adjust - Add to or remove from product stock atomically (authenticated)
+		productGroup.POST("/:id/stock/adjust", adminAuth, adjustStockHandler)
+
+		// GET /products/:id/stock/movements - Get the stock ledger of a product (authenticated)
+		productGroup.GET("/:id/stock/movements", adminAuth, getStockMovementsHandler)
+
+		// POST /products/:id/reservations - Reserve product stock for a checkout (authenticated)
+		productGroup.POST("/:id/reservations", adminAuth, createReservationHandler)
+
+		// GET /products/:id/reservations/:reservation_id - Get a stock reservation
+		productGroup.GET("/:id/reservations/:reservation_id", getReservationHandler)
+
+		// POST /products/:id/reservations/:reservation_id/commit - Make a reservation final (authenticated)
+		productGroup.POST("/:id/reservations/:reservation_id/commit", adminAuth, commitReservationHandler)
+
+		// POST /products/:id/reservations/:reservation_id/release - Cancel a reservation and return its stock (authenticated)
+		productGroup.POST("/:id/reservations/:reservation_id/release", adminAuth, releaseReservationHandler)
+
+		// GET /products/:id/history - Get the audit trail of a product (authenticated)
+		productGroup.GET("/:id/history", adminAuth, getProductHistoryHandler)
 	}
 }
 
@@ -69,6 +105,7 @@ func createProductHandler(c *gin.Context) {
 	}
 
 	// Return created product with 201 Created
+	c.Header("ETag", productETag(product.Version))
 	c.JSON(http.StatusCreated, ProductResponse{Product: *product})
 }
 
@@ -77,6 +114,19 @@ func listProductsHandler(c *gin.Context) {
 	// Get the product service from the context
 	productService := c.MustGet("productService").(Service)
 
+	respondWithProductList(c, productService.ListProducts)
+}
+
+// listDeletedProductsHandler handles listing soft-deleted products
+func listDeletedProductsHandler(c *gin.Context) {
+	// Get the product service from the context
+	productService := c.MustGet("productService").(Service)
+
+	respondWithProductList(c, productService.ListDeletedProducts)
+}
+
+// respondWithProductList binds the list query, runs list and writes the page with its Link header
+func respondWithProductList(c *gin.Context, list func(ctx context.Context, req *ProductListRequest) (*ProductListResponse, error)) {
 	ctx := c.Request.Context()
 
 	// Parse query parameters
@@ -87,7 +137,7 @@ func listProductsHandler(c *gin.Context) {
 	}
 
 	// List products through service layer
-	response, err := productService.ListProducts(ctx, &req)
+	response, err := list(ctx, &req)
 	if err != nil {
 		if appErr := errors.GetAppError(err); appErr != nil {
 			middleware.HandleAppError(c, appErr)
@@ -97,10 +147,41 @@ func listProductsHandler(c *gin.Context) {
 		return
 	}
 
-	// Return product list with 200 OK
+	// Advertise the neighbouring pages (RFC 8288) and return the list with 200 OK
+	c.Header("Link", productListLinks(c.Request.URL, response))
 	c.JSON(http.StatusOK, response)
 }
 
+// productListLinks builds the Link header of a product list response
+// Each link repeats the request's filters and limit with the page's cursor;
+// cursor links drop page, sort and order because the cursor carries them
+func productListLinks(requestURL *url.URL, response *ProductListResponse) string {
+	link := func(cursor, rel string) string {
+		query := requestURL.Query()
+		query.Del("cursor")
+		query.Del("page")
+		if cursor != "" {
+			query.Del("sort")
+			query.Del("order")
+			query.Set("cursor", cursor)
+		} else {
+			query.Set("sort", response.Sort)
+			query.Set("order", response.Order)
+		}
+		target := url.URL{Path: requestURL.Path, RawQuery: query.Encode()}
+		return fmt.Sprintf(`<%s>; rel="%s"`, target.String(), rel)
+	}
+
+	links := []string{link("", "first")}
+	if response.PrevCursor != "" {
+		links = append(links, link(response.PrevCursor, "prev"))
+	}
+	if response.NextCursor != "" {
+		links = append(links, link(response.NextCursor, "next"))
+	}
+	return strings.Join(links, ", ")
+}
+
 // getProductHandler handles getting a specific product by ID
 func getProductHandler(c *gin.Context) {
 	// Get the product service from the context
@@ -133,6 +214,7 @@ func getProductHandler(c *gin.Context) {
 	}
 
 	// Return product with 200 OK
+	c.Header("ETag", productETag(product.Version))
 	c.JSON(http.StatusOK, ProductResponse{Product: *product})
 }
 
@@ -158,8 +240,14 @@ func updateProductHandler(c *gin.Context) {
 		return
 	}
 
+	// Only apply the update to the version the client read
+	ifMatch, ok := ifMatchPrecondition(c)
+	if !ok {
+		return
+	}
+
 	// Update product through service layer
-	product, err := productService.UpdateProduct(ctx, id, &req)
+	product, err := productService.UpdateProduct(ctx, id, &req, ifMatch)
 	if err != nil {
 		if appErr := errors.GetAppError(err); appErr != nil {
 			middleware.HandleAppError(c, appErr)
@@ -175,6 +263,7 @@ func updateProductHandler(c *gin.Context) {
 	}
 
 	// Return updated product with 200 OK
+	c.Header("ETag", productETag(product.Version))
 	c.JSON(http.StatusOK, ProductResponse{Product: *product})
 }
 
@@ -193,8 +282,14 @@ func deleteProductHandler(c *gin.Context) {
 		return
 	}
 
+	// Only delete the version the client read
+	ifMatch, ok := ifMatchPrecondition(c)
+	if !ok {
+		return
+	}
+
 	// Delete product through service layer
-	err = productService.DeleteProduct(ctx, id)
+	err = productService.DeleteProduct(ctx, id, ifMatch)
 	if err != nil {
 		if appErr := errors.GetAppError(err); appErr != nil {
 			middleware.HandleAppError(c, appErr)
@@ -213,6 +308,55 @@ func deleteProductHandler(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// restoreProductHandler handles restoring a soft-deleted product
+func restoreProductHandler(c *gin.Context) {
+	// Get the product service from the context
+	productService := c.MustGet("productService").(Service)
+
+	ctx := c.Request.Context()
+
+	// Parse product ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid product ID", "Product ID must be a valid integer", http.StatusBadRequest))
+		return
+	}
+
+	// Restore product through service layer
+	product, err := productService.RestoreProduct(ctx, id)
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			middleware.HandleAppError(c, appErr)
+		} else {
+			middleware.HandleAppError(c, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to restore product", http.StatusInternalServerError, err))
+		}
+		return
+	}
+
+	// Return restored product with 200 OK
+	c.Header("ETag", productETag(product.Version))
+	c.JSON(http.StatusOK, ProductResponse{Product: *product})
+}
+
+// purgeDeletedProductsHandler handles manual purges of expired deleted products
+func purgeDeletedProductsHandler(c *gin.Context) {
+	// Get the product service from the context
+	productService := c.MustGet("productService").(Service)
+
+	response, err := productService.PurgeDeleted(c.Request.Context())
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			middleware.HandleAppError(c, appErr)
+		} else {
+			middleware.HandleAppError(c, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to purge deleted products", http.StatusInternalServerError, err))
+		}
+		return
+	}
+
+	// Return purge result with 200 OK
+	c.JSON(http.StatusOK, response)
+}
+
 // getProductBySKUHandler handles getting a product by SKU
 func getProductBySKUHandler(c *gin.Context) {
 	// Get the product service from the context
@@ -244,6 +388,7 @@ func getProductBySKUHandler(c *gin.Context) {
 	}
 
 	// Return product with 200 OK
+	c.Header("ETag", productETag(product.Version))
 	c.JSON(http.StatusOK, ProductResponse{Product: *product})
 }
 
@@ -277,8 +422,14 @@ func updateStockHandler(c *gin.Context) {
 		return
 	}
 
+	// Only update the stock of the version the client read
+	ifMatch, ok := ifMatchPrecondition(c)
+	if !ok {
+		return
+	}
+
 	// Update stock through service layer
-	err = productService.UpdateStock(ctx, id, req.Stock)
+	version, err := productService.UpdateStock(ctx, id, req.Stock, ifMatch)
 	if err != nil {
 		if appErr := errors.GetAppError(err); appErr != nil {
 			middleware.HandleAppError(c, appErr)
@@ -294,9 +445,220 @@ func updateStockHandler(c *gin.Context) {
 	}
 
 	// Return success with 200 OK
+	c.Header("ETag", productETag(version))
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Stock updated successfully",
 		"id":      id,
 		"stock":   req.Stock,
+		"version": version,
+	})
+}
+
+// adjustStockHandler handles relative stock adjustments
+func adjustStockHandler(c *gin.Context) {
+	// Get the product service from the context
+	productService := c.MustGet("productService").(Service)
+
+	ctx := c.Request.Context()
+
+	// Parse product ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid product ID", "Product ID must be a valid integer", http.StatusBadRequest))
+		return
+	}
+
+	// Parse request body
+	var req StockAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid request body", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	// Adjust stock through service layer
+	response, err := productService.AdjustStock(ctx, id, &req)
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			middleware.HandleAppError(c, appErr)
+		} else {
+			middleware.HandleAppError(c, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to adjust stock", http.StatusInternalServerError, err))
+		}
+		return
+	}
+
+	// Return adjusted stock with 200 OK
+	c.Header("ETag", productETag(response.Version))
+	c.JSON(http.StatusOK, response)
+}
+
+// getStockMovementsHandler handles stock ledger requests
+func getStockMovementsHandler(c *gin.Context) {
+	// Get the product service from the context
+	productService := c.MustGet("productService").(Service)
+
+	ctx := c.Request.Context()
+
+	// Parse product ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid product ID", "Product ID must be a valid integer", http.StatusBadRequest))
+		return
+	}
+
+	// Parse query parameters
+	var req StockMovementsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid query parameters", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	// Get movements through service layer
+	movements, err := productService.GetStockMovements(ctx, id, req.Limit)
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			middleware.HandleAppError(c, appErr)
+		} else {
+			middleware.HandleAppError(c, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to get stock movements", http.StatusInternalServerError, err))
+		}
+		return
+	}
+
+	// Return movements with 200 OK
+	c.JSON(http.StatusOK, StockMovementsResponse{
+		ProductID: id,
+		Movements: movements,
+	})
+}
+
+// createReservationHandler handles stock reservation requests
+func createReservationHandler(c *gin.Context) {
+	// Get the product service from the context
+	productService := c.MustGet("productService").(Service)
+
+	ctx := c.Request.Context()
+
+	// Parse product ID from URL parameter
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid product ID", "Product ID must be a valid integer", http.StatusBadRequest))
+		return
+	}
+
+	// Parse request body
+	var req CreateReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid request body", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	// Reserve stock through service layer
+	reservation, err := productService.ReserveStock(ctx, id, &req)
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			middleware.HandleAppError(c, appErr)
+		} else {
+			middleware.HandleAppError(c, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to reserve stock", http.StatusInternalServerError, err))
+		}
+		return
+	}
+
+	// Return created reservation with 201 Created
+	c.JSON(http.StatusCreated, ReservationResponse{Reservation: *reservation})
+}
+
+// getReservationHandler handles getting a stock reservation
+func getReservationHandler(c *gin.Context) {
+	productService := c.MustGet("productService").(Service)
+	respondWithReservation(c, productService.GetReservation)
+}
+
+// commitReservationHandler handles committing a stock reservation
+func commitReservationHandler(c *gin.Context) {
+	productService := c.MustGet("productService").(Service)
+	respondWithReservation(c, productService.CommitReservation)
+}
+
+// releaseReservationHandler handles releasing a stock reservation
+func releaseReservationHandler(c *gin.Context) {
+	productService := c.MustGet("productService").(Service)
+	respondWithReservation(c, productService.ReleaseReservation)
+}
+
+// respondWithReservation parses the product and reservation IDs, runs operation and writes the reservation
+func respondWithReservation(c *gin.Context, operation func(ctx context.Context, id, reservationID int64) (*StockReservation, error)) {
+	// Parse product and reservation IDs from URL parameters
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid product ID", "Product ID must be a valid integer", http.StatusBadRequest))
+		return
+	}
+	reservationID, err := strconv.ParseInt(c.Param("reservation_id"), 10, 64)
+	if err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid reservation ID", "Reservation ID must be a valid integer", http.StatusBadRequest))
+		return
+	}
+
+	reservation, err := operation(c.Request.Context(), id, reservationID)
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			middleware.HandleAppError(c, appErr)
+		} else {
+			middleware.HandleAppError(c, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to process reservation", http.StatusInternalServerError, err))
+		}
+		return
+	}
+
+	// Return reservation with 200 OK
+	c.JSON(http.StatusOK, ReservationResponse{Reservation: *reservation})
+}
+
+// ifMatchPrecondition returns the precondition the If-Match header of a write asks for
+// Responds with the error and returns false when the header is malformed or matches nothing
+func ifMatchPrecondition(c *gin.Context) (Precondition, bool) {
+	ifMatch, err := parseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		middleware.HandleAppError(c, errors.GetAppError(err))
+		return Precondition{}, false
+	}
+	return ifMatch, true
+}
+
+// getProductHistoryHandler handles product audit trail requests
+func getProductHistoryHandler(c *gin.Context) {
+	// Get the product service from the context
+	productService := c.MustGet("productService").(Service)
+
+	ctx := c.Request.Context()
+
+	// Parse product ID from URL parameter
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid product ID", "Product ID must be a valid integer", http.StatusBadRequest))
+		return
+	}
+
+	// Parse query parameters
+	var req ProductHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.HandleAppError(c, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid query parameters", err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	// Get history through service layer
+	events, err := productService.GetProductHistory(ctx, id, req.Limit)
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			middleware.HandleAppError(c, appErr)
+		} else {
+			middleware.HandleAppError(c, errors.NewWithError(errors.ErrCodeInternalServer, "Failed to get product history", http.StatusInternalServerError, err))
+		}
+		return
+	}
+
+	// Return history with 200 OK
+	c.JSON(http.StatusOK, ProductHistoryResponse{
+		ProductID: id,
+		Events:    events,
 	})
 }
@@ -0,0 +1,100 @@
+package productregistration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"tushartemplategin/mocks"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/middleware"
+)
+
+func newTestRouter(t *testing.T, repo Repository) *gin.Engine {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	service := newTestService(t, repo, config.ProductsConfig{})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.ErrorHandlerMiddleware(mockLogger))
+	router.Use(func(c *gin.Context) {
+		c.Set("productService", service)
+		c.Next()
+	})
+	RegisterRoutes(router.Group("/api/v1"), middleware.APIKeyAuth(map[string]string{"ops": "key"}, mockLogger))
+	return router
+}
+
+func TestRegisterRoutes_WritesAndAuditRequireAPIKey(t *testing.T) {
+	router := newTestRouter(t, &stubRepository{})
+
+	tests := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, "/api/v1/products"},
+		{http.MethodPut, "/api/v1/products/7"},
+		{http.MethodDelete, "/api/v1/products/7"},
+		{http.MethodPatch, "/api/v1/products/7/stock"},
+		{http.MethodPost, "/api/v1/products/7/stock/adjust"},
+		{http.MethodGet, "/api/v1/products/7/stock/movements"},
+		{http.MethodPost, "/api/v1/products/7/reservations"},
+		{http.MethodPost, "/api/v1/products/7/reservations/1/commit"},
+		{http.MethodPost, "/api/v1/products/7/reservations/1/release"},
+		{http.MethodGet, "/api/v1/products/7/history"},
+		{http.MethodGet, "/api/v1/products/deleted"},
+		{http.MethodPost, "/api/v1/products/purge"},
+		{http.MethodPost, "/api/v1/products/7/restore"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		})
+	}
+
+	t.Run("reads stay public", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/products/7", nil))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("restore with API key", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products/7/restore", nil)
+		req.Header.Set("X-API-Key", "key")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
+
+func TestUpdateProductHandler_RecordsPrincipalAsActor(t *testing.T) {
+	repo := &stubRepository{products: map[int64]*ProductRegistration{7: {ID: 7, Name: "Chair", Version: 2}}}
+	router := newTestRouter(t, repo)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/products/7", strings.NewReader(`{"name":"Renamed"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer key")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "ops", repo.actor)
+}
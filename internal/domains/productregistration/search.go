@@ -0,0 +1,191 @@
+package productregistration
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// textSearchConfig is the PostgreSQL text search configuration search_vector is built with
+// (migration 009); queries must use the same one to match its stems
+const textSearchConfig = "english"
+
+// Delimiters ts_headline puts around matches instead of markup
+// ts_headline copies the product text as is, so the headline is HTML-escaped in
+// Go and only then are the delimiters replaced with <mark> tags
+const (
+	headlineStart = "\x02"
+	headlineStop  = "\x03"
+)
+
+// ts_headline options for the highlighted name and the description snippet
+const (
+	nameHeadlineOptions    = "StartSel=" + headlineStart + ", StopSel=" + headlineStop + ", HighlightAll=true"
+	snippetHeadlineOptions = "StartSel=" + headlineStart + ", StopSel=" + headlineStop + ", MaxWords=35, MinWords=15, MaxFragments=2"
+)
+
+// headlineMarks turns the ts_headline delimiters into <mark> tags
+var headlineMarks = strings.NewReplacer(headlineStart, "<mark>", headlineStop, "</mark>")
+
+// Limits of the portable search used without PostgreSQL full-text support
+const (
+	maxFallbackTerms     = 8
+	fallbackSnippetRunes = 160
+	fallbackSnippetLead  = 40 // Runes kept before the first match
+)
+
+// searchRanking is the SQL a full-text search adds to a list query
+type searchRanking struct {
+	rank    string         // Relevance expression, also used for sorting and cursors
+	columns []string       // Extra select expressions: the rank, then the highlighted name and snippet if computed in SQL
+	pattern *regexp.Regexp // Matches the query terms when highlighting happens in Go
+}
+
+// applyFullTextSearch adds the condition of a full-text search to b and returns its ranking,
+// or nil if the filter does not search in full-text mode
+// With PostgreSQL support the search uses websearch_to_tsquery against the indexed
+// search_vector; otherwise it falls back to portable LIKE matching
+func (r *ProductRepository) applyFullTextSearch(filter *ProductFilter, b *whereBuilder) *searchRanking {
+	if !filter.fullTextSearch() {
+		return nil
+	}
+	if !r.fullTextSearch {
+		return applyPortableSearch(filter.Search, b)
+	}
+
+	tsquery := fmt.Sprintf("websearch_to_tsquery('%s', %s)", textSearchConfig, b.arg(filter.Search))
+	b.add("search_vector @@ " + tsquery)
+	rank := fmt.Sprintf("ts_rank(search_vector, %s)", tsquery)
+	return &searchRanking{
+		rank: rank,
+		columns: []string{
+			rank,
+			fmt.Sprintf("ts_headline('%s', name, %s, '%s')", textSearchConfig, tsquery, nameHeadlineOptions),
+			fmt.Sprintf("ts_headline('%s', COALESCE(description, ''), %s, '%s')", textSearchConfig, tsquery, snippetHeadlineOptions),
+		},
+	}
+}
+
+// applyPortableSearch approximates a web search with LIKE conditions any SQL backend runs
+// Every term must appear in the name, SKU or description and excluded terms must not;
+// the rank counts name and SKU matches twice as much as description matches
+func applyPortableSearch(search string, b *whereBuilder) *searchRanking {
+	include, exclude := parseSearchTerms(search)
+
+	matches := func(term string) (name, sku, description string) {
+		pattern := b.arg("%" + escapeLike(term) + "%")
+		return fmt.Sprintf("LOWER(name) LIKE %s ESCAPE '%s'", pattern, likeEscape),
+			fmt.Sprintf("LOWER(sku) LIKE %s ESCAPE '%s'", pattern, likeEscape),
+			fmt.Sprintf("LOWER(COALESCE(description, '')) LIKE %s ESCAPE '%s'", pattern, likeEscape)
+	}
+
+	ranks := []string{}
+	for _, term := range include {
+		name, sku, description := matches(term)
+		b.add(fmt.Sprintf("(%s OR %s OR %s)", name, sku, description))
+		ranks = append(ranks, fmt.Sprintf("CASE WHEN %s THEN 2 ELSE 0 END + CASE WHEN %s THEN 2 ELSE 0 END + CASE WHEN %s THEN 1 ELSE 0 END", name, sku, description))
+	}
+	for _, term := range exclude {
+		name, sku, description := matches(term)
+		b.add(fmt.Sprintf("NOT (%s OR %s OR %s)", name, sku, description))
+	}
+
+	rank := "0"
+	if len(ranks) > 0 {
+		rank = "(" + strings.Join(ranks, " + ") + ")"
+	}
+	return &searchRanking{rank: rank, columns: []string{rank}, pattern: termPattern(include)}
+}
+
+// parseSearchTerms splits a web search into lowercase terms to include and to exclude
+// "quoted phrases" are one term, a leading - excludes a term and OR is ignored
+func parseSearchTerms(search string) (include, exclude []string) {
+	var terms []string
+	for i, part := range strings.Split(search, `"`) {
+		if i%2 == 1 {
+			if phrase := strings.Join(strings.Fields(part), " "); phrase != "" {
+				terms = append(terms, phrase)
+			}
+			continue
+		}
+		terms = append(terms, strings.Fields(part)...)
+	}
+
+	for _, term := range terms {
+		if len(include)+len(exclude) == maxFallbackTerms {
+			break
+		}
+		term = strings.ToLower(term)
+		switch {
+		case term == "or":
+		case strings.HasPrefix(term, "-") && len(term) > 1:
+			exclude = append(exclude, term[1:])
+		case term != "-":
+			include = append(include, term)
+		}
+	}
+	return include, exclude
+}
+
+// termPattern returns a case-insensitive pattern matching any of terms, or nil without terms
+// Longer terms come first so they win over their own prefixes
+func termPattern(terms []string) *regexp.Regexp {
+	if len(terms) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	return regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+}
+
+// markHeadline returns a ts_headline result as HTML with its matches in <mark> tags
+func markHeadline(headline string) string {
+	return headlineMarks.Replace(html.EscapeString(headline))
+}
+
+// highlight returns text as HTML with the matches of pattern in <mark> tags, like markHeadline
+func highlight(text string, pattern *regexp.Regexp) string {
+	if pattern == nil {
+		return html.EscapeString(text)
+	}
+
+	var marked strings.Builder
+	last := 0
+	for _, match := range pattern.FindAllStringIndex(text, -1) {
+		marked.WriteString(html.EscapeString(text[last:match[0]]))
+		marked.WriteString("<mark>" + html.EscapeString(text[match[0]:match[1]]) + "</mark>")
+		last = match[1]
+	}
+	marked.WriteString(html.EscapeString(text[last:]))
+	return marked.String()
+}
+
+// snippet returns an excerpt of text around the first match of pattern, highlighted
+func snippet(text string, pattern *regexp.Regexp) string {
+	start := 0
+	if pattern != nil {
+		if match := pattern.FindStringIndex(text); match != nil {
+			start = match[0]
+		}
+	}
+
+	// Back up to keep some context before the match, on a rune boundary
+	for lead := 0; start > 0 && lead < fallbackSnippetLead; lead++ {
+		_, size := utf8.DecodeLastRuneInString(text[:start])
+		start -= size
+	}
+	excerpt := text[start:]
+	if utf8.RuneCountInString(excerpt) > fallbackSnippetRunes {
+		excerpt = string([]rune(excerpt)[:fallbackSnippetRunes]) + " ..."
+	}
+	if start > 0 {
+		excerpt = "... " + excerpt
+	}
+	return highlight(excerpt, pattern)
+}
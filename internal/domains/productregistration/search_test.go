@@ -0,0 +1,132 @@
+package productregistration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchTerms(t *testing.T) {
+	tests := []struct {
+		search  string
+		include []string
+		exclude []string
+	}{
+		{"", nil, nil},
+		{"Oak  Chair", []string{"oak", "chair"}, nil},
+		{`"dining   table" -glass`, []string{"dining table"}, []string{"glass"}},
+		{"oak OR pine", []string{"oak", "pine"}, nil},
+		{"oak - -", []string{"oak"}, nil},
+		{`"unterminated phrase`, []string{"unterminated phrase"}, nil},
+		{"a b c d e f g h i j", []string{"a", "b", "c", "d", "e", "f", "g", "h"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			include, exclude := parseSearchTerms(tt.search)
+
+			assert.Equal(t, tt.include, include)
+			assert.Equal(t, tt.exclude, exclude)
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		terms    []string
+		expected string
+	}{
+		{"no terms", "Oak <chair>", nil, "Oak &lt;chair&gt;"},
+		{"case-insensitive", "Oak table, oak chair", []string{"oak"}, "<mark>Oak</mark> table, <mark>oak</mark> chair"},
+		{"longest term wins", "Oakwood", []string{"oak", "oakwood"}, "<mark>Oakwood</mark>"},
+		{"markup in text", `<script>alert("oak")</script>`, []string{"oak"}, `&lt;script&gt;alert(&#34;<mark>oak</mark>&#34;)&lt;/script&gt;`},
+		{"markup in match", "Tom & Jerry", []string{"&"}, "Tom <mark>&amp;</mark> Jerry"},
+		{"term looks like a tag", "<mark>oak</mark>", []string{"<mark>"}, "<mark>&lt;mark&gt;</mark>oak&lt;/mark&gt;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, highlight(tt.text, termPattern(tt.terms)))
+		})
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	pattern := termPattern([]string{"needle"})
+
+	assert.Equal(t, "short <mark>needle</mark> &amp; thread", snippet("short needle & thread", pattern))
+
+	// Long texts keep some context before the first match and are cut on rune boundaries
+	long := strings.Repeat("é", 100) + " needle " + strings.Repeat("<b>", 100)
+	excerpt := snippet(long, pattern)
+
+	assert.True(t, strings.HasPrefix(excerpt, "... "+strings.Repeat("é", fallbackSnippetLead-1)+" <mark>needle</mark>"))
+	assert.True(t, strings.HasSuffix(excerpt, " ..."))
+	assert.NotContains(t, excerpt, "<b>")
+	assert.Contains(t, excerpt, "&lt;b&gt;")
+
+	// Without a match the snippet starts at the beginning
+	assert.Equal(t, "no match &lt;here&gt;", snippet("no match <here>", pattern))
+}
+
+func TestMarkHeadline(t *testing.T) {
+	tests := []struct {
+		headline string
+		expected string
+	}{
+		{"plain", "plain"},
+		{headlineStart + "Oak" + headlineStop + " chair", "<mark>Oak</mark> chair"},
+		{`<img src=x onerror=alert(1)> ` + headlineStart + "oak" + headlineStop, `&lt;img src=x onerror=alert(1)&gt; <mark>oak</mark>`},
+		{"a & " + headlineStart + "<b>" + headlineStop, "a &amp; <mark>&lt;b&gt;</mark>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.headline, func(t *testing.T) {
+			assert.Equal(t, tt.expected, markHeadline(tt.headline))
+		})
+	}
+}
+
+func TestApplyFullTextSearch(t *testing.T) {
+	filter := &ProductFilter{Search: "oak -glass", SearchMode: SearchModeFullText}
+
+	t.Run("postgres", func(t *testing.T) {
+		where := &whereBuilder{}
+		ranking := (&ProductRepository{fullTextSearch: true}).applyFullTextSearch(filter, where)
+
+		require.NotNil(t, ranking)
+		assert.Equal(t, "WHERE search_vector @@ websearch_to_tsquery('english', $1)", where.clause())
+		assert.Equal(t, []interface{}{filter.Search}, where.args)
+		assert.Equal(t, "ts_rank(search_vector, websearch_to_tsquery('english', $1))", ranking.rank)
+		require.Len(t, ranking.columns, 3)
+		for _, headline := range ranking.columns[1:] {
+			assert.Contains(t, headline, "StartSel="+headlineStart)
+			assert.NotContains(t, headline, "<mark>")
+		}
+		assert.Nil(t, ranking.pattern)
+	})
+
+	t.Run("portable", func(t *testing.T) {
+		where := &whereBuilder{}
+		ranking := (&ProductRepository{}).applyFullTextSearch(filter, where)
+
+		require.NotNil(t, ranking)
+		assert.Equal(t, []interface{}{"%oak%", "%glass%"}, where.args)
+		require.Len(t, where.conditions, 2)
+		assert.True(t, strings.HasPrefix(where.conditions[1], "NOT ("))
+		assert.Len(t, ranking.columns, 1)
+		assert.Equal(t, "<mark>Oak</mark> bench", highlight("Oak bench", ranking.pattern))
+	})
+
+	t.Run("substring mode", func(t *testing.T) {
+		where := &whereBuilder{}
+		ranking := (&ProductRepository{fullTextSearch: true}).applyFullTextSearch(&ProductFilter{Search: "oak", SearchMode: SearchModeSubstring}, where)
+
+		assert.Nil(t, ranking)
+		assert.Empty(t, where.conditions)
+	})
+}
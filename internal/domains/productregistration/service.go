@@ -4,47 +4,72 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
+	"tushartemplategin/pkg/audit"
+	"tushartemplategin/pkg/config"
 	"tushartemplategin/pkg/errors"
 	"tushartemplategin/pkg/interfaces"
+	"tushartemplategin/pkg/logger"
 )
 
+// defaultListLimit is the page size when the request gives none
+const defaultListLimit = 10
+
+// defaultReservationTTL is how long a reservation holds stock when neither the request nor the configuration say
+const defaultReservationTTL = 15 * time.Minute
+
+// expireBatchSize bounds the reservations expired per repository call
+const expireBatchSize = 100
+
 // ProductService implements the Service interface for product business logic
 type ProductService struct {
 	repo   Repository
+	config config.ProductsConfig
 	logger interfaces.Logger
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
 }
 
 // NewProductService creates a new product service
-func NewProductService(repo Repository, log interfaces.Logger) Service {
+// cfg sets how long soft-deleted products are kept before they are purged
+func NewProductService(repo Repository, cfg config.ProductsConfig, log interfaces.Logger) Service {
 	return &ProductService{
 		repo:   repo,
+		config: cfg,
 		logger: log,
+		stopCh: make(chan struct{}),
 	}
 }
 
+// requestLogger returns the request-scoped logger bound with the given fields
+// Falls back to the service logger when no request logger is in the context
+func (s *ProductService) requestLogger(ctx context.Context, fields interfaces.Fields) interfaces.Logger {
+	return logger.FromContext(ctx, s.logger).With(fields)
+}
+
 // CreateProduct creates a new product with business logic validation
 func (s *ProductService) CreateProduct(ctx context.Context, req *CreateProductRequest) (*ProductRegistration, error) {
-	s.logger.Info(ctx, "Creating new product", interfaces.Fields{
+	log := s.requestLogger(ctx, interfaces.Fields{"sku": req.SKU})
+
+	log.Info(ctx, "Creating new product", interfaces.Fields{
 		"name":     req.Name,
 		"category": req.Category,
-		"sku":      req.SKU,
 	})
 
 	// Check if SKU already exists
 	exists, err := s.repo.SKUExists(ctx, req.SKU, nil)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to check SKU existence", interfaces.Fields{
+		log.Error(ctx, "Failed to check SKU existence", interfaces.Fields{
 			"error": err.Error(),
-			"sku":   req.SKU,
 		})
 		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "check SKU existence")
 	}
 
 	if exists {
-		s.logger.Warn(ctx, "Product creation failed: SKU already exists", interfaces.Fields{
-			"sku": req.SKU,
-		})
+		log.Warn(ctx, "Product creation failed: SKU already exists", interfaces.Fields{})
 		return nil, errors.NewWithDetails(errors.ErrCodeProductSKUExists, "Product SKU already exists", fmt.Sprintf("Product with SKU '%s' already exists", req.SKU), http.StatusConflict).WithField("sku", req.SKU)
 	}
 
@@ -62,16 +87,14 @@ func (s *ProductService) CreateProduct(ctx context.Context, req *CreateProductRe
 	// Save to repository
 	createdProduct, err := s.repo.Create(ctx, product)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to create product in repository", interfaces.Fields{
+		log.Error(ctx, "Failed to create product in repository", interfaces.Fields{
 			"error": err.Error(),
-			"sku":   req.SKU,
 		})
 		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "create product")
 	}
 
-	s.logger.Info(ctx, "Product created successfully", interfaces.Fields{
-		"id":  createdProduct.ID,
-		"sku": createdProduct.SKU,
+	log.Info(ctx, "Product created successfully", interfaces.Fields{
+		"product_id": createdProduct.ID,
 	})
 
 	return createdProduct, nil
@@ -79,28 +102,24 @@ func (s *ProductService) CreateProduct(ctx context.Context, req *CreateProductRe
 
 // GetProduct retrieves a product by ID
 func (s *ProductService) GetProduct(ctx context.Context, id int64) (*ProductRegistration, error) {
-	s.logger.Info(ctx, "Getting product by ID", interfaces.Fields{
-		"id": id,
-	})
+	log := s.requestLogger(ctx, interfaces.Fields{"product_id": id})
+
+	log.Info(ctx, "Getting product by ID", interfaces.Fields{})
 
 	product, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to get product", interfaces.Fields{
+		log.Error(ctx, "Failed to get product", interfaces.Fields{
 			"error": err.Error(),
-			"id":    id,
 		})
 		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "get product by ID")
 	}
 
 	if product == nil {
-		s.logger.Warn(ctx, "Product not found", interfaces.Fields{
-			"id": id,
-		})
-		return nil, errors.NewWithDetails(errors.ErrCodeProductNotFound, "Product not found", fmt.Sprintf("Product with ID %v not found", id), http.StatusNotFound).WithField("product_id", id)
+		log.Warn(ctx, "Product not found", interfaces.Fields{})
+		return nil, productNotFound(id)
 	}
 
-	s.logger.Info(ctx, "Product retrieved successfully", interfaces.Fields{
-		"id":  product.ID,
+	log.Info(ctx, "Product retrieved successfully", interfaces.Fields{
 		"sku": product.SKU,
 	})
 
@@ -108,37 +127,55 @@ func (s *ProductService) GetProduct(ctx context.Context, id int64) (*ProductRegi
 }
 
 // UpdateProduct updates an existing product with business logic validation
-func (s *ProductService) UpdateProduct(ctx context.Context, id int64, req *UpdateProductRequest) (*ProductRegistration, error) {
-	s.logger.Info(ctx, "Updating product", interfaces.Fields{
-		"id": id,
+// A product at a version ifMatch does not name fails with 412. The merge itself is
+// written only if the product is still at the version it was read at; otherwise it
+// fails with 412 when the client named versions and 409 when it did not
+func (s *ProductService) UpdateProduct(ctx context.Context, id int64, req *UpdateProductRequest, ifMatch Precondition) (*ProductRegistration, error) {
+	log := s.requestLogger(ctx, interfaces.Fields{"product_id": id})
+
+	log.Info(ctx, "Updating product", interfaces.Fields{
+		"if_match": ifMatch.String(),
 	})
 
+	if err := s.requirePrecondition(id, ifMatch); err != nil {
+		log.Warn(ctx, "Product update rejected: If-Match required", interfaces.Fields{})
+		return nil, err
+	}
+
 	// Check if product exists
 	exists, err := s.repo.Exists(ctx, id)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to check product existence", interfaces.Fields{
+		log.Error(ctx, "Failed to check product existence", interfaces.Fields{
 			"error": err.Error(),
-			"id":    id,
 		})
 		return nil, fmt.Errorf("failed to validate product: %w", err)
 	}
 
 	if !exists {
-		s.logger.Warn(ctx, "Product update failed: product not found", interfaces.Fields{
-			"id": id,
-		})
-		return nil, fmt.Errorf("product with id %d not found", id)
+		log.Warn(ctx, "Product update failed: product not found", interfaces.Fields{})
+		return nil, productNotFound(id)
 	}
 
 	// Get existing product
 	existingProduct, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to get existing product", interfaces.Fields{
+		log.Error(ctx, "Failed to get existing product", interfaces.Fields{
 			"error": err.Error(),
-			"id":    id,
 		})
 		return nil, fmt.Errorf("failed to get existing product: %w", err)
 	}
+	if existingProduct == nil {
+		log.Warn(ctx, "Product update failed: product not found", interfaces.Fields{})
+		return nil, productNotFound(id)
+	}
+
+	if err := ifMatch.check(id, existingProduct.Version); err != nil {
+		log.Warn(ctx, "Product update failed: version mismatch", interfaces.Fields{
+			"current_version": existingProduct.Version,
+		})
+		return nil, err
+	}
+	readVersion := existingProduct.Version
 
 	// Update fields if provided
 	if req.Name != nil {
@@ -157,7 +194,7 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id int64, req *Updat
 		// Check if new SKU already exists (excluding current product)
 		exists, err := s.repo.SKUExists(ctx, *req.SKU, &id)
 		if err != nil {
-			s.logger.Error(ctx, "Failed to check SKU existence", interfaces.Fields{
+			log.Error(ctx, "Failed to check SKU existence", interfaces.Fields{
 				"error": err.Error(),
 				"sku":   *req.SKU,
 			})
@@ -165,7 +202,7 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id int64, req *Updat
 		}
 
 		if exists {
-			s.logger.Warn(ctx, "Product update failed: SKU already exists", interfaces.Fields{
+			log.Warn(ctx, "Product update failed: SKU already exists", interfaces.Fields{
 				"sku": *req.SKU,
 			})
 			return nil, fmt.Errorf("product with SKU %s already exists", *req.SKU)
@@ -179,172 +216,719 @@ func (s *ProductService) UpdateProduct(ctx context.Context, id int64, req *Updat
 		existingProduct.IsActive = *req.IsActive
 	}
 
-	// Save updated product
-	updatedProduct, err := s.repo.Update(ctx, id, existingProduct)
+	// Save updated product, unless another write got in since it was read
+	updatedProduct, err := s.repo.Update(ctx, id, existingProduct, readVersion)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to update product in repository", interfaces.Fields{
+		if appErr := errors.GetAppError(err); appErr != nil {
+			if appErr.Code == errors.ErrCodeProductVersionConflict && len(ifMatch.Versions) == 0 {
+				// The client did not pin a version, so the lost race is a plain conflict it can retry
+				appErr.HTTPStatus = http.StatusConflict
+			}
+			log.Warn(ctx, "Product update rejected", interfaces.Fields{
+				"code":         appErr.Code,
+				"read_version": readVersion,
+			})
+			return nil, appErr
+		}
+		log.Error(ctx, "Failed to update product in repository", interfaces.Fields{
 			"error": err.Error(),
-			"id":    id,
 		})
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
 
-	s.logger.Info(ctx, "Product updated successfully", interfaces.Fields{
-		"id":  updatedProduct.ID,
+	log.Info(ctx, "Product updated successfully", interfaces.Fields{
 		"sku": updatedProduct.SKU,
 	})
 
 	return updatedProduct, nil
 }
 
-// DeleteProduct removes a product
-func (s *ProductService) DeleteProduct(ctx context.Context, id int64) error {
-	s.logger.Info(ctx, "Deleting product", interfaces.Fields{
-		"id": id,
+// DeleteProduct soft-deletes a product; it can be restored until it is purged
+// The product must be at a version ifMatch allows
+func (s *ProductService) DeleteProduct(ctx context.Context, id int64, ifMatch Precondition) error {
+	log := s.requestLogger(ctx, interfaces.Fields{"product_id": id})
+
+	log.Info(ctx, "Deleting product", interfaces.Fields{
+		"if_match": ifMatch.String(),
 	})
 
+	if err := s.requirePrecondition(id, ifMatch); err != nil {
+		log.Warn(ctx, "Product deletion rejected: If-Match required", interfaces.Fields{})
+		return err
+	}
+
 	// Check if product exists
 	exists, err := s.repo.Exists(ctx, id)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to check product existence", interfaces.Fields{
+		log.Error(ctx, "Failed to check product existence", interfaces.Fields{
 			"error": err.Error(),
-			"id":    id,
 		})
 		return fmt.Errorf("failed to validate product: %w", err)
 	}
 
 	if !exists {
-		s.logger.Warn(ctx, "Product deletion failed: product not found", interfaces.Fields{
-			"id": id,
+		log.Warn(ctx, "Product deletion failed: product not found", interfaces.Fields{})
+		return productNotFound(id)
+	}
+
+	expectedVersion, err := s.expectedVersion(ctx, id, ifMatch)
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			log.Warn(ctx, "Product deletion rejected", interfaces.Fields{"code": appErr.Code})
+			return appErr
+		}
+		log.Error(ctx, "Failed to get product for deletion", interfaces.Fields{
+			"error": err.Error(),
 		})
-		return fmt.Errorf("product with id %d not found", id)
+		return err
 	}
 
 	// Delete from repository
-	err = s.repo.Delete(ctx, id)
+	err = s.repo.Delete(ctx, id, expectedVersion)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to delete product from repository", interfaces.Fields{
+		if appErr := errors.GetAppError(err); appErr != nil {
+			log.Warn(ctx, "Product deletion rejected", interfaces.Fields{"code": appErr.Code})
+			return appErr
+		}
+		log.Error(ctx, "Failed to delete product from repository", interfaces.Fields{
 			"error": err.Error(),
-			"id":    id,
 		})
 		return fmt.Errorf("failed to delete product: %w", err)
 	}
 
-	s.logger.Info(ctx, "Product deleted successfully", interfaces.Fields{
-		"id": id,
-	})
+	log.Info(ctx, "Product deleted successfully", interfaces.Fields{})
 
 	return nil
 }
 
-// ListProducts retrieves a list of products with pagination and filtering
+// RestoreProduct restores a soft-deleted product
+func (s *ProductService) RestoreProduct(ctx context.Context, id int64) (*ProductRegistration, error) {
+	log := s.requestLogger(ctx, interfaces.Fields{"product_id": id})
+
+	log.Info(ctx, "Restoring product", interfaces.Fields{})
+
+	product, err := s.repo.Restore(ctx, id)
+	if err != nil {
+		log.Error(ctx, "Failed to restore product", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "restore product")
+	}
+
+	if product == nil {
+		log.Warn(ctx, "Product restore failed: no deleted product", interfaces.Fields{})
+		return nil, errors.NewWithDetails(errors.ErrCodeProductNotFound, "Product not found", fmt.Sprintf("No deleted product with ID %v", id), http.StatusNotFound).WithField("product_id", id)
+	}
+
+	log.Info(ctx, "Product restored successfully", interfaces.Fields{
+		"sku": product.SKU,
+	})
+
+	return product, nil
+}
+
+// PurgeDeleted permanently removes products deleted longer than the retention period ago
+// A retention of zero days keeps deleted products forever
+func (s *ProductService) PurgeDeleted(ctx context.Context) (*ProductPurgeResponse, error) {
+	if s.config.DeletedRetentionDays <= 0 {
+		return &ProductPurgeResponse{}, nil
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -s.config.DeletedRetentionDays)
+	purged, err := s.repo.PurgeDeleted(ctx, cutoff)
+	if err != nil {
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "purge deleted products")
+	}
+
+	s.logger.Info(ctx, "Purged deleted products", interfaces.Fields{
+		"purged":                 purged,
+		"cutoff":                 cutoff,
+		"deleted_retention_days": s.config.DeletedRetentionDays,
+	})
+
+	return &ProductPurgeResponse{Purged: purged, Cutoff: cutoff}, nil
+}
+
+// StartRetention runs the background jobs of the domain until StopRetention is called:
+// PurgeDeleted every purge interval and ExpireReservations every reservation sweep interval
+func (s *ProductService) StartRetention(ctx context.Context) {
+	s.startReservationExpiry(ctx)
+
+	if s.config.DeletedRetentionDays <= 0 || s.config.PurgeInterval <= 0 {
+		s.logger.Info(ctx, "Deleted product purge disabled", interfaces.Fields{
+			"deleted_retention_days": s.config.DeletedRetentionDays,
+			"purge_interval":         s.config.PurgeInterval.String(),
+		})
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.config.PurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.PurgeDeleted(ctx); err != nil {
+					s.logger.Error(ctx, "Scheduled deleted product purge failed", interfaces.Fields{
+						"error": err.Error(),
+					})
+				}
+			case <-s.stopCh:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// startReservationExpiry runs ExpireReservations periodically until StopRetention is called
+func (s *ProductService) startReservationExpiry(ctx context.Context) {
+	if s.config.ReservationSweepInterval <= 0 {
+		s.logger.Info(ctx, "Stock reservation expiry disabled", interfaces.Fields{
+			"reservation_sweep_interval": s.config.ReservationSweepInterval.String(),
+		})
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.config.ReservationSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := s.ExpireReservations(ctx); err != nil {
+					s.logger.Error(ctx, "Scheduled stock reservation expiry failed", interfaces.Fields{
+						"error": err.Error(),
+					})
+				}
+			case <-s.stopCh:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// StopRetention stops the background purge of deleted products and expiry of reservations
+func (s *ProductService) StopRetention() {
+	s.stopOnce.Do(func() { close(s.stopCh) })
+}
+
+// ListProducts retrieves a page of products with filtering and sorting
+// The response carries cursors for the neighbouring pages; page numbers are
+// still accepted for the first request but cursors should be followed after it
 func (s *ProductService) ListProducts(ctx context.Context, req *ProductListRequest) (*ProductListResponse, error) {
-	s.logger.Info(ctx, "Listing products", interfaces.Fields{
+	return s.listProducts(ctx, req, false)
+}
+
+// ListDeletedProducts lists soft-deleted products with the filters, sorting and
+// cursors of ListProducts
+func (s *ProductService) ListDeletedProducts(ctx context.Context, req *ProductListRequest) (*ProductListResponse, error) {
+	return s.listProducts(ctx, req, true)
+}
+
+// listProducts lists live or soft-deleted products
+func (s *ProductService) listProducts(ctx context.Context, req *ProductListRequest, deleted bool) (*ProductListResponse, error) {
+	log := s.requestLogger(ctx, interfaces.Fields{"deleted": deleted})
+
+	query, err := newProductListQuery(req)
+	if err != nil {
+		log.Warn(ctx, "Invalid product list query", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid query parameters", err.Error(), http.StatusBadRequest)
+	}
+	query.Filter.Deleted = deleted
+
+	log.Info(ctx, "Listing products", interfaces.Fields{
 		"page":     req.Page,
-		"limit":    req.Limit,
-		"category": req.Category,
-		"search":   req.Search,
+		"limit":    query.Limit,
+		"category": query.Filter.Categories,
+		"search":   query.Filter.Search,
+		"sort":     query.Sort,
+		"order":    query.Order,
+		"cursor":   query.Cursor != nil,
 	})
 
-	products, total, err := s.repo.List(ctx, req)
+	page, err := s.repo.List(ctx, query)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to list products", interfaces.Fields{
+		log.Error(ctx, "Failed to list products", interfaces.Fields{
 			"error": err.Error(),
 		})
 		return nil, fmt.Errorf("failed to list products: %w", err)
 	}
 
 	// Convert to response format
-	productList := make([]ProductRegistration, len(products))
-	for i, product := range products {
+	productList := make([]ProductRegistration, len(page.Products))
+	for i, product := range page.Products {
 		productList[i] = *product
 	}
 
 	response := &ProductListResponse{
 		Products: productList,
-		Total:    total,
-		Page:     req.Page,
-		Limit:    req.Limit,
+		Limit:    query.Limit,
+		Sort:     query.Sort,
+		Order:    query.Order,
+	}
+	if query.CountTotal {
+		total := page.Total
+		response.Total = &total
+	}
+	if query.Cursor == nil {
+		response.Page = query.Offset/query.Limit + 1
 	}
 
-	s.logger.Info(ctx, "Products listed successfully", interfaces.Fields{
-		"count": len(products),
-		"total": total,
+	// A backward page always has rows after it; a forward page has rows before
+	// it whenever it did not start at the top of the list
+	if len(page.Products) > 0 {
+		backward := query.Cursor != nil && query.Cursor.Backward
+		if backward || page.HasMore {
+			response.NextCursor = newProductCursor(query.Sort, query.Order, page.Products[len(page.Products)-1], false).Encode()
+		}
+		if (backward && page.HasMore) || (!backward && (query.Cursor != nil || query.Offset > 0)) {
+			response.PrevCursor = newProductCursor(query.Sort, query.Order, page.Products[0], true).Encode()
+		}
+	}
+	response.HasMore = response.NextCursor != ""
+
+	log.Info(ctx, "Products listed successfully", interfaces.Fields{
+		"count":    len(page.Products),
+		"has_more": response.HasMore,
 	})
 
 	return response, nil
 }
 
+// newProductListQuery validates a list request and applies its defaults
+// Filters are not part of the cursor and must be repeated with it. A cursor fixes
+// the sort and order; asking for a different one is an error rather than a
+// silently reordered page
+func newProductListQuery(req *ProductListRequest) (*ProductListQuery, error) {
+	filter, err := newProductFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &ProductListQuery{
+		Filter:     filter,
+		Sort:       req.Sort,
+		Order:      req.Order,
+		Limit:      req.Limit,
+		CountTotal: !req.SkipTotal,
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultListLimit
+	}
+
+	switch {
+	case req.Cursor != "":
+		cursor, err := DecodeProductCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if (query.Sort != "" && query.Sort != cursor.Sort) || (query.Order != "" && query.Order != cursor.Order) {
+			return nil, fmt.Errorf("cursor was issued for sort %s %s", cursor.Sort, cursor.Order)
+		}
+		query.Sort, query.Order, query.Cursor = cursor.Sort, cursor.Order, cursor
+	case query.Sort == "" && filter.fullTextSearch():
+		query.Sort = SortRelevance
+	case query.Sort == "":
+		query.Sort = SortCreatedAt
+	}
+
+	if query.Sort == SortRelevance && !filter.fullTextSearch() {
+		return nil, fmt.Errorf("sort relevance requires a search with search_mode fulltext")
+	}
+	if query.Order == "" {
+		query.Order = defaultOrder(query.Sort)
+	}
+	if query.Cursor == nil && req.Page > 1 {
+		query.Offset = (req.Page - 1) * query.Limit
+	}
+	return query, nil
+}
+
 // GetProductBySKU retrieves a product by its SKU
 func (s *ProductService) GetProductBySKU(ctx context.Context, sku string) (*ProductRegistration, error) {
-	s.logger.Info(ctx, "Getting product by SKU", interfaces.Fields{
-		"sku": sku,
-	})
+	log := s.requestLogger(ctx, interfaces.Fields{"sku": sku})
+
+	log.Info(ctx, "Getting product by SKU", interfaces.Fields{})
 
 	product, err := s.repo.GetBySKU(ctx, sku)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to get product by SKU", interfaces.Fields{
+		log.Error(ctx, "Failed to get product by SKU", interfaces.Fields{
 			"error": err.Error(),
-			"sku":   sku,
 		})
-		return nil, err
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "get product by SKU")
 	}
 
-	s.logger.Info(ctx, "Product retrieved by SKU successfully", interfaces.Fields{
-		"id":  product.ID,
-		"sku": product.SKU,
+	if product == nil {
+		log.Warn(ctx, "Product not found", interfaces.Fields{})
+		return nil, errors.NewWithDetails(errors.ErrCodeNotFound, "Product not found", "Product with SKU '"+sku+"' not found", http.StatusNotFound).WithField("sku", sku)
+	}
+
+	log.Info(ctx, "Product retrieved by SKU successfully", interfaces.Fields{
+		"product_id": product.ID,
 	})
 
 	return product, nil
 }
 
-// UpdateStock updates the stock quantity of a product
-func (s *ProductService) UpdateStock(ctx context.Context, id int64, stock int) error {
-	s.logger.Info(ctx, "Updating product stock", interfaces.Fields{
-		"id":    id,
-		"stock": stock,
+// UpdateStock updates the stock quantity of a product and returns its new version
+// The product must be at a version ifMatch allows
+func (s *ProductService) UpdateStock(ctx context.Context, id int64, stock int, ifMatch Precondition) (int, error) {
+	log := s.requestLogger(ctx, interfaces.Fields{"product_id": id})
+
+	log.Info(ctx, "Updating product stock", interfaces.Fields{
+		"stock":    stock,
+		"if_match": ifMatch.String(),
 	})
 
+	if err := s.requirePrecondition(id, ifMatch); err != nil {
+		log.Warn(ctx, "Stock update rejected: If-Match required", interfaces.Fields{})
+		return 0, err
+	}
+
 	// Check if product exists
 	exists, err := s.repo.Exists(ctx, id)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to check product existence", interfaces.Fields{
+		log.Error(ctx, "Failed to check product existence", interfaces.Fields{
 			"error": err.Error(),
-			"id":    id,
 		})
-		return fmt.Errorf("failed to validate product: %w", err)
+		return 0, fmt.Errorf("failed to validate product: %w", err)
 	}
 
 	if !exists {
-		s.logger.Warn(ctx, "Stock update failed: product not found", interfaces.Fields{
-			"id": id,
-		})
-		return fmt.Errorf("product with id %d not found", id)
+		log.Warn(ctx, "Stock update failed: product not found", interfaces.Fields{})
+		return 0, productNotFound(id)
 	}
 
 	// Validate stock quantity
 	if stock < 0 {
-		s.logger.Warn(ctx, "Stock update failed: invalid stock quantity", interfaces.Fields{
-			"id":    id,
+		log.Warn(ctx, "Stock update failed: invalid stock quantity", interfaces.Fields{
 			"stock": stock,
 		})
-		return fmt.Errorf("stock quantity cannot be negative")
+		return 0, fmt.Errorf("stock quantity cannot be negative")
+	}
+
+	expectedVersion, err := s.expectedVersion(ctx, id, ifMatch)
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			log.Warn(ctx, "Stock update rejected", interfaces.Fields{"code": appErr.Code})
+			return 0, appErr
+		}
+		log.Error(ctx, "Failed to get product for stock update", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return 0, err
 	}
 
 	// Update stock
-	err = s.repo.UpdateStock(ctx, id, stock)
+	version, err := s.repo.UpdateStock(ctx, id, stock, expectedVersion)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to update product stock in repository", interfaces.Fields{
+		if appErr := errors.GetAppError(err); appErr != nil {
+			log.Warn(ctx, "Stock update rejected", interfaces.Fields{"code": appErr.Code})
+			return 0, appErr
+		}
+		log.Error(ctx, "Failed to update product stock in repository", interfaces.Fields{
 			"error": err.Error(),
-			"id":    id,
 		})
-		return fmt.Errorf("failed to update product stock: %w", err)
+		return 0, fmt.Errorf("failed to update product stock: %w", err)
 	}
 
-	s.logger.Info(ctx, "Product stock updated successfully", interfaces.Fields{
-		"id":    id,
-		"stock": stock,
+	log.Info(ctx, "Product stock updated successfully", interfaces.Fields{
+		"stock":   stock,
+		"version": version,
 	})
 
-	return nil
+	return version, nil
+}
+
+// requirePrecondition fails with 428 when writes must be conditional and the client sent no If-Match
+func (s *ProductService) requirePrecondition(id int64, ifMatch Precondition) error {
+	if !s.config.RequireIfMatch || ifMatch.IsSet() {
+		return nil
+	}
+	return errors.NewWithDetails(errors.ErrCodePreconditionRequired, "Precondition required",
+		fmt.Sprintf("Changes to product %d must send its ETag in If-Match", id), http.StatusPreconditionRequired).
+		WithField("product_id", id)
+}
+
+// expectedVersion resolves ifMatch into the version the repository re-checks under its row lock
+// A list of tags is matched against the current version first, so the write fails with 412
+// when none of them is current and applies only if the matching one still is when locked
+func (s *ProductService) expectedVersion(ctx context.Context, id int64, ifMatch Precondition) (int, error) {
+	if version, ok := ifMatch.version(); ok {
+		return version, nil
+	}
+
+	product, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return 0, productNotFound(id)
+	}
+	if err := ifMatch.check(id, product.Version); err != nil {
+		return 0, err
+	}
+	return product.Version, nil
+}
+
+// productNotFound builds the not found error for a product ID
+func productNotFound(id int64) error {
+	return errors.NewWithDetails(errors.ErrCodeProductNotFound, "Product not found", fmt.Sprintf("Product with ID %v not found", id), http.StatusNotFound).WithField("product_id", id)
+}
+
+// AdjustStock adds a relative quantity (negative to remove) to the stock of a product
+// Unlike UpdateStock it needs no version: concurrent adjustments all apply, and
+// one that would take the stock below zero fails with INSUFFICIENT_STOCK
+func (s *ProductService) AdjustStock(ctx context.Context, id int64, req *StockAdjustmentRequest) (*StockAdjustmentResponse, error) {
+	log := s.requestLogger(ctx, interfaces.Fields{"product_id": id})
+
+	log.Info(ctx, "Adjusting product stock", interfaces.Fields{
+		"delta":     req.Delta,
+		"reference": req.Reference,
+	})
+
+	if req.Delta == 0 {
+		return nil, errors.NewWithDetails(errors.ErrCodeInvalidStock, "Invalid stock adjustment", "Stock adjustment delta cannot be zero", http.StatusUnprocessableEntity)
+	}
+
+	movement, version, err := s.repo.AdjustStock(ctx, id, req.Delta, req.Reference)
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			log.Warn(ctx, "Stock adjustment rejected", interfaces.Fields{
+				"code":  appErr.Code,
+				"delta": req.Delta,
+			})
+			return nil, appErr
+		}
+		log.Error(ctx, "Failed to adjust product stock", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "adjust product stock")
+	}
+
+	if movement == nil {
+		log.Warn(ctx, "Stock adjustment failed: product not found", interfaces.Fields{})
+		return nil, productNotFound(id)
+	}
+
+	log.Info(ctx, "Product stock adjusted successfully", interfaces.Fields{
+		"stock":   movement.StockAfter,
+		"version": version,
+	})
+
+	return &StockAdjustmentResponse{
+		ProductID: id,
+		Stock:     movement.StockAfter,
+		Version:   version,
+		Movement:  movement,
+	}, nil
+}
+
+// ReserveStock holds stock of a product for a checkout until the reservation is
+// committed, released or expires after its TTL
+func (s *ProductService) ReserveStock(ctx context.Context, id int64, req *CreateReservationRequest) (*StockReservation, error) {
+	log := s.requestLogger(ctx, interfaces.Fields{"product_id": id})
+
+	log.Info(ctx, "Reserving product stock", interfaces.Fields{
+		"quantity":    req.Quantity,
+		"ttl_seconds": req.TTLSeconds,
+		"reference":   req.Reference,
+	})
+
+	ttl, err := s.reservationTTL(req.TTLSeconds)
+	if err != nil {
+		log.Warn(ctx, "Stock reservation failed: TTL too long", interfaces.Fields{
+			"max_reservation_ttl": s.config.MaxReservationTTL.String(),
+		})
+		return nil, err
+	}
+
+	reservation, err := s.repo.Reserve(ctx, id, req.Quantity, req.Reference, time.Now().Add(ttl))
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			log.Warn(ctx, "Stock reservation rejected", interfaces.Fields{
+				"code":     appErr.Code,
+				"quantity": req.Quantity,
+			})
+			return nil, appErr
+		}
+		log.Error(ctx, "Failed to reserve product stock", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "reserve product stock")
+	}
+
+	if reservation == nil {
+		log.Warn(ctx, "Stock reservation failed: product not found", interfaces.Fields{})
+		return nil, productNotFound(id)
+	}
+
+	log.Info(ctx, "Product stock reserved successfully", interfaces.Fields{
+		"reservation_id": reservation.ID,
+		"expires_at":     reservation.ExpiresAt,
+	})
+
+	return reservation, nil
+}
+
+// reservationTTL returns how long a reservation asking for ttlSeconds (0 for the default) holds stock
+// ttlSeconds is compared with products.max_reservation_ttl in whole seconds before it is
+// converted, so a huge value cannot overflow into a negative duration that passes the check
+func (s *ProductService) reservationTTL(ttlSeconds int) (time.Duration, error) {
+	maxTTL := s.config.MaxReservationTTL
+
+	ttl := s.config.ReservationTTL
+	if ttl <= 0 {
+		ttl = defaultReservationTTL
+	}
+	if ttlSeconds > 0 {
+		if maxTTL > 0 && int64(ttlSeconds) > int64(maxTTL/time.Second) {
+			return 0, reservationTTLTooLong(maxTTL)
+		}
+		ttl = time.Duration(ttlSeconds) * time.Second
+	}
+	if maxTTL > 0 && ttl > maxTTL {
+		return 0, reservationTTLTooLong(maxTTL)
+	}
+	return ttl, nil
+}
+
+// reservationTTLTooLong reports a reservation TTL over products.max_reservation_ttl
+func reservationTTLTooLong(maxTTL time.Duration) error {
+	return errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid reservation TTL",
+		fmt.Sprintf("Reservations can hold stock for at most %s", maxTTL), http.StatusBadRequest).
+		WithField("max_ttl_seconds", int(maxTTL.Seconds()))
+}
+
+// GetReservation retrieves a stock reservation of a product
+func (s *ProductService) GetReservation(ctx context.Context, id, reservationID int64) (*StockReservation, error) {
+	return s.runReservationOperation(ctx, id, reservationID, "get", s.repo.GetReservation)
+}
+
+// CommitReservation makes an active, unexpired reservation final
+func (s *ProductService) CommitReservation(ctx context.Context, id, reservationID int64) (*StockReservation, error) {
+	return s.runReservationOperation(ctx, id, reservationID, "commit", s.repo.CommitReservation)
+}
+
+// ReleaseReservation cancels an active reservation and returns its stock
+func (s *ProductService) ReleaseReservation(ctx context.Context, id, reservationID int64) (*StockReservation, error) {
+	return s.runReservationOperation(ctx, id, reservationID, "release", s.repo.ReleaseReservation)
+}
+
+// runReservationOperation runs one reservation operation of the repository and maps its result
+// A missing reservation is a 404; state errors from the repository are returned unchanged
+func (s *ProductService) runReservationOperation(ctx context.Context, id, reservationID int64, operation string,
+	run func(ctx context.Context, id, reservationID int64) (*StockReservation, error)) (*StockReservation, error) {
+	log := s.requestLogger(ctx, interfaces.Fields{
+		"product_id":     id,
+		"reservation_id": reservationID,
+		"operation":      operation,
+	})
+
+	log.Info(ctx, "Running stock reservation operation", interfaces.Fields{})
+
+	reservation, err := run(ctx, id, reservationID)
+	if err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			log.Warn(ctx, "Stock reservation operation rejected", interfaces.Fields{
+				"error": appErr.Error(),
+			})
+			return nil, appErr
+		}
+		log.Error(ctx, "Failed to run stock reservation operation", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", operation+" stock reservation")
+	}
+
+	if reservation == nil {
+		log.Warn(ctx, "Stock reservation not found", interfaces.Fields{})
+		return nil, errors.NewWithDetails(errors.ErrCodeReservationNotFound, "Reservation not found",
+			fmt.Sprintf("Product %d has no reservation %d", id, reservationID), http.StatusNotFound).
+			WithField("product_id", id).
+			WithField("reservation_id", reservationID)
+	}
+
+	log.Info(ctx, "Stock reservation operation succeeded", interfaces.Fields{
+		"status": reservation.Status,
+	})
+
+	return reservation, nil
+}
+
+// ExpireReservations returns the stock of every active reservation past its expiry
+func (s *ProductService) ExpireReservations(ctx context.Context) (int64, error) {
+	var total int64
+	for {
+		expired, err := s.repo.ExpireReservations(ctx, time.Now(), expireBatchSize)
+		total += expired
+		if err != nil {
+			return total, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "expire stock reservations")
+		}
+		if expired < expireBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		s.logger.Info(ctx, "Expired stock reservations", interfaces.Fields{
+			"expired": total,
+		})
+	}
+
+	return total, nil
+}
+
+// GetStockMovements retrieves the stock ledger of a product, newest first
+func (s *ProductService) GetStockMovements(ctx context.Context, id int64, limit int) ([]*StockMovement, error) {
+	log := s.requestLogger(ctx, interfaces.Fields{"product_id": id})
+
+	log.Info(ctx, "Getting product stock movements", interfaces.Fields{
+		"limit": limit,
+	})
+
+	movements, err := s.repo.StockMovements(ctx, id, limit)
+	if err != nil {
+		log.Error(ctx, "Failed to get product stock movements", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "get product stock movements")
+	}
+
+	log.Info(ctx, "Product stock movements retrieved successfully", interfaces.Fields{
+		"count": len(movements),
+	})
+
+	return movements, nil
+}
+
+// GetProductHistory retrieves the audit trail of a product
+func (s *ProductService) GetProductHistory(ctx context.Context, id int64, limit int) ([]*audit.Event, error) {
+	log := s.requestLogger(ctx, interfaces.Fields{"product_id": id})
+
+	log.Info(ctx, "Getting product history", interfaces.Fields{
+		"limit": limit,
+	})
+
+	events, err := s.repo.History(ctx, id, limit)
+	if err != nil {
+		log.Error(ctx, "Failed to get product history", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return nil, errors.NewWithError(errors.ErrCodeDatabaseQuery, "Database operation failed", http.StatusInternalServerError, err).WithField("operation", "get product history")
+	}
+
+	log.Info(ctx, "Product history retrieved successfully", interfaces.Fields{
+		"count": len(events),
+	})
+
+	return events, nil
 }
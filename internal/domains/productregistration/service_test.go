@@ -0,0 +1,170 @@
+package productregistration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/mocks"
+	"tushartemplategin/pkg/audit"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+)
+
+// stubRepository serves a fixed set of live products, remembers the actor of the
+// last update and the expected version of the last delete, and fails updates with
+// updateErr when set; methods it does not override panic through the nil embedded Repository
+type stubRepository struct {
+	Repository
+	products  map[int64]*ProductRegistration
+	updateErr error
+	actor     string
+	deleted   map[int64]int
+}
+
+func (r *stubRepository) GetByID(ctx context.Context, id int64) (*ProductRegistration, error) {
+	return r.products[id], nil
+}
+
+func (r *stubRepository) GetBySKU(ctx context.Context, sku string) (*ProductRegistration, error) {
+	for _, product := range r.products {
+		if product.SKU == sku {
+			return product, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *stubRepository) Exists(ctx context.Context, id int64) (bool, error) {
+	return r.products[id] != nil, nil
+}
+
+func (r *stubRepository) Update(ctx context.Context, id int64, product *ProductRegistration, expectedVersion int) (*ProductRegistration, error) {
+	if r.updateErr != nil {
+		return nil, r.updateErr
+	}
+	r.actor = audit.ActorFromContext(ctx)
+	product.Version++
+	return product, nil
+}
+
+func (r *stubRepository) Delete(ctx context.Context, id int64, expectedVersion int) error {
+	if r.deleted == nil {
+		r.deleted = make(map[int64]int)
+	}
+	r.deleted[id] = expectedVersion
+	return nil
+}
+
+func (r *stubRepository) Restore(ctx context.Context, id int64) (*ProductRegistration, error) {
+	return nil, nil
+}
+
+func (r *stubRepository) UpdateStock(ctx context.Context, id int64, stock int, expectedVersion int) (int, error) {
+	return 0, productNotFound(id)
+}
+
+func newTestService(t *testing.T, repo Repository, cfg config.ProductsConfig) Service {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Debug(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().With(gomock.Any()).Return(mockLogger).AnyTimes()
+
+	return NewProductService(repo, cfg, mockLogger)
+}
+
+func TestProductService_MissingProductsAreNotFound(t *testing.T) {
+	ctx := context.Background()
+	service := newTestService(t, &stubRepository{}, config.ProductsConfig{})
+
+	name := "Renamed"
+	tests := []struct {
+		name string
+		call func() error
+		code errors.ErrorCode
+	}{
+		{"get", func() error {
+			_, err := service.GetProduct(ctx, 7)
+			return err
+		}, errors.ErrCodeProductNotFound},
+		{"get by sku", func() error {
+			_, err := service.GetProductBySKU(ctx, "GONE-1")
+			return err
+		}, errors.ErrCodeNotFound},
+		{"update", func() error {
+			_, err := service.UpdateProduct(ctx, 7, &UpdateProductRequest{Name: &name}, Precondition{})
+			return err
+		}, errors.ErrCodeProductNotFound},
+		{"delete", func() error {
+			return service.DeleteProduct(ctx, 7, Precondition{})
+		}, errors.ErrCodeProductNotFound},
+		{"update stock", func() error {
+			_, err := service.UpdateStock(ctx, 7, 3, Precondition{})
+			return err
+		}, errors.ErrCodeProductNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr := errors.GetAppError(tt.call())
+
+			require.NotNil(t, appErr)
+			assert.Equal(t, tt.code, appErr.Code)
+			assert.Equal(t, http.StatusNotFound, appErr.HTTPStatus)
+		})
+	}
+}
+
+func TestProductService_UpdateStockPassesRepositoryNotFound(t *testing.T) {
+	// The product is deleted between the existence check and the locked update
+	repo := &stubRepository{products: map[int64]*ProductRegistration{7: {ID: 7, Version: 2}}}
+	service := newTestService(t, repo, config.ProductsConfig{})
+
+	_, err := service.UpdateStock(context.Background(), 7, 3, Precondition{})
+
+	appErr := errors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, errors.ErrCodeProductNotFound, appErr.Code)
+	assert.Equal(t, http.StatusNotFound, appErr.HTTPStatus)
+}
+
+func TestProductService_DeleteProductIfMatchList(t *testing.T) {
+	tests := []struct {
+		name     string
+		ifMatch  Precondition
+		expected int
+		status   int
+	}{
+		{name: "single tag", ifMatch: Precondition{Versions: []int{1}}, expected: 1},
+		{name: "list with current version", ifMatch: Precondition{Versions: []int{1, 2}}, expected: 2},
+		{name: "list without current version", ifMatch: Precondition{Versions: []int{1, 3}}, status: http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubRepository{products: map[int64]*ProductRegistration{7: {ID: 7, Version: 2}}}
+			service := newTestService(t, repo, config.ProductsConfig{})
+
+			err := service.DeleteProduct(context.Background(), 7, tt.ifMatch)
+			if tt.status == 0 {
+				// A list is resolved to the current version, which the repository re-checks under its lock
+				require.NoError(t, err)
+				assert.Equal(t, map[int64]int{7: tt.expected}, repo.deleted)
+				return
+			}
+
+			appErr := errors.GetAppError(err)
+			require.NotNil(t, appErr)
+			assert.Equal(t, tt.status, appErr.HTTPStatus)
+			assert.Empty(t, repo.deleted)
+		})
+	}
+}
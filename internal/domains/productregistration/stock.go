@@ -0,0 +1,517 @@
+package productregistration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/lib/pq"
+	"tushartemplategin/pkg/audit"
+	"tushartemplategin/pkg/errors"
+	"tushartemplategin/pkg/interfaces"
+)
+
+// pgNumericValueOutOfRange is the PostgreSQL error code of an INTEGER overflow
+const pgNumericValueOutOfRange = "22003"
+
+// defaultMovementsLimit is the number of ledger entries returned when the request gives none
+const defaultMovementsLimit = 50
+
+// reservationColumns are the columns scanned by scanReservation, in order
+const reservationColumns = "id, product_id, quantity, status, reference, expires_at, created_at, updated_at"
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanReservation reads a reservation selected with reservationColumns
+func scanReservation(row rowScanner) (*StockReservation, error) {
+	reservation := &StockReservation{}
+	var status string
+	if err := row.Scan(
+		&reservation.ID, &reservation.ProductID, &reservation.Quantity, &status,
+		&reservation.Reference, &reservation.ExpiresAt, &reservation.CreatedAt, &reservation.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	reservation.Status = ReservationStatus(status)
+	return reservation, nil
+}
+
+// recordMovement writes the ledger entry of a stock change of product inside tx
+// product holds the stock after the change; a zero delta is not a change and is not recorded
+func (r *ProductRepository) recordMovement(ctx context.Context, tx *sql.Tx, product *ProductRegistration, delta int, reason StockMovementReason, reservationID *int64, reference string) (*StockMovement, error) {
+	if delta == 0 {
+		return nil, nil
+	}
+
+	movement := &StockMovement{
+		ProductID:     product.ID,
+		Delta:         delta,
+		StockAfter:    product.Stock,
+		Reason:        reason,
+		ReservationID: reservationID,
+		Reference:     reference,
+		Actor:         audit.ActorFromContext(ctx),
+		CorrelationID: audit.CorrelationIDFromContext(ctx),
+		CreatedAt:     time.Now(),
+	}
+
+	query := `
+		INSERT INTO stock_movements (product_id, delta, stock_after, reason, reservation_id, reference, actor, correlation_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+	if err := tx.QueryRowContext(ctx, query,
+		movement.ProductID, movement.Delta, movement.StockAfter, string(movement.Reason), movement.ReservationID,
+		movement.Reference, movement.Actor, movement.CorrelationID, movement.CreatedAt,
+	).Scan(&movement.ID); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// changeStock adds delta to the stock of a live product in one guarded statement
+// The stock never goes below zero: a change that would is an INSUFFICIENT_STOCK
+// AppError, and one past the INTEGER column is an INVALID_STOCK AppError. Returns nil without error when no live product has the ID
+func (r *ProductRepository) changeStock(ctx context.Context, tx *sql.Tx, id int64, delta int) (before, after *ProductRegistration, err error) {
+	query := `
+		UPDATE products
+		SET stock = stock + $1, updated_at = $2, version = version + 1
+		WHERE id = $3 AND deleted_at IS NULL AND stock + $1 >= 0
+		RETURNING stock, updated_at, version
+	`
+
+	after = &ProductRegistration{ID: id}
+	err = tx.QueryRowContext(ctx, query, delta, time.Now(), id).Scan(&after.Stock, &after.UpdatedAt, &after.Version)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == pgNumericValueOutOfRange {
+		return nil, nil, stockOutOfRange(id, delta)
+	}
+	if err == sql.ErrNoRows {
+		// Either there is no such product or the guard failed; tell which
+		var stock int
+		err = tx.QueryRowContext(ctx, `SELECT stock FROM products WHERE id = $1 AND deleted_at IS NULL`, id).Scan(&stock)
+		if err == sql.ErrNoRows {
+			return nil, nil, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, insufficientStock(id, stock, -delta)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Only the stock is audited; the version and timestamp are bookkeeping
+	before = &ProductRegistration{ID: id, Stock: after.Stock - delta}
+	return before, after, nil
+}
+
+// returnStock adds quantity back to the stock of a product, even a soft-deleted one,
+// so a restored product has the stock its released reservations left it
+func (r *ProductRepository) returnStock(ctx context.Context, tx *sql.Tx, id int64, quantity int) (before, after *ProductRegistration, err error) {
+	query := `
+		UPDATE products
+		SET stock = stock + $1, updated_at = $2, version = version + 1
+		WHERE id = $3
+		RETURNING stock, updated_at, version
+	`
+
+	after = &ProductRegistration{ID: id}
+	if err := tx.QueryRowContext(ctx, query, quantity, time.Now(), id).Scan(&after.Stock, &after.UpdatedAt, &after.Version); err != nil {
+		return nil, nil, err
+	}
+	before = &ProductRegistration{ID: id, Stock: after.Stock - quantity}
+	return before, after, nil
+}
+
+// AdjustStock adds delta (negative to remove) to the stock of a product atomically
+// and returns its ledger entry and the new product version. Concurrent adjustments
+// never overwrite each other and the stock never goes below zero. Returns a nil
+// movement without error when no live product has the ID
+func (r *ProductRepository) AdjustStock(ctx context.Context, id int64, delta int, reference string) (*StockMovement, int, error) {
+	var movement *StockMovement
+	version := 0
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		before, after, err := r.changeStock(ctx, tx, id, delta)
+		if err != nil || after == nil {
+			return err
+		}
+		if movement, err = r.recordMovement(ctx, tx, after, delta, StockMovementAdjust, nil, reference); err != nil {
+			return err
+		}
+		version = after.Version
+		return r.recordAudit(ctx, tx, id, audit.ActionStockUpdate, before, after)
+	}); err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			return nil, 0, appErr
+		}
+		r.logger.Error(ctx, "Failed to adjust product stock", interfaces.Fields{
+			"error": err.Error(),
+			"id":    id,
+			"delta": delta,
+		})
+		return nil, 0, fmt.Errorf("failed to adjust product stock: %w", err)
+	}
+
+	if movement != nil {
+		r.logger.Info(ctx, "Product stock adjusted successfully", interfaces.Fields{
+			"id":    id,
+			"delta": delta,
+			"stock": movement.StockAfter,
+		})
+	}
+
+	return movement, version, nil
+}
+
+// Reserve takes quantity out of the stock of a product until expiresAt
+// Fails with INSUFFICIENT_STOCK when less is available. Returns nil without error
+// when no live product has the ID
+func (r *ProductRepository) Reserve(ctx context.Context, id int64, quantity int, reference string, expiresAt time.Time) (*StockReservation, error) {
+	query := `
+		INSERT INTO stock_reservations (product_id, quantity, status, reference, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		RETURNING id
+	`
+
+	var reservation *StockReservation
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		before, after, err := r.changeStock(ctx, tx, id, -quantity)
+		if err != nil || after == nil {
+			return err
+		}
+
+		now := time.Now()
+		created := &StockReservation{
+			ProductID: id,
+			Quantity:  quantity,
+			Status:    ReservationActive,
+			Reference: reference,
+			ExpiresAt: expiresAt,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := tx.QueryRowContext(ctx, query, id, quantity, string(created.Status), reference, expiresAt, now).Scan(&created.ID); err != nil {
+			return err
+		}
+		if _, err := r.recordMovement(ctx, tx, after, -quantity, StockMovementReserve, &created.ID, reference); err != nil {
+			return err
+		}
+		reservation = created
+		return r.recordAudit(ctx, tx, id, audit.ActionStockUpdate, before, after)
+	}); err != nil {
+		if appErr := errors.GetAppError(err); appErr != nil {
+			return nil, appErr
+		}
+		r.logger.Error(ctx, "Failed to reserve product stock", interfaces.Fields{
+			"error":    err.Error(),
+			"id":       id,
+			"quantity": quantity,
+		})
+		return nil, fmt.Errorf("failed to reserve product stock: %w", err)
+	}
+
+	if reservation != nil {
+		r.logger.Info(ctx, "Product stock reserved successfully", interfaces.Fields{
+			"id":             id,
+			"reservation_id": reservation.ID,
+			"quantity":       quantity,
+			"expires_at":     expiresAt,
+		})
+	}
+
+	return reservation, nil
+}
+
+// GetReservation retrieves a reservation of a product
+// Returns nil without error when the product has no such reservation
+func (r *ProductRepository) GetReservation(ctx context.Context, id, reservationID int64) (*StockReservation, error) {
+	query := fmt.Sprintf(`SELECT %s FROM stock_reservations WHERE id = $1 AND product_id = $2`, reservationColumns)
+
+	var reservation *StockReservation
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		found, err := scanReservation(tx.QueryRowContext(ctx, query, reservationID, id))
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		reservation = found
+		return err
+	}); err != nil {
+		r.logger.Error(ctx, "Failed to get stock reservation", interfaces.Fields{
+			"error":          err.Error(),
+			"id":             id,
+			"reservation_id": reservationID,
+		})
+		return nil, fmt.Errorf("failed to get stock reservation: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// getReservationForUpdate loads a reservation of a product and locks its row for the rest of tx
+// Reservations are always locked before their product, never after
+func (r *ProductRepository) getReservationForUpdate(ctx context.Context, tx *sql.Tx, id, reservationID int64) (*StockReservation, error) {
+	query := fmt.Sprintf(`SELECT %s FROM stock_reservations WHERE id = $1 AND product_id = $2 FOR UPDATE`, reservationColumns)
+	return scanReservation(tx.QueryRowContext(ctx, query, reservationID, id))
+}
+
+// setReservationStatus moves a locked reservation to status
+func (r *ProductRepository) setReservationStatus(ctx context.Context, tx *sql.Tx, reservation *StockReservation, status ReservationStatus) error {
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, `UPDATE stock_reservations SET status = $1, updated_at = $2 WHERE id = $3`,
+		string(status), now, reservation.ID); err != nil {
+		return err
+	}
+	reservation.Status = status
+	reservation.UpdatedAt = now
+	return nil
+}
+
+// CommitReservation makes an active reservation final; the stock stays taken
+// Fails with INVALID_RESERVATION_STATE when the reservation is no longer active or
+// has expired. Returns nil without error when the product has no such reservation
+func (r *ProductRepository) CommitReservation(ctx context.Context, id, reservationID int64) (*StockReservation, error) {
+	var reservation *StockReservation
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		locked, err := r.getReservationForUpdate(ctx, tx, id, reservationID)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkReservationActive(locked, time.Now()); err != nil {
+			return err
+		}
+		if err := r.setReservationStatus(ctx, tx, locked, ReservationCommitted); err != nil {
+			return err
+		}
+		reservation = locked
+		return nil
+	}); err != nil {
+		return nil, r.reservationError(ctx, "Failed to commit stock reservation", id, reservationID, err)
+	}
+
+	return reservation, nil
+}
+
+// ReleaseReservation cancels an active reservation and returns its quantity to the stock
+// Fails with INVALID_RESERVATION_STATE when the reservation is no longer active.
+// Returns nil without error when the product has no such reservation
+func (r *ProductRepository) ReleaseReservation(ctx context.Context, id, reservationID int64) (*StockReservation, error) {
+	var reservation *StockReservation
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		locked, err := r.getReservationForUpdate(ctx, tx, id, reservationID)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// A reservation past its expiry may still be released: the sweep has simply not reached it
+		if locked.Status != ReservationActive {
+			return invalidReservationState(locked)
+		}
+		if err := r.finishReservation(ctx, tx, locked, ReservationReleased, StockMovementRelease); err != nil {
+			return err
+		}
+		reservation = locked
+		return nil
+	}); err != nil {
+		return nil, r.reservationError(ctx, "Failed to release stock reservation", id, reservationID, err)
+	}
+
+	return reservation, nil
+}
+
+// ExpireReservations returns the stock of at most limit reservations that expired by now
+// Each reservation is expired in its own transaction, so a long backlog does not hold
+// many locks; reservations committed or released meanwhile are skipped
+func (r *ProductRepository) ExpireReservations(ctx context.Context, now time.Time, limit int) (int64, error) {
+	query := `
+		SELECT id, product_id
+		FROM stock_reservations
+		WHERE status = $1 AND expires_at <= $2
+		ORDER BY expires_at
+		LIMIT $3
+	`
+
+	type key struct{ id, productID int64 }
+	var due []key
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, string(ReservationActive), now, limit)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var k key
+			if err := rows.Scan(&k.id, &k.productID); err != nil {
+				return err
+			}
+			due = append(due, k)
+		}
+		return rows.Err()
+	}); err != nil {
+		r.logger.Error(ctx, "Failed to find expired stock reservations", interfaces.Fields{
+			"error": err.Error(),
+		})
+		return 0, fmt.Errorf("failed to find expired stock reservations: %w", err)
+	}
+
+	var expired int64
+	for _, k := range due {
+		done := false
+		if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+			locked, err := r.getReservationForUpdate(ctx, tx, k.productID, k.id)
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if locked.Status != ReservationActive {
+				return nil
+			}
+			if err := r.finishReservation(ctx, tx, locked, ReservationExpired, StockMovementExpire); err != nil {
+				return err
+			}
+			done = true
+			return nil
+		}); err != nil {
+			r.logger.Error(ctx, "Failed to expire stock reservation", interfaces.Fields{
+				"error":          err.Error(),
+				"reservation_id": k.id,
+			})
+			return expired, fmt.Errorf("failed to expire stock reservation %d: %w", k.id, err)
+		}
+		if done {
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+// finishReservation moves a locked active reservation to status and returns its quantity to the stock
+func (r *ProductRepository) finishReservation(ctx context.Context, tx *sql.Tx, reservation *StockReservation, status ReservationStatus, reason StockMovementReason) error {
+	if err := r.setReservationStatus(ctx, tx, reservation, status); err != nil {
+		return err
+	}
+	before, after, err := r.returnStock(ctx, tx, reservation.ProductID, reservation.Quantity)
+	if err != nil {
+		return err
+	}
+	if _, err := r.recordMovement(ctx, tx, after, reservation.Quantity, reason, &reservation.ID, reservation.Reference); err != nil {
+		return err
+	}
+	return r.recordAudit(ctx, tx, reservation.ProductID, audit.ActionStockUpdate, before, after)
+}
+
+// reservationError returns AppErrors unchanged and logs and wraps any other error
+func (r *ProductRepository) reservationError(ctx context.Context, message string, id, reservationID int64, err error) error {
+	if appErr := errors.GetAppError(err); appErr != nil {
+		return appErr
+	}
+	r.logger.Error(ctx, message, interfaces.Fields{
+		"error":          err.Error(),
+		"id":             id,
+		"reservation_id": reservationID,
+	})
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+// StockMovements returns the stock ledger of a product, newest first
+func (r *ProductRepository) StockMovements(ctx context.Context, id int64, limit int) ([]*StockMovement, error) {
+	if limit <= 0 {
+		limit = defaultMovementsLimit
+	}
+
+	query := `
+		SELECT id, product_id, delta, stock_after, reason, reservation_id, reference, actor, correlation_id, created_at
+		FROM stock_movements
+		WHERE product_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`
+
+	movements := []*StockMovement{}
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, id, limit)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			movement := &StockMovement{}
+			var reason string
+			var reservationID sql.NullInt64
+			if err := rows.Scan(
+				&movement.ID, &movement.ProductID, &movement.Delta, &movement.StockAfter, &reason,
+				&reservationID, &movement.Reference, &movement.Actor, &movement.CorrelationID, &movement.CreatedAt,
+			); err != nil {
+				return err
+			}
+			movement.Reason = StockMovementReason(reason)
+			if reservationID.Valid {
+				movement.ReservationID = &reservationID.Int64
+			}
+			movements = append(movements, movement)
+		}
+		return rows.Err()
+	}); err != nil {
+		r.logger.Error(ctx, "Failed to list stock movements", interfaces.Fields{
+			"error": err.Error(),
+			"id":    id,
+		})
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// checkReservationActive fails with INVALID_RESERVATION_STATE unless reservation can still be committed at now
+func checkReservationActive(reservation *StockReservation, now time.Time) error {
+	if reservation.Status != ReservationActive {
+		return invalidReservationState(reservation)
+	}
+	if !now.Before(reservation.ExpiresAt) {
+		return errors.NewWithDetails(errors.ErrCodeInvalidReservationState, "Reservation cannot be changed",
+			fmt.Sprintf("Reservation %d expired at %s", reservation.ID, reservation.ExpiresAt.UTC().Format(time.RFC3339)), http.StatusConflict).
+			WithField("reservation_id", reservation.ID).
+			WithField("status", ReservationExpired)
+	}
+	return nil
+}
+
+// invalidReservationState reports that reservation is no longer active and cannot change
+func invalidReservationState(reservation *StockReservation) *errors.AppError {
+	return errors.NewWithDetails(errors.ErrCodeInvalidReservationState, "Reservation cannot be changed",
+		fmt.Sprintf("Reservation %d is %s; only active reservations can change", reservation.ID, reservation.Status), http.StatusConflict).
+		WithField("reservation_id", reservation.ID).
+		WithField("status", reservation.Status)
+}
+
+// stockOutOfRange reports that adding delta would take the stock of product id past the INTEGER column
+func stockOutOfRange(id int64, delta int) *errors.AppError {
+	return errors.NewWithDetails(errors.ErrCodeInvalidStock, "Invalid stock adjustment",
+		fmt.Sprintf("Adding %d to the stock of product %d exceeds the largest stock of %d", delta, id, math.MaxInt32), http.StatusUnprocessableEntity).
+		WithField("product_id", id).
+		WithField("delta", delta)
+}
+
+// insufficientStock reports that product id has only available units of the requested ones
+func insufficientStock(id int64, available, requested int) *errors.AppError {
+	return errors.NewWithDetails(errors.ErrCodeInsufficientStock, "Insufficient stock",
+		fmt.Sprintf("Product with ID %d has %d in stock, %d requested", id, available, requested), http.StatusConflict).
+		WithField("product_id", id).
+		WithField("available", available).
+		WithField("requested", requested)
+}
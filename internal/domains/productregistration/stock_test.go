@@ -0,0 +1,248 @@
+package productregistration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+)
+
+// stockRepository keeps the stock of one product in memory, changing it the way
+// the guarded UPDATE does; expireBatches are the counts ExpireReservations returns in turn
+type stockRepository struct {
+	Repository
+	stock         int
+	version       int
+	expiresAt     time.Time
+	expireBatches []int64
+}
+
+func (r *stockRepository) AdjustStock(ctx context.Context, id int64, delta int, reference string) (*StockMovement, int, error) {
+	if id != 7 {
+		return nil, 0, nil
+	}
+	if r.stock+delta < 0 {
+		return nil, 0, insufficientStock(id, r.stock, -delta)
+	}
+	r.stock += delta
+	r.version++
+	return &StockMovement{ProductID: id, Delta: delta, StockAfter: r.stock, Reason: StockMovementAdjust, Reference: reference}, r.version, nil
+}
+
+func (r *stockRepository) Reserve(ctx context.Context, id int64, quantity int, reference string, expiresAt time.Time) (*StockReservation, error) {
+	if _, _, err := r.AdjustStock(ctx, id, -quantity, reference); err != nil {
+		return nil, err
+	}
+	r.expiresAt = expiresAt
+	return &StockReservation{ID: 1, ProductID: id, Quantity: quantity, Status: ReservationActive, ExpiresAt: expiresAt}, nil
+}
+
+func (r *stockRepository) ExpireReservations(ctx context.Context, now time.Time, limit int) (int64, error) {
+	if len(r.expireBatches) == 0 {
+		return 0, nil
+	}
+	expired := r.expireBatches[0]
+	r.expireBatches = r.expireBatches[1:]
+	return expired, nil
+}
+
+func TestCheckReservationActive(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		status    ReservationStatus
+		expiresAt time.Time
+		state     ReservationStatus
+	}{
+		{"active", ReservationActive, now.Add(time.Second), ""},
+		{"expires now", ReservationActive, now, ReservationExpired},
+		{"past expiry", ReservationActive, now.Add(-time.Minute), ReservationExpired},
+		{"committed", ReservationCommitted, now.Add(time.Minute), ReservationCommitted},
+		{"released", ReservationReleased, now.Add(time.Minute), ReservationReleased},
+		{"expired by the sweep", ReservationExpired, now.Add(-time.Minute), ReservationExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkReservationActive(&StockReservation{ID: 3, Status: tt.status, ExpiresAt: tt.expiresAt}, now)
+			if tt.state == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			appErr := errors.GetAppError(err)
+			require.NotNil(t, appErr)
+			assert.Equal(t, errors.ErrCodeInvalidReservationState, appErr.Code)
+			assert.Equal(t, http.StatusConflict, appErr.HTTPStatus)
+			assert.Equal(t, int64(3), appErr.Fields["reservation_id"])
+			assert.Equal(t, tt.state, appErr.Fields["status"])
+		})
+	}
+}
+
+func TestInsufficientStock(t *testing.T) {
+	appErr := insufficientStock(7, 2, 5)
+
+	assert.Equal(t, errors.ErrCodeInsufficientStock, appErr.Code)
+	assert.Equal(t, http.StatusConflict, appErr.HTTPStatus)
+	assert.Equal(t, "Product with ID 7 has 2 in stock, 5 requested", appErr.Details)
+	assert.Equal(t, 2, appErr.Fields["available"])
+	assert.Equal(t, 5, appErr.Fields["requested"])
+}
+
+func TestStockOutOfRange(t *testing.T) {
+	appErr := stockOutOfRange(7, 5)
+
+	assert.Equal(t, errors.ErrCodeInvalidStock, appErr.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, appErr.HTTPStatus)
+	assert.Equal(t, 5, appErr.Fields["delta"])
+}
+
+func TestStockRequests_Binding(t *testing.T) {
+	tests := []struct {
+		body  string
+		into  interface{}
+		valid bool
+	}{
+		{`{"delta": -3}`, &StockAdjustmentRequest{}, true},
+		{`{"delta": 0}`, &StockAdjustmentRequest{}, false},
+		{`{"delta": 2147483647}`, &StockAdjustmentRequest{}, true},
+		{`{"delta": 2147483648}`, &StockAdjustmentRequest{}, false},
+		{`{"delta": -2147483648}`, &StockAdjustmentRequest{}, false},
+		{`{"quantity": 2, "ttl_seconds": 600}`, &CreateReservationRequest{}, true},
+		{`{"quantity": 2147483648}`, &CreateReservationRequest{}, false},
+		{`{"quantity": 2, "ttl_seconds": 31536000}`, &CreateReservationRequest{}, true},
+		{`{"quantity": 2, "ttl_seconds": 10000000000}`, &CreateReservationRequest{}, false},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			err := c.ShouldBindJSON(tt.into)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRecordMovement_SkipsZeroDelta(t *testing.T) {
+	// A zero delta returns before touching the transaction
+	movement, err := (&ProductRepository{}).recordMovement(context.Background(), nil, &ProductRegistration{ID: 7, Stock: 4}, 0, StockMovementSet, nil, "")
+
+	assert.NoError(t, err)
+	assert.Nil(t, movement)
+}
+
+func TestProductService_AdjustStock(t *testing.T) {
+	ctx := context.Background()
+	repo := &stockRepository{stock: 5, version: 1}
+	service := newTestService(t, repo, config.ProductsConfig{})
+
+	response, err := service.AdjustStock(ctx, 7, &StockAdjustmentRequest{Delta: -3, Reference: "order-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, response.Stock)
+	assert.Equal(t, 2, response.Version)
+	assert.Equal(t, -3, response.Movement.Delta)
+	assert.Equal(t, 2, response.Movement.StockAfter)
+
+	response, err = service.AdjustStock(ctx, 7, &StockAdjustmentRequest{Delta: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 12, response.Stock)
+
+	// Taking more than is in stock changes nothing
+	_, err = service.AdjustStock(ctx, 7, &StockAdjustmentRequest{Delta: -13})
+	appErr := errors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, errors.ErrCodeInsufficientStock, appErr.Code)
+	assert.Equal(t, 12, repo.stock)
+
+	_, err = service.AdjustStock(ctx, 7, &StockAdjustmentRequest{Delta: 0})
+	appErr = errors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, appErr.HTTPStatus)
+
+	_, err = service.AdjustStock(ctx, 8, &StockAdjustmentRequest{Delta: 1})
+	appErr = errors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, errors.ErrCodeProductNotFound, appErr.Code)
+}
+
+func TestProductService_ReserveStockExpiry(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        config.ProductsConfig
+		ttlSeconds int
+		ttl        time.Duration
+		status     int
+	}{
+		{name: "default TTL", ttl: defaultReservationTTL},
+		{name: "configured TTL", cfg: config.ProductsConfig{ReservationTTL: 5 * time.Minute}, ttl: 5 * time.Minute},
+		{name: "requested TTL", cfg: config.ProductsConfig{ReservationTTL: 5 * time.Minute}, ttlSeconds: 90, ttl: 90 * time.Second},
+		{name: "requested TTL at the maximum", cfg: config.ProductsConfig{MaxReservationTTL: time.Hour}, ttlSeconds: 3600, ttl: time.Hour},
+		{name: "requested TTL over the maximum", cfg: config.ProductsConfig{MaxReservationTTL: time.Hour}, ttlSeconds: 3601, status: http.StatusBadRequest},
+		{name: "configured TTL over the maximum", cfg: config.ProductsConfig{ReservationTTL: 2 * time.Hour, MaxReservationTTL: time.Hour}, status: http.StatusBadRequest},
+		{name: "requested TTL overflowing a duration", cfg: config.ProductsConfig{MaxReservationTTL: time.Hour}, ttlSeconds: 10_000_000_000, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stockRepository{stock: 5}
+			service := newTestService(t, repo, tt.cfg)
+
+			start := time.Now()
+			reservation, err := service.ReserveStock(context.Background(), 7, &CreateReservationRequest{Quantity: 2, TTLSeconds: tt.ttlSeconds})
+			if tt.status != 0 {
+				appErr := errors.GetAppError(err)
+				require.NotNil(t, appErr)
+				assert.Equal(t, tt.status, appErr.HTTPStatus)
+				assert.Equal(t, 5, repo.stock)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, 3, repo.stock)
+			assert.WithinRange(t, reservation.ExpiresAt, start.Add(tt.ttl), time.Now().Add(tt.ttl))
+		})
+	}
+}
+
+func TestProductService_ReserveStockInsufficient(t *testing.T) {
+	repo := &stockRepository{stock: 1}
+	service := newTestService(t, repo, config.ProductsConfig{})
+
+	_, err := service.ReserveStock(context.Background(), 7, &CreateReservationRequest{Quantity: 2})
+
+	appErr := errors.GetAppError(err)
+	require.NotNil(t, appErr)
+	assert.Equal(t, errors.ErrCodeInsufficientStock, appErr.Code)
+	assert.Equal(t, 1, appErr.Fields["available"])
+	assert.Equal(t, 2, appErr.Fields["requested"])
+	assert.Equal(t, 1, repo.stock)
+}
+
+func TestProductService_ExpireReservationsDrainsFullBatches(t *testing.T) {
+	repo := &stockRepository{expireBatches: []int64{expireBatchSize, expireBatchSize, 3, 50}}
+	service := newTestService(t, repo, config.ProductsConfig{})
+
+	expired, err := service.ExpireReservations(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2*expireBatchSize+3), expired)
+	assert.Equal(t, []int64{50}, repo.expireBatches)
+}
@@ -0,0 +1,126 @@
+package productregistration
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"tushartemplategin/pkg/errors"
+)
+
+// Expected versions accepted by repository writes
+// A positive expected version must equal the current one for the write to apply
+const (
+	NoVersion  = 0  // No precondition: the write applies to whatever version is current
+	AnyVersion = -1 // If-Match: *; the product must exist but any version matches
+)
+
+// Precondition is the If-Match header of a write
+// The zero value is no If-Match; Any is If-Match: *; otherwise the write applies only
+// while the product is at one of Versions
+type Precondition struct {
+	Any      bool
+	Versions []int
+}
+
+// IsSet reports whether the client sent an If-Match header
+func (p Precondition) IsSet() bool {
+	return p.Any || len(p.Versions) > 0
+}
+
+// String returns the precondition in If-Match syntax
+func (p Precondition) String() string {
+	if p.Any {
+		return "*"
+	}
+	tags := make([]string, len(p.Versions))
+	for i, version := range p.Versions {
+		tags[i] = productETag(version)
+	}
+	return strings.Join(tags, ", ")
+}
+
+// version returns the expected version a repository write re-checks under its row lock
+// It is false for a list of tags, which needs the current version to pick the one that matches
+func (p Precondition) version() (int, bool) {
+	switch {
+	case p.Any:
+		return AnyVersion, true
+	case len(p.Versions) == 0:
+		return NoVersion, true
+	case len(p.Versions) == 1:
+		return p.Versions[0], true
+	}
+	return 0, false
+}
+
+// check fails with 412 Precondition Failed unless the precondition allows version current
+func (p Precondition) check(id int64, current int) error {
+	if !p.IsSet() || p.Any || slices.Contains(p.Versions, current) {
+		return nil
+	}
+	if len(p.Versions) == 1 {
+		return versionConflict(id, p.Versions[0], current, http.StatusPreconditionFailed)
+	}
+	return errors.NewWithDetails(errors.ErrCodeProductVersionConflict, "Product was modified",
+		fmt.Sprintf("Product with ID %d is at version %d, none of %s; reload it and retry", id, current, p), http.StatusPreconditionFailed).
+		WithField("product_id", id).
+		WithField("current_version", current)
+}
+
+// productETag returns the strong entity tag of a product version
+func productETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// parseIfMatch returns the precondition an If-Match header asks for
+// The header is * or a list of tags (RFC 9110 section 13.1.1), compared strongly: weak
+// tags and tags not issued by this API can never match and are ignored. A list in which
+// no tag is left fails the precondition; * inside a list is rejected
+func parseIfMatch(header string) (Precondition, error) {
+	header = strings.TrimSpace(header)
+	switch header {
+	case "":
+		return Precondition{}, nil
+	case "*":
+		return Precondition{Any: true}, nil
+	}
+
+	var versions []int
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return Precondition{}, errors.NewWithDetails(errors.ErrCodeBadRequest, "Invalid If-Match header",
+				"If-Match must be * or a list of ETags, not both", http.StatusBadRequest)
+		}
+		if len(tag) > 2 && strings.HasPrefix(tag, `"`) && strings.HasSuffix(tag, `"`) {
+			if version, err := strconv.Atoi(tag[1 : len(tag)-1]); err == nil && version > 0 && !slices.Contains(versions, version) {
+				versions = append(versions, version)
+			}
+		}
+	}
+	if len(versions) == 0 {
+		return Precondition{}, errors.NewWithDetails(errors.ErrCodeProductVersionConflict, "Product was modified",
+			fmt.Sprintf("If-Match %s does not match any version of the product", header), http.StatusPreconditionFailed)
+	}
+	return Precondition{Versions: versions}, nil
+}
+
+// checkVersion fails with 412 Precondition Failed when expected names another version than current
+func checkVersion(id int64, expected, current int) error {
+	if expected <= NoVersion || expected == current {
+		return nil
+	}
+	return versionConflict(id, expected, current, http.StatusPreconditionFailed)
+}
+
+// versionConflict reports that product id is at version current rather than expected
+// status is 412 when the client sent the expected version, 409 when the service read it
+func versionConflict(id int64, expected, current, status int) *errors.AppError {
+	return errors.NewWithDetails(errors.ErrCodeProductVersionConflict, "Product was modified",
+		fmt.Sprintf("Product with ID %d is at version %d, not %d; reload it and retry", id, current, expected), status).
+		WithField("product_id", id).
+		WithField("current_version", current)
+}
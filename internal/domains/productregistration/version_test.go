@@ -0,0 +1,185 @@
+package productregistration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/pkg/config"
+	"tushartemplategin/pkg/errors"
+)
+
+func TestProductETag(t *testing.T) {
+	assert.Equal(t, `"1"`, productETag(1))
+	assert.Equal(t, `"42"`, productETag(42))
+
+	ifMatch, err := parseIfMatch(productETag(42))
+	require.NoError(t, err)
+	assert.Equal(t, Precondition{Versions: []int{42}}, ifMatch)
+}
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		ifMatch Precondition
+		status  int
+	}{
+		{"", Precondition{}, 0},
+		{"   ", Precondition{}, 0},
+		{"*", Precondition{Any: true}, 0},
+		{` "3" `, Precondition{Versions: []int{3}}, 0},
+		{`"3", "4"`, Precondition{Versions: []int{3, 4}}, 0},
+		{`"3","4"`, Precondition{Versions: []int{3, 4}}, 0},
+		{`W/"3", "4"`, Precondition{Versions: []int{4}}, 0},
+		{`"3", "abc", "3"`, Precondition{Versions: []int{3}}, 0},
+		{`*, "4"`, Precondition{}, http.StatusBadRequest},
+		{`W/"3"`, Precondition{}, http.StatusPreconditionFailed},
+		{`W/"3", W/"4"`, Precondition{}, http.StatusPreconditionFailed},
+		{`"0"`, Precondition{}, http.StatusPreconditionFailed},
+		{`"-1"`, Precondition{}, http.StatusPreconditionFailed},
+		{`"abc"`, Precondition{}, http.StatusPreconditionFailed},
+		{`""`, Precondition{}, http.StatusPreconditionFailed},
+		{`3`, Precondition{}, http.StatusPreconditionFailed},
+		{`"3`, Precondition{}, http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			ifMatch, err := parseIfMatch(tt.header)
+			if tt.status == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, tt.ifMatch, ifMatch)
+				return
+			}
+
+			appErr := errors.GetAppError(err)
+			require.NotNil(t, appErr)
+			assert.Equal(t, tt.status, appErr.HTTPStatus)
+			if tt.status == http.StatusPreconditionFailed {
+				assert.Equal(t, errors.ErrCodeProductVersionConflict, appErr.Code)
+			} else {
+				assert.Equal(t, errors.ErrCodeBadRequest, appErr.Code)
+			}
+		})
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	assert.NoError(t, checkVersion(7, NoVersion, 3))
+	assert.NoError(t, checkVersion(7, AnyVersion, 3))
+	assert.NoError(t, checkVersion(7, 3, 3))
+
+	appErr := errors.GetAppError(checkVersion(7, 2, 3))
+	require.NotNil(t, appErr)
+	assert.Equal(t, errors.ErrCodeProductVersionConflict, appErr.Code)
+	assert.Equal(t, http.StatusPreconditionFailed, appErr.HTTPStatus)
+	assert.Equal(t, int64(7), appErr.Fields["product_id"])
+	assert.Equal(t, 3, appErr.Fields["current_version"])
+}
+
+func TestPrecondition_Check(t *testing.T) {
+	assert.NoError(t, Precondition{}.check(7, 3))
+	assert.NoError(t, Precondition{Any: true}.check(7, 3))
+	assert.NoError(t, Precondition{Versions: []int{3}}.check(7, 3))
+	assert.NoError(t, Precondition{Versions: []int{2, 3}}.check(7, 3))
+
+	for _, ifMatch := range []Precondition{{Versions: []int{2}}, {Versions: []int{1, 2}}} {
+		appErr := errors.GetAppError(ifMatch.check(7, 3))
+		require.NotNil(t, appErr)
+		assert.Equal(t, errors.ErrCodeProductVersionConflict, appErr.Code)
+		assert.Equal(t, http.StatusPreconditionFailed, appErr.HTTPStatus)
+		assert.Equal(t, 3, appErr.Fields["current_version"])
+	}
+}
+
+func TestProductService_UpdateProductPreconditions(t *testing.T) {
+	name := "Renamed"
+
+	tests := []struct {
+		name     string
+		cfg      config.ProductsConfig
+		lostRace bool
+		ifMatch  Precondition
+		status   int
+	}{
+		{name: "unconditional"},
+		{name: "any version", ifMatch: Precondition{Any: true}},
+		{name: "current version", ifMatch: Precondition{Versions: []int{2}}},
+		{name: "list with current version", ifMatch: Precondition{Versions: []int{1, 2}}},
+		{name: "stale version", ifMatch: Precondition{Versions: []int{1}}, status: http.StatusPreconditionFailed},
+		{name: "list without current version", ifMatch: Precondition{Versions: []int{1, 3}}, status: http.StatusPreconditionFailed},
+		{name: "If-Match required", cfg: config.ProductsConfig{RequireIfMatch: true}, status: http.StatusPreconditionRequired},
+		{name: "lost race with If-Match", lostRace: true, ifMatch: Precondition{Versions: []int{2}}, status: http.StatusPreconditionFailed},
+		{name: "lost race with If-Match list", lostRace: true, ifMatch: Precondition{Versions: []int{1, 2}}, status: http.StatusPreconditionFailed},
+		{name: "lost race without If-Match", lostRace: true, status: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Another write bumps the version between the read and the locked update
+			var updateErr error
+			if tt.lostRace {
+				updateErr = versionConflict(7, 2, 3, http.StatusPreconditionFailed)
+			}
+			repo := &stubRepository{
+				products:  map[int64]*ProductRegistration{7: {ID: 7, Name: "Chair", Version: 2}},
+				updateErr: updateErr,
+			}
+			service := newTestService(t, repo, tt.cfg)
+
+			product, err := service.UpdateProduct(context.Background(), 7, &UpdateProductRequest{Name: &name}, tt.ifMatch)
+			if tt.status == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, "Renamed", product.Name)
+				assert.Equal(t, 3, product.Version)
+				return
+			}
+
+			appErr := errors.GetAppError(err)
+			require.NotNil(t, appErr)
+			assert.Equal(t, tt.status, appErr.HTTPStatus)
+		})
+	}
+}
+
+func TestUpdateProductHandler_IfMatch(t *testing.T) {
+	tests := []struct {
+		ifMatch string
+		status  int
+		etag    string
+	}{
+		{"", http.StatusOK, `"3"`},
+		{`"2"`, http.StatusOK, `"3"`},
+		{"*", http.StatusOK, `"3"`},
+		{`"1"`, http.StatusPreconditionFailed, ""},
+		{`W/"2"`, http.StatusPreconditionFailed, ""},
+		{`"2", "3"`, http.StatusOK, `"3"`},
+		{`W/"2", "2"`, http.StatusOK, `"3"`},
+		{`"1", W/"2"`, http.StatusPreconditionFailed, ""},
+		{`*, "2"`, http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ifMatch, func(t *testing.T) {
+			router := newTestRouter(t, &stubRepository{
+				products: map[int64]*ProductRegistration{7: {ID: 7, Name: "Chair", Version: 2}},
+			})
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/products/7", strings.NewReader(`{"name":"Renamed"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-API-Key", "key")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.status, recorder.Code)
+			assert.Equal(t, tt.etag, recorder.Header().Get("ETag"))
+		})
+	}
+}
@@ -94,3 +94,17 @@ func (mr *MockLoggerMockRecorder) Warn(ctx, msg, fields interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warn", reflect.TypeOf((*MockLogger)(nil).Warn), ctx, msg, fields)
 }
+
+// With mocks base method.
+func (m *MockLogger) With(fields interfaces.Fields) interfaces.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "With", fields)
+	ret0, _ := ret[0].(interfaces.Logger)
+	return ret0
+}
+
+// With indicates an expected call of With.
+func (mr *MockLoggerMockRecorder) With(fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "With", reflect.TypeOf((*MockLogger)(nil).With), fields)
+}
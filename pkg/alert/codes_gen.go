@@ -0,0 +1,101 @@
+// Code generated by go run ./cmd/catalog gen; DO NOT EDIT.
+// Source: alert catalog (messagecatelog.json, messagecatelog-en-US.json)
+
+package alert
+
+// Message codes of the alert catalog
+const (
+	// CodeABC0001 is "Registration is about to expire" (Registration, CRITICAL)
+	CodeABC0001 = "ABC0001"
+	// CodeABC0002 is "Authentication failed" (Authentication, HIGH)
+	CodeABC0002 = "ABC0002"
+	// CodeABC0003 is "License validation warning" (License, MEDIUM)
+	CodeABC0003 = "ABC0003"
+	// CodeABC0004 is "System maintenance scheduled" (System, LOW)
+	CodeABC0004 = "ABC0004"
+	// CodeABC0005 is "Security breach detected" (Security, CRITICAL)
+	CodeABC0005 = "ABC0005"
+)
+
+// CatalogCodes returns every message code of the alert catalog, sorted
+func CatalogCodes() []string {
+	return []string{
+		CodeABC0001,
+		CodeABC0002,
+		CodeABC0003,
+		CodeABC0004,
+		CodeABC0005,
+	}
+}
+
+// ABC0001Params are the parameters of CodeABC0001
+type ABC0001Params struct {
+	ExpDate string `json:"exp_date"`
+}
+
+// Parameters returns p as a message catalog parameter map
+func (p ABC0001Params) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"exp_date": p.ExpDate,
+	}
+}
+
+// ABC0002Params are the parameters of CodeABC0002
+type ABC0002Params struct {
+	Timestamp string `json:"timestamp"`
+	Username  string `json:"username"`
+}
+
+// Parameters returns p as a message catalog parameter map
+func (p ABC0002Params) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"timestamp": p.Timestamp,
+		"username":  p.Username,
+	}
+}
+
+// ABC0003Params are the parameters of CodeABC0003
+type ABC0003Params struct {
+	ExpiryDate  string `json:"expiry_date"`
+	FeatureName string `json:"feature_name"`
+}
+
+// Parameters returns p as a message catalog parameter map
+func (p ABC0003Params) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"expiry_date":  p.ExpiryDate,
+		"feature_name": p.FeatureName,
+	}
+}
+
+// ABC0004Params are the parameters of CodeABC0004
+type ABC0004Params struct {
+	EndTime         string `json:"end_time"`
+	MaintenanceDate string `json:"maintenance_date"`
+	StartTime       string `json:"start_time"`
+}
+
+// Parameters returns p as a message catalog parameter map
+func (p ABC0004Params) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"end_time":         p.EndTime,
+		"maintenance_date": p.MaintenanceDate,
+		"start_time":       p.StartTime,
+	}
+}
+
+// ABC0005Params are the parameters of CodeABC0005
+type ABC0005Params struct {
+	ActivityType string `json:"activity_type"`
+	IPAddress    string `json:"ip_address"`
+	Timestamp    string `json:"timestamp"`
+}
+
+// Parameters returns p as a message catalog parameter map
+func (p ABC0005Params) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"activity_type": p.ActivityType,
+		"ip_address":    p.IPAddress,
+		"timestamp":     p.Timestamp,
+	}
+}
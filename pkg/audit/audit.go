@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// Action identifies the kind of change recorded in an audit event
+type Action string
+
+// Audit action constants
+const (
+	ActionCreate      Action = "create"       // Entity was created
+	ActionUpdate      Action = "update"       // Entity fields were updated
+	ActionDelete      Action = "delete"       // Entity was deleted
+	ActionRestore     Action = "restore"      // Soft-deleted entity was restored
+	ActionPurge       Action = "purge"        // Soft-deleted entity was removed permanently
+	ActionStockUpdate Action = "stock_update" // Product stock quantity was changed
+)
+
+// Context keys shared with the HTTP middleware (kept as plain strings so this
+// package does not depend on pkg/middleware)
+const (
+	principalContextKey     = "principal"
+	correlationIDContextKey = "correlation_id"
+)
+
+// SystemActor is recorded when no authenticated principal is present
+const SystemActor = "system"
+
+// FieldChange holds the before and after values of a single field
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Event represents a single audit trail entry
+type Event struct {
+	ID            int64                  `json:"id" db:"id"`
+	EntityType    string                 `json:"entity_type" db:"entity_type"`
+	EntityID      string                 `json:"entity_id" db:"entity_id"`
+	Action        Action                 `json:"action" db:"action"`
+	Actor         string                 `json:"actor" db:"actor"`
+	CorrelationID string                 `json:"correlation_id,omitempty" db:"correlation_id"`
+	Changes       map[string]FieldChange `json:"changes" db:"changes"`
+	CreatedAt     time.Time              `json:"created_at" db:"created_at"`
+}
+
+// NewEvent builds an audit event for the given entity, filling actor and
+// correlation ID from the request context and computing the field diff
+func NewEvent(ctx context.Context, entityType, entityID string, action Action, before, after interface{}) *Event {
+	return &Event{
+		EntityType:    entityType,
+		EntityID:      entityID,
+		Action:        action,
+		Actor:         ActorFromContext(ctx),
+		CorrelationID: CorrelationIDFromContext(ctx),
+		Changes:       Diff(before, after),
+		CreatedAt:     time.Now(),
+	}
+}
+
+// ActorFromContext returns the authenticated principal or SystemActor
+func ActorFromContext(ctx context.Context) string {
+	if principal, ok := ctx.Value(principalContextKey).(string); ok && principal != "" {
+		return principal
+	}
+	return SystemActor
+}
+
+// CorrelationIDFromContext returns the request correlation ID if present
+func CorrelationIDFromContext(ctx context.Context) string {
+	if correlationID, ok := ctx.Value(correlationIDContextKey).(string); ok {
+		return correlationID
+	}
+	return ""
+}
+
+// ignoredDiffFields are bookkeeping fields that change on every write
+var ignoredDiffFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"version":    true,
+}
+
+// Diff compares two values by their JSON representation and returns the
+// fields that differ. Either side may be nil (create or delete).
+func Diff(before, after interface{}) map[string]FieldChange {
+	beforeFields := toFieldMap(before)
+	afterFields := toFieldMap(after)
+
+	changes := make(map[string]FieldChange)
+	for key, beforeValue := range beforeFields {
+		if ignoredDiffFields[key] {
+			continue
+		}
+		afterValue, exists := afterFields[key]
+		if !exists || !reflect.DeepEqual(beforeValue, afterValue) {
+			changes[key] = FieldChange{Before: beforeValue, After: afterValue}
+		}
+	}
+	for key, afterValue := range afterFields {
+		if ignoredDiffFields[key] {
+			continue
+		}
+		if _, exists := beforeFields[key]; !exists {
+			changes[key] = FieldChange{Before: nil, After: afterValue}
+		}
+	}
+
+	return changes
+}
+
+// toFieldMap converts a struct (or pointer) to a map using its JSON tags
+func toFieldMap(value interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if value == nil {
+		return fields
+	}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return fields
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fields
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return make(map[string]interface{})
+	}
+	return fields
+}
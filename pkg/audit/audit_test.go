@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sampleEntity struct {
+	Name      string  `json:"name"`
+	Price     float64 `json:"price"`
+	Stock     int     `json:"stock"`
+	UpdatedAt string  `json:"updated_at"`
+	Version   int     `json:"version"`
+}
+
+func TestDiff_Update(t *testing.T) {
+	before := &sampleEntity{Name: "Widget", Price: 10, Stock: 5, UpdatedAt: "t1", Version: 1}
+	after := &sampleEntity{Name: "Widget", Price: 12.5, Stock: 5, UpdatedAt: "t2", Version: 2}
+
+	changes := Diff(before, after)
+
+	assert.Len(t, changes, 1)
+	assert.Equal(t, FieldChange{Before: 10.0, After: 12.5}, changes["price"])
+}
+
+func TestDiff_CreateAndDelete(t *testing.T) {
+	entity := &sampleEntity{Name: "Widget", Price: 10, Stock: 5}
+
+	created := Diff(nil, entity)
+	assert.Len(t, created, 3)
+	assert.Equal(t, FieldChange{Before: nil, After: "Widget"}, created["name"])
+
+	var missing *sampleEntity
+	deleted := Diff(entity, missing)
+	assert.Len(t, deleted, 3)
+	assert.Equal(t, FieldChange{Before: 5.0, After: nil}, deleted["stock"])
+}
+
+func TestNewEvent_UsesRequestContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), principalContextKey, "alice")
+	ctx = context.WithValue(ctx, correlationIDContextKey, "corr-1")
+
+	event := NewEvent(ctx, "product", "7", ActionUpdate, nil, nil)
+
+	assert.Equal(t, "alice", event.Actor)
+	assert.Equal(t, "corr-1", event.CorrelationID)
+	assert.Equal(t, ActionUpdate, event.Action)
+	assert.Empty(t, event.Changes)
+}
+
+func TestActorFromContext_DefaultsToSystem(t *testing.T) {
+	assert.Equal(t, SystemActor, ActorFromContext(context.Background()))
+}
@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:generate go run ../../cmd/catalog gen -dir catalog -out codes_gen.go
+
+//go:embed catalog/*.json
+var catalogFiles embed.FS
+
+// CatalogFS returns the audit message catalog compiled into the binary
+// File names are the same as in pkg/audit/catalog (e.g. "messagecatelog.json")
+func CatalogFS() fs.FS {
+	catalog, err := fs.Sub(catalogFiles, "catalog")
+	if err != nil {
+		// The directory is embedded at build time, so this cannot fail
+		panic(err)
+	}
+	return catalog
+}
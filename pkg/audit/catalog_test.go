@@ -0,0 +1,19 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"tushartemplategin/pkg/catalogcodegen"
+)
+
+// TestGeneratedCodesAreCurrent fails when the catalog changed without go generate
+func TestGeneratedCodesAreCurrent(t *testing.T) {
+	options := catalogcodegen.Options{
+		Package:       "audit",
+		Catalog:       "audit",
+		StructureFile: "messagecatelog.json",
+		LanguageFile:  "messagecatelog-en-US.json",
+	}
+	assert.NoError(t, catalogcodegen.Check(CatalogFS(), options, "codes_gen.go"))
+}
@@ -0,0 +1,127 @@
+// Code generated by go run ./cmd/catalog gen; DO NOT EDIT.
+// Source: audit catalog (messagecatelog.json, messagecatelog-en-US.json)
+
+package audit
+
+// Message codes of the audit catalog
+const (
+	// CodeAUD0001 is "User access event logged" (UserAccess, HIGH)
+	CodeAUD0001 = "AUD0001"
+	// CodeAUD0002 is "Data modification detected" (DataModification, CRITICAL)
+	CodeAUD0002 = "AUD0002"
+	// CodeAUD0003 is "System event occurred" (SystemEvent, MEDIUM)
+	CodeAUD0003 = "AUD0003"
+	// CodeAUD0004 is "Security event detected" (SecurityEvent, CRITICAL)
+	CodeAUD0004 = "AUD0004"
+	// CodeAUD0005 is "Configuration change logged" (ConfigurationChange, HIGH)
+	CodeAUD0005 = "AUD0005"
+)
+
+// CatalogCodes returns every message code of the audit catalog, sorted
+func CatalogCodes() []string {
+	return []string{
+		CodeAUD0001,
+		CodeAUD0002,
+		CodeAUD0003,
+		CodeAUD0004,
+		CodeAUD0005,
+	}
+}
+
+// AUD0001Params are the parameters of CodeAUD0001
+type AUD0001Params struct {
+	Action          string `json:"action"`
+	IPAddress       string `json:"ip_address"`
+	SessionDuration string `json:"session_duration"`
+	Timestamp       string `json:"timestamp"`
+	Username        string `json:"username"`
+}
+
+// Parameters returns p as a message catalog parameter map
+func (p AUD0001Params) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"action":           p.Action,
+		"ip_address":       p.IPAddress,
+		"session_duration": p.SessionDuration,
+		"timestamp":        p.Timestamp,
+		"username":         p.Username,
+	}
+}
+
+// AUD0002Params are the parameters of CodeAUD0002
+type AUD0002Params struct {
+	OperationType string `json:"operation_type"`
+	RecordCount   string `json:"record_count"`
+	TableName     string `json:"table_name"`
+	Timestamp     string `json:"timestamp"`
+	Username      string `json:"username"`
+}
+
+// Parameters returns p as a message catalog parameter map
+func (p AUD0002Params) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"operation_type": p.OperationType,
+		"record_count":   p.RecordCount,
+		"table_name":     p.TableName,
+		"timestamp":      p.Timestamp,
+		"username":       p.Username,
+	}
+}
+
+// AUD0003Params are the parameters of CodeAUD0003
+type AUD0003Params struct {
+	ComponentName string `json:"component_name"`
+	EventStatus   string `json:"event_status"`
+	EventType     string `json:"event_type"`
+	Timestamp     string `json:"timestamp"`
+}
+
+// Parameters returns p as a message catalog parameter map
+func (p AUD0003Params) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"component_name": p.ComponentName,
+		"event_status":   p.EventStatus,
+		"event_type":     p.EventType,
+		"timestamp":      p.Timestamp,
+	}
+}
+
+// AUD0004Params are the parameters of CodeAUD0004
+type AUD0004Params struct {
+	RiskLevel         string `json:"risk_level"`
+	SecurityEventType string `json:"security_event_type"`
+	SourceIP          string `json:"source_ip"`
+	TargetResource    string `json:"target_resource"`
+	Timestamp         string `json:"timestamp"`
+}
+
+// Parameters returns p as a message catalog parameter map
+func (p AUD0004Params) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"risk_level":          p.RiskLevel,
+		"security_event_type": p.SecurityEventType,
+		"source_ip":           p.SourceIP,
+		"target_resource":     p.TargetResource,
+		"timestamp":           p.Timestamp,
+	}
+}
+
+// AUD0005Params are the parameters of CodeAUD0005
+type AUD0005Params struct {
+	ConfigSection string `json:"config_section"`
+	NewValue      string `json:"new_value"`
+	OldValue      string `json:"old_value"`
+	Timestamp     string `json:"timestamp"`
+	Username      string `json:"username"`
+}
+
+// Parameters returns p as a message catalog parameter map
+func (p AUD0005Params) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"config_section": p.ConfigSection,
+		"new_value":      p.NewValue,
+		"old_value":      p.OldValue,
+		"timestamp":      p.Timestamp,
+		"username":       p.Username,
+	}
+}
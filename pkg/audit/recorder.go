@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"tushartemplategin/pkg/interfaces"
+)
+
+// Recorder persists and reads back audit events
+type Recorder interface {
+	// Record writes the event using the caller's transaction so the audit
+	// entry commits or rolls back together with the audited change
+	Record(ctx context.Context, tx *sql.Tx, event *Event) error
+
+	// ListByEntity returns the most recent events for an entity, newest first
+	ListByEntity(ctx context.Context, entityType, entityID string, limit int) ([]*Event, error)
+}
+
+// SQLRecorder implements Recorder on top of the audit_events table
+type SQLRecorder struct {
+	db     interfaces.Database
+	logger interfaces.Logger
+}
+
+// NewRecorder creates a new SQL-backed audit recorder
+func NewRecorder(db interfaces.Database, log interfaces.Logger) Recorder {
+	return &SQLRecorder{
+		db:     db,
+		logger: log,
+	}
+}
+
+// Record inserts an audit event inside the given transaction
+func (r *SQLRecorder) Record(ctx context.Context, tx *sql.Tx, event *Event) error {
+	query := `
+		INSERT INTO audit_events (entity_type, entity_id, action, actor, correlation_id, changes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+
+	if err := tx.QueryRowContext(ctx, query,
+		event.EntityType, event.EntityID, string(event.Action), event.Actor,
+		event.CorrelationID, changes, event.CreatedAt,
+	).Scan(&event.ID); err != nil {
+		r.logger.Error(ctx, "Failed to record audit event", interfaces.Fields{
+			"error":       err.Error(),
+			"entity_type": event.EntityType,
+			"entity_id":   event.EntityID,
+			"action":      event.Action,
+		})
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+
+	return nil
+}
+
+// ListByEntity retrieves audit events for an entity, newest first
+func (r *SQLRecorder) ListByEntity(ctx context.Context, entityType, entityID string, limit int) ([]*Event, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := `
+		SELECT id, entity_type, entity_id, action, actor, correlation_id, changes, created_at
+		FROM audit_events
+		WHERE entity_type = $1 AND entity_id = $2
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`
+
+	events := make([]*Event, 0)
+	if err := r.db.WithTransaction(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, query, entityType, entityID, limit)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			event := &Event{}
+			var action string
+			var changes []byte
+			if err := rows.Scan(
+				&event.ID, &event.EntityType, &event.EntityID, &action,
+				&event.Actor, &event.CorrelationID, &changes, &event.CreatedAt,
+			); err != nil {
+				return err
+			}
+			event.Action = Action(action)
+			if err := json.Unmarshal(changes, &event.Changes); err != nil {
+				return fmt.Errorf("failed to decode audit changes: %w", err)
+			}
+			events = append(events, event)
+		}
+		return rows.Err()
+	}); err != nil {
+		r.logger.Error(ctx, "Failed to list audit events", interfaces.Fields{
+			"error":       err.Error(),
+			"entity_type": entityType,
+			"entity_id":   entityID,
+		})
+		return nil, fmt.Errorf("failed to list audit events: %w", err)
+	}
+
+	return events, nil
+}
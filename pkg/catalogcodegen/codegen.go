@@ -0,0 +1,286 @@
+// Package catalogcodegen generates Go code for message catalog codes
+//
+// For every code in a catalog structure file it emits a constant and, when the
+// default language texts have placeholders, a parameter struct whose fields are
+// typed from the ICU MessageFormat arguments:
+//
+//	{name}, {g, select, ...}              string
+//	{n, number}, {n, plural, ...}         float64
+//	{d, date}, {d, time}                  time.Time
+//
+// Packages run the generator with go:generate (see cmd/catalog) and call Check
+// from a test so stale generated code fails the build.
+package catalogcodegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"tushartemplategin/pkg/messageformat"
+)
+
+// Options describes one catalog and the package generated for it
+type Options struct {
+	Package       string // Go package name of the generated file
+	Catalog       string // Catalog name, used in comments
+	StructureFile string // Structure file name, e.g. "messagecatelog.json"
+	LanguageFile  string // Default language file name, e.g. "messagecatelog-en-US.json"
+}
+
+// GenerateCommand is how the generated file says it was produced
+const GenerateCommand = "go run ./cmd/catalog gen"
+
+// initialisms are kept upper case in generated identifiers (ip_address -> IPAddress)
+var initialisms = map[string]bool{
+	"api": true, "http": true, "id": true, "ip": true, "json": true,
+	"sku": true, "sql": true, "url": true, "uuid": true,
+}
+
+// textFields are the language file fields scanned for placeholders
+var textFields = []string{"message", "detailed_description", "response_action"}
+
+// code is the template data of one message code
+type code struct {
+	Code     string
+	Const    string
+	Params   string
+	Summary  string
+	Category string
+	Severity string
+	Fields   []field
+}
+
+// field is one parameter struct field
+type field struct {
+	Name string
+	Type string
+	Key  string
+}
+
+// Generate returns the formatted Go source for a catalog read from fsys
+func Generate(fsys fs.FS, options Options) ([]byte, error) {
+	structure, err := readJSON(fsys, options.StructureFile)
+	if err != nil {
+		return nil, err
+	}
+	texts, err := readJSON(fsys, options.LanguageFile)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]code, 0, len(structure))
+	usesTime := false
+	for _, messageCode := range sortedKeys(structure) {
+		entry, _ := structure[messageCode].(map[string]interface{})
+		text, _ := texts[messageCode].(map[string]interface{})
+
+		fields, err := parameterFields(messageCode, text)
+		if err != nil {
+			return nil, err
+		}
+
+		identifier := identifierFor(messageCode)
+		c := code{
+			Code:     messageCode,
+			Const:    "Code" + identifier,
+			Summary:  oneLine(stringField(text, "message")),
+			Category: stringField(entry, "category"),
+			Severity: stringField(entry, "severity"),
+			Fields:   fields,
+		}
+		if len(fields) > 0 {
+			c.Params = identifier + "Params"
+		}
+		for _, f := range fields {
+			usesTime = usesTime || f.Type == "time.Time"
+		}
+		codes = append(codes, c)
+	}
+
+	var source bytes.Buffer
+	if err := fileTemplate.Execute(&source, map[string]interface{}{
+		"Command":  GenerateCommand,
+		"Options":  options,
+		"Codes":    codes,
+		"UsesTime": usesTime,
+	}); err != nil {
+		return nil, fmt.Errorf("catalogcodegen: %w", err)
+	}
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("catalogcodegen: generated invalid Go for catalog %s: %w", options.Catalog, err)
+	}
+	return formatted, nil
+}
+
+// Check reports whether the file at path matches what Generate produces now
+func Check(fsys fs.FS, options Options, path string) error {
+	expected, err := Generate(fsys, options)
+	if err != nil {
+		return err
+	}
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("catalogcodegen: %w", err)
+	}
+	if !bytes.Equal(current, expected) {
+		return fmt.Errorf("catalogcodegen: %s is stale for catalog %s; run go generate", path, options.Catalog)
+	}
+	return nil
+}
+
+// parameterFields derives the parameter struct fields of one code
+func parameterFields(messageCode string, text map[string]interface{}) ([]field, error) {
+	types := make(map[string]string)
+	for _, name := range textFields {
+		content := stringField(text, name)
+		if content == "" {
+			continue
+		}
+		message, err := messageformat.Parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("catalogcodegen: %s %s: %w", messageCode, name, err)
+		}
+		for _, argument := range message.Arguments() {
+			if types[argument.Name] == "" {
+				types[argument.Name] = argument.Type
+			}
+		}
+	}
+
+	fields := make([]field, 0, len(types))
+	for _, key := range sortedStringKeys(types) {
+		fields = append(fields, field{Name: identifierFor(key), Type: goType(types[key]), Key: key})
+	}
+	return fields, nil
+}
+
+// goType maps an ICU argument type to the Go type of its parameter
+func goType(argumentType string) string {
+	switch argumentType {
+	case messageformat.TypeNumber, messageformat.TypePlural, messageformat.TypeSelectOrdinal:
+		return "float64"
+	case messageformat.TypeDate, messageformat.TypeTime:
+		return "time.Time"
+	default:
+		return "string"
+	}
+}
+
+// identifierFor turns a code or parameter name into an exported Go identifier
+// Single-word codes keep their spelling ("ABC0001"); snake case is camel-cased
+// ("exp_date" -> "ExpDate", "INTERNAL_SERVER_ERROR" -> "InternalServerError")
+func identifierFor(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var identifier strings.Builder
+	for _, part := range parts {
+		lower := strings.ToLower(part)
+		switch {
+		case initialisms[lower]:
+			identifier.WriteString(strings.ToUpper(part))
+		case len(parts) == 1 && part == strings.ToUpper(part):
+			identifier.WriteString(part)
+		default:
+			identifier.WriteString(strings.ToUpper(lower[:1]) + lower[1:])
+		}
+	}
+
+	result := identifier.String()
+	if result == "" || !unicode.IsLetter(rune(result[0])) {
+		result = "X" + result
+	}
+	return result
+}
+
+func readJSON(fsys fs.FS, name string) (map[string]interface{}, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("catalogcodegen: %w", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("catalogcodegen: %s: %w", name, err)
+	}
+	return result, nil
+}
+
+func stringField(data map[string]interface{}, key string) string {
+	value, _ := data[key].(string)
+	return value
+}
+
+// oneLine keeps comment text on a single line
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func sortedKeys(data map[string]interface{}) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedStringKeys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var fileTemplate = template.Must(template.New("codes").Parse(`// Code generated by {{.Command}}; DO NOT EDIT.
+// Source: {{.Options.Catalog}} catalog ({{.Options.StructureFile}}, {{.Options.LanguageFile}})
+
+package {{.Options.Package}}
+{{if .UsesTime}}
+import "time"
+{{end}}
+// Message codes of the {{.Options.Catalog}} catalog
+const (
+{{- range .Codes}}
+	// {{.Const}} is {{if .Summary}}"{{.Summary}}" {{end}}({{.Category}}, {{.Severity}})
+	{{.Const}} = "{{.Code}}"
+{{- end}}
+)
+
+// CatalogCodes returns every message code of the {{.Options.Catalog}} catalog, sorted
+func CatalogCodes() []string {
+	return []string{
+{{- range .Codes}}
+		{{.Const}},
+{{- end}}
+	}
+}
+{{range .Codes}}{{if .Params}}
+// {{.Params}} are the parameters of {{.Const}}
+type {{.Params}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`" + `json:"{{.Key}}"` + "`" + `
+{{- end}}
+}
+
+// Parameters returns p as a message catalog parameter map
+func (p {{.Params}}) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+{{- range .Fields}}
+		"{{.Key}}": p.{{.Name}},
+{{- end}}
+	}
+}
+{{end}}{{end}}`))
@@ -0,0 +1,88 @@
+package catalogcodegen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOptions = Options{
+	Package:       "sample",
+	Catalog:       "sample",
+	StructureFile: "messagecatelog.json",
+	LanguageFile:  "messagecatelog-en-US.json",
+}
+
+func testCatalog() fstest.MapFS {
+	return fstest.MapFS{
+		"messagecatelog.json": {Data: []byte(`{
+			"TST0001": {"message_code": "TST0001", "category": "Registration", "severity": "HIGH", "component": "Core"},
+			"TST0002": {"message_code": "TST0002", "category": "System", "severity": "LOW", "component": "Core"}
+		}`)},
+		"messagecatelog-en-US.json": {Data: []byte(`{
+			"TST0001": {
+				"message": "{count, plural, one {# device} other {# devices}} of {user_id} expire",
+				"detailed_description": "Expires on {exp_date, date, medium}",
+				"response_action": "Contact {owner}"
+			},
+			"TST0002": {"message": "Done"}
+		}`)},
+	}
+}
+
+func TestIdentifierFor(t *testing.T) {
+	tests := map[string]string{
+		"ABC0001":               "ABC0001",
+		"exp_date":              "ExpDate",
+		"user_id":               "UserID",
+		"ip_address":            "IPAddress",
+		"INTERNAL_SERVER_ERROR": "InternalServerError",
+		"count":                 "Count",
+		"1st":                   "X1st",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, identifierFor(name), name)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	source, err := Generate(testCatalog(), testOptions)
+	require.NoError(t, err)
+
+	code := string(source)
+	assert.Contains(t, code, "// Code generated by go run ./cmd/catalog gen; DO NOT EDIT.")
+	assert.Contains(t, code, "package sample")
+	assert.Contains(t, code, `import "time"`)
+	assert.Contains(t, code, `CodeTST0001 = "TST0001"`)
+	assert.Contains(t, code, `// CodeTST0002 is "Done" (System, LOW)`)
+	assert.Contains(t, code, "type TST0001Params struct")
+	assert.Contains(t, code, "Count   float64   `json:\"count\"`")
+	assert.Contains(t, code, "ExpDate time.Time `json:\"exp_date\"`")
+	assert.Contains(t, code, "UserID  string    `json:\"user_id\"`")
+	assert.NotContains(t, code, "TST0002Params")
+}
+
+func TestGenerate_InvalidPattern(t *testing.T) {
+	catalog := testCatalog()
+	catalog["messagecatelog-en-US.json"] = &fstest.MapFile{Data: []byte(`{"TST0001": {"message": "Broken {user"}}`)}
+
+	_, err := Generate(catalog, testOptions)
+	assert.ErrorContains(t, err, "TST0001 message")
+}
+
+func TestCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "codes_gen.go")
+	source, err := Generate(testCatalog(), testOptions)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, source, 0o644))
+
+	assert.NoError(t, Check(testCatalog(), testOptions, path))
+
+	catalog := testCatalog()
+	catalog["messagecatelog-en-US.json"] = &fstest.MapFile{Data: []byte(`{"TST0001": {"message": "Hello {name}"}}`)}
+	assert.ErrorContains(t, Check(catalog, testOptions, path), "is stale")
+}
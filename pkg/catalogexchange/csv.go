@@ -0,0 +1,106 @@
+package catalogexchange
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// CSV status column values
+const (
+	StatusTranslated   = "translated"
+	StatusUntranslated = "untranslated"
+	StatusFuzzy        = "fuzzy"
+)
+
+// csvColumns is the CSV header; languages are named in the source and target
+// column headers ("source:en-US", "target:fr-FR") and the catalog in "id:alert"
+var csvColumns = []string{"id", "context", "source", "target", "status"}
+
+func encodeCSV(w io.Writer, document *Document) error {
+	writer := csv.NewWriter(w)
+	header := []string{
+		"id:" + document.Catalog,
+		"context",
+		"source:" + document.SourceLanguage,
+		"target:" + document.TargetLanguage,
+		"status",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, unit := range document.Units {
+		status := StatusTranslated
+		switch {
+		case unit.Target == "":
+			status = StatusUntranslated
+		case unit.Fuzzy:
+			status = StatusFuzzy
+		}
+		if err := writer.Write([]string{unit.ID(), unit.Context, unit.Source, unit.Target, status}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func decodeCSV(r io.Reader) (*Document, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvColumns)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("catalogexchange: invalid CSV header: %w", err)
+	}
+	document := &Document{}
+	for i, column := range header {
+		name, value := splitColumn(column)
+		if name != csvColumns[i] {
+			return nil, fmt.Errorf("catalogexchange: CSV column %d is %q, want %q", i+1, name, csvColumns[i])
+		}
+		switch name {
+		case "id":
+			document.Catalog = value
+		case "source":
+			document.SourceLanguage = value
+		case "target":
+			document.TargetLanguage = value
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return document, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("catalogexchange: invalid CSV: %w", err)
+		}
+		code, field, ok := ParseID(record[0])
+		if !ok {
+			return nil, fmt.Errorf("catalogexchange: CSV id %q is not <code>.<field>", record[0])
+		}
+		status := record[4]
+		if status != "" && !slices.Contains([]string{StatusTranslated, StatusUntranslated, StatusFuzzy}, status) {
+			return nil, fmt.Errorf("catalogexchange: unit %s has unknown status %q", record[0], status)
+		}
+		document.Units = append(document.Units, Unit{
+			Code:    code,
+			Field:   field,
+			Context: record[1],
+			Source:  record[2],
+			Target:  record[3],
+			Fuzzy:   status == StatusFuzzy && record[3] != "",
+		})
+	}
+}
+
+// splitColumn splits "target:fr-FR" into its name and value
+func splitColumn(column string) (string, string) {
+	name, value, _ := strings.Cut(column, ":")
+	return name, value
+}
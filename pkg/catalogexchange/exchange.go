@@ -0,0 +1,124 @@
+// Package catalogexchange converts message catalog translations to and from the
+// formats translation vendors work in: XLIFF 2.0, gettext PO and CSV
+//
+// A Document holds one catalog in one source and one target language. Each
+// translatable field of a message is a Unit identified by "<code>.<field>"
+// (e.g. "ABC0001.message"). Texts are ICU MessageFormat patterns; XLIFF marks
+// simple placeholders such as {user} or {count, number} as protected inline
+// codes, PO and CSV carry them as plain text.
+package catalogexchange
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Supported formats
+const (
+	FormatXLIFF = "xliff"
+	FormatPO    = "po"
+	FormatCSV   = "csv"
+)
+
+// Formats lists the supported formats
+var Formats = []string{FormatXLIFF, FormatPO, FormatCSV}
+
+// Unit is one translatable text
+type Unit struct {
+	Code    string // Message code
+	Field   string // "message", "detailed_description" or "response_action"
+	Context string // Shown to translators, e.g. "Registration, CRITICAL"
+	Source  string // Text in the source language
+	Target  string // Text in the target language; empty when untranslated
+	Fuzzy   bool   // The translation needs review
+}
+
+// ID returns the unit identifier used in every format
+func (u Unit) ID() string {
+	return u.Code + "." + u.Field
+}
+
+// ParseID splits a unit identifier into message code and field
+func ParseID(id string) (code, field string, ok bool) {
+	code, field, ok = strings.Cut(id, ".")
+	return code, field, ok && code != "" && field != ""
+}
+
+// Document is one catalog in a source and a target language
+type Document struct {
+	Catalog        string
+	SourceLanguage string
+	TargetLanguage string
+	Units          []Unit
+}
+
+// Encode writes a document in the given format
+func Encode(w io.Writer, format string, document *Document) error {
+	switch format {
+	case FormatXLIFF:
+		return encodeXLIFF(w, document)
+	case FormatPO:
+		return encodePO(w, document)
+	case FormatCSV:
+		return encodeCSV(w, document)
+	default:
+		return unknownFormat(format)
+	}
+}
+
+// Decode reads a document in the given format
+// Languages and the catalog name are taken from the file when it has them
+func Decode(r io.Reader, format string) (*Document, error) {
+	switch format {
+	case FormatXLIFF:
+		return decodeXLIFF(r)
+	case FormatPO:
+		return decodePO(r)
+	case FormatCSV:
+		return decodeCSV(r)
+	default:
+		return nil, unknownFormat(format)
+	}
+}
+
+// FormatFromFileName returns the format of a file by its extension (.xlf, .xliff, .po, .csv)
+func FormatFromFileName(name string) (string, bool) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".xlf", ".xliff":
+		return FormatXLIFF, true
+	case ".po":
+		return FormatPO, true
+	case ".csv":
+		return FormatCSV, true
+	default:
+		return "", false
+	}
+}
+
+// ContentType returns the MIME type of a format
+func ContentType(format string) string {
+	switch format {
+	case FormatXLIFF:
+		return "application/xliff+xml"
+	case FormatPO:
+		return "text/x-gettext-translation; charset=utf-8"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// FileExtension returns the usual file extension of a format
+func FileExtension(format string) string {
+	if format == FormatXLIFF {
+		return ".xlf"
+	}
+	return "." + format
+}
+
+func unknownFormat(format string) error {
+	return fmt.Errorf("catalogexchange: unknown format %q (want one of %s)", format, strings.Join(Formats, ", "))
+}
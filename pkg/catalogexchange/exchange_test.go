@@ -0,0 +1,101 @@
+package catalogexchange
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDocument() *Document {
+	return &Document{
+		Catalog:        "alert",
+		SourceLanguage: "en-US",
+		TargetLanguage: "fr-FR",
+		Units: []Unit{
+			{Code: "ABC0001", Field: "message", Context: "Registration, CRITICAL", Source: "Expires on {exp_date, date, medium}", Target: "Expire le {exp_date, date, medium}"},
+			{Code: "ABC0001", Field: "response_action", Source: "Renew \"now\" & retry\nor call {phone}", Target: "Renouveler", Fuzzy: true},
+			{Code: "ABC0002", Field: "message", Source: "{count, plural, one {# attempt} other {# attempts}} by {user}"},
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range Formats {
+		t.Run(format, func(t *testing.T) {
+			var encoded bytes.Buffer
+			require.NoError(t, Encode(&encoded, format, testDocument()))
+
+			decoded, err := Decode(&encoded, format)
+			require.NoError(t, err)
+			assert.Equal(t, testDocument(), decoded)
+		})
+	}
+}
+
+func TestEncodeXLIFF_ProtectsPlaceholders(t *testing.T) {
+	var encoded bytes.Buffer
+	require.NoError(t, Encode(&encoded, FormatXLIFF, testDocument()))
+
+	xliff := encoded.String()
+	assert.Contains(t, xliff, `<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="en-US" trgLang="fr-FR">`)
+	assert.Contains(t, xliff, `<data id="d1">{exp_date, date, medium}</data>`)
+	assert.Contains(t, xliff, `<source>Expires on <ph id="ph1" dataRef="d1"/></source>`)
+	assert.Contains(t, xliff, `<segment state="initial">`)
+	assert.Contains(t, xliff, `by <ph id="ph1" dataRef="d1"/></source>`)
+	assert.Contains(t, xliff, `{# attempt}`)
+}
+
+func TestDecodePO_ReadsVendorFiles(t *testing.T) {
+	po := `# Translator comment
+msgid ""
+msgstr ""
+"Language: de-DE\n"
+"X-Catalog: audit\n"
+
+#. Security, HIGH
+#, fuzzy, ics-format
+msgctxt "AUD0001.message"
+msgid ""
+"User {username} "
+"logged in"
+msgstr "Benutzer {username} hat sich angemeldet"
+
+msgctxt "AUD0002.message"
+msgid "Logout"
+msgstr ""
+`
+	document, err := Decode(strings.NewReader(po), FormatPO)
+	require.NoError(t, err)
+	assert.Equal(t, "audit", document.Catalog)
+	assert.Equal(t, "de-DE", document.TargetLanguage)
+	assert.Equal(t, []Unit{
+		{Code: "AUD0001", Field: "message", Context: "Security, HIGH", Source: "User {username} logged in", Target: "Benutzer {username} hat sich angemeldet", Fuzzy: true},
+		{Code: "AUD0002", Field: "message", Source: "Logout"},
+	}, document.Units)
+
+	_, err = Decode(strings.NewReader("msgid \"Hello\"\nmsgstr \"Bonjour\"\n"), FormatPO)
+	assert.ErrorContains(t, err, "is not <code>.<field>")
+}
+
+func TestDecode_RejectsInvalidInput(t *testing.T) {
+	_, err := Decode(strings.NewReader("id,source\n"), FormatCSV)
+	assert.Error(t, err)
+
+	_, err = Decode(strings.NewReader(`<xliff version="1.2"></xliff>`), FormatXLIFF)
+	assert.ErrorContains(t, err, "unsupported XLIFF version")
+
+	_, err = Decode(strings.NewReader(""), "docx")
+	assert.ErrorContains(t, err, "unknown format")
+}
+
+func TestFormatFromFileName(t *testing.T) {
+	format, ok := FormatFromFileName("alert-fr-FR.XLF")
+	assert.True(t, ok)
+	assert.Equal(t, FormatXLIFF, format)
+
+	_, ok = FormatFromFileName("alert.json")
+	assert.False(t, ok)
+}
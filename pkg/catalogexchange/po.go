@@ -0,0 +1,202 @@
+package catalogexchange
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PO header fields naming the catalog and languages
+const (
+	poHeaderLanguage       = "Language"
+	poHeaderSourceLanguage = "X-Source-Language"
+	poHeaderCatalog        = "X-Catalog"
+)
+
+// encodePO writes one entry per unit: the unit ID as msgctxt, the context as
+// an extracted comment ("#.") and "#, fuzzy" for fuzzy translations
+func encodePO(w io.Writer, document *Document) error {
+	out := bufio.NewWriter(w)
+
+	header := fmt.Sprintf("%s: %s\nContent-Type: text/plain; charset=UTF-8\n%s: %s\n%s: %s\n",
+		poHeaderLanguage, document.TargetLanguage,
+		poHeaderSourceLanguage, document.SourceLanguage,
+		poHeaderCatalog, document.Catalog)
+	fmt.Fprintf(out, "msgid \"\"\nmsgstr %s\n", poQuote(header))
+
+	for _, unit := range document.Units {
+		out.WriteString("\n")
+		if unit.Context != "" {
+			fmt.Fprintf(out, "#. %s\n", unit.Context)
+		}
+		if unit.Fuzzy && unit.Target != "" {
+			out.WriteString("#, fuzzy\n")
+		}
+		fmt.Fprintf(out, "msgctxt %s\n", poQuote(unit.ID()))
+		fmt.Fprintf(out, "msgid %s\n", poQuote(unit.Source))
+		fmt.Fprintf(out, "msgstr %s\n", poQuote(unit.Target))
+	}
+	return out.Flush()
+}
+
+// poEntry is an entry being parsed
+type poEntry struct {
+	context  string
+	comment  string
+	fuzzy    bool
+	msgctxt  string
+	msgid    string
+	msgstr   string
+	hasMsgid bool
+}
+
+// decodePO reads entries with msgctxt "<code>.<field>"; entries without a
+// context (other than the header) are rejected, plural forms are not supported
+func decodePO(r io.Reader) (*Document, error) {
+	document := &Document{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var entry poEntry
+	var current *string // string being continued by quoted lines
+	lineNumber := 0
+
+	flush := func() error {
+		defer func() { entry = poEntry{}; current = nil }()
+		if !entry.hasMsgid {
+			return nil
+		}
+		if entry.msgid == "" && entry.msgctxt == "" {
+			applyPOHeader(document, entry.msgstr)
+			return nil
+		}
+		code, field, ok := ParseID(entry.msgctxt)
+		if !ok {
+			return fmt.Errorf("catalogexchange: line %d: msgctxt %q is not <code>.<field>", lineNumber, entry.msgctxt)
+		}
+		document.Units = append(document.Units, Unit{
+			Code:    code,
+			Field:   field,
+			Context: entry.comment,
+			Source:  entry.msgid,
+			Target:  entry.msgstr,
+			Fuzzy:   entry.fuzzy && entry.msgstr != "",
+		})
+		return nil
+	}
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "#,"):
+			for _, flag := range strings.Split(line[2:], ",") {
+				if strings.TrimSpace(flag) == "fuzzy" {
+					entry.fuzzy = true
+				}
+			}
+		case strings.HasPrefix(line, "#."):
+			entry.comment = strings.TrimSpace(line[2:])
+		case strings.HasPrefix(line, "#"):
+			// Translator and reference comments are not kept
+		case strings.HasPrefix(line, "\""):
+			if current == nil {
+				return nil, fmt.Errorf("catalogexchange: line %d: string without keyword", lineNumber)
+			}
+			value, err := poUnquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("catalogexchange: line %d: %w", lineNumber, err)
+			}
+			*current += value
+		default:
+			keyword, rest, _ := strings.Cut(line, " ")
+			value, err := poUnquote(strings.TrimSpace(rest))
+			if err != nil {
+				return nil, fmt.Errorf("catalogexchange: line %d: %w", lineNumber, err)
+			}
+			switch keyword {
+			case "msgctxt":
+				if entry.hasMsgid {
+					if err := flush(); err != nil {
+						return nil, err
+					}
+				}
+				entry.msgctxt, current = value, &entry.msgctxt
+			case "msgid":
+				if entry.hasMsgid {
+					if err := flush(); err != nil {
+						return nil, err
+					}
+				}
+				entry.msgid, entry.hasMsgid, current = value, true, &entry.msgid
+			case "msgstr":
+				entry.msgstr, current = value, &entry.msgstr
+			default:
+				return nil, fmt.Errorf("catalogexchange: line %d: unsupported keyword %q", lineNumber, keyword)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("catalogexchange: %w", err)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// applyPOHeader reads the catalog and languages from the header entry
+func applyPOHeader(document *Document, header string) {
+	for _, line := range strings.Split(header, "\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(name) {
+		case poHeaderLanguage:
+			document.TargetLanguage = value
+		case poHeaderSourceLanguage:
+			document.SourceLanguage = value
+		case poHeaderCatalog:
+			document.Catalog = value
+		}
+	}
+}
+
+// poQuote quotes a PO string; multi-line texts are split after each newline
+func poQuote(text string) string {
+	if !strings.Contains(strings.TrimSuffix(text, "\n"), "\n") {
+		return poEscape(text)
+	}
+	var quoted strings.Builder
+	quoted.WriteString(`""`)
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line != "" {
+			quoted.WriteString("\n" + poEscape(line))
+		}
+	}
+	return quoted.String()
+}
+
+func poEscape(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return `"` + replacer.Replace(text) + `"`
+}
+
+func poUnquote(quoted string) (string, error) {
+	if len(quoted) < 2 || quoted[0] != '"' || quoted[len(quoted)-1] != '"' {
+		return "", fmt.Errorf("invalid PO string %s", quoted)
+	}
+	value, err := strconv.Unquote(quoted)
+	if err != nil {
+		return "", fmt.Errorf("invalid PO string %s", quoted)
+	}
+	return value, nil
+}
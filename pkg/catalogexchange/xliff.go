@@ -0,0 +1,219 @@
+package catalogexchange
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// xliffNamespace is the XLIFF 2.0 core namespace
+const xliffNamespace = "urn:oasis:names:tc:xliff:document:2.0"
+
+// XLIFF 2.0 segment states; "initial" with a target is how fuzzy translations are marked
+const (
+	stateInitial    = "initial"
+	stateTranslated = "translated"
+)
+
+// placeholderPattern matches the simple arguments protected as inline codes:
+// {name}, {name, number}, {name, date, short}, ... Plural and select arguments
+// contain translatable text and stay plain.
+var placeholderPattern = regexp.MustCompile(`\{\s*[A-Za-z_][A-Za-z0-9_]*\s*(?:,\s*(?:number|date|time)\s*(?:,[^{}]*)?)?\}`)
+
+type xliffFile struct {
+	XMLName xml.Name      `xml:"xliff"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	Version string        `xml:"version,attr"`
+	SrcLang string        `xml:"srcLang,attr"`
+	TrgLang string        `xml:"trgLang,attr,omitempty"`
+	Files   []xliffSubset `xml:"file"`
+}
+
+type xliffSubset struct {
+	ID    string      `xml:"id,attr"`
+	Units []xliffUnit `xml:"unit"`
+}
+
+type xliffUnit struct {
+	ID           string       `xml:"id,attr"`
+	Notes        []xliffNote  `xml:"notes>note,omitempty"`
+	OriginalData []xliffData  `xml:"originalData>data,omitempty"`
+	Segment      xliffSegment `xml:"segment"`
+}
+
+type xliffNote struct {
+	Category string `xml:"category,attr,omitempty"`
+	Text     string `xml:",chardata"`
+}
+
+type xliffData struct {
+	ID   string `xml:"id,attr"`
+	Text string `xml:",chardata"`
+}
+
+type xliffSegment struct {
+	State  string        `xml:"state,attr,omitempty"`
+	Source xliffContent  `xml:"source"`
+	Target *xliffContent `xml:"target,omitempty"`
+}
+
+// xliffContent is source or target text with <ph/> inline codes
+type xliffContent struct {
+	Inner string `xml:",innerxml"`
+}
+
+func encodeXLIFF(w io.Writer, document *Document) error {
+	file := xliffFile{
+		Xmlns:   xliffNamespace,
+		Version: "2.0",
+		SrcLang: document.SourceLanguage,
+		TrgLang: document.TargetLanguage,
+		Files:   []xliffSubset{{ID: document.Catalog}},
+	}
+	for _, unit := range document.Units {
+		data := newInlineData()
+		xu := xliffUnit{ID: unit.ID()}
+		if unit.Context != "" {
+			xu.Notes = []xliffNote{{Category: "context", Text: unit.Context}}
+		}
+		xu.Segment.Source = xliffContent{Inner: data.protect(unit.Source)}
+		xu.Segment.State = stateInitial
+		if unit.Target != "" {
+			xu.Segment.Target = &xliffContent{Inner: data.protect(unit.Target)}
+			if !unit.Fuzzy {
+				xu.Segment.State = stateTranslated
+			}
+		}
+		xu.OriginalData = data.entries
+		file.Files[0].Units = append(file.Files[0].Units, xu)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(file); err != nil {
+		return fmt.Errorf("catalogexchange: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func decodeXLIFF(r io.Reader) (*Document, error) {
+	var file xliffFile
+	if err := xml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("catalogexchange: invalid XLIFF: %w", err)
+	}
+	if file.Version != "2.0" && file.Version != "2.1" {
+		return nil, fmt.Errorf("catalogexchange: unsupported XLIFF version %q", file.Version)
+	}
+
+	document := &Document{SourceLanguage: file.SrcLang, TargetLanguage: file.TrgLang}
+	for _, subset := range file.Files {
+		if document.Catalog == "" {
+			document.Catalog = subset.ID
+		}
+		for _, xu := range subset.Units {
+			code, field, ok := ParseID(xu.ID)
+			if !ok {
+				return nil, fmt.Errorf("catalogexchange: unit id %q is not <code>.<field>", xu.ID)
+			}
+			data := make(map[string]string, len(xu.OriginalData))
+			for _, entry := range xu.OriginalData {
+				data[entry.ID] = entry.Text
+			}
+
+			unit := Unit{Code: code, Field: field}
+			for _, note := range xu.Notes {
+				if note.Category == "context" {
+					unit.Context = note.Text
+				}
+			}
+			var err error
+			if unit.Source, err = restoreInline(xu.Segment.Source.Inner, data); err != nil {
+				return nil, fmt.Errorf("catalogexchange: unit %s source: %w", xu.ID, err)
+			}
+			if xu.Segment.Target != nil {
+				if unit.Target, err = restoreInline(xu.Segment.Target.Inner, data); err != nil {
+					return nil, fmt.Errorf("catalogexchange: unit %s target: %w", xu.ID, err)
+				}
+			}
+			unit.Fuzzy = unit.Target != "" && (xu.Segment.State == stateInitial)
+			document.Units = append(document.Units, unit)
+		}
+	}
+	return document, nil
+}
+
+// inlineData collects the original text of the placeholders of one unit
+type inlineData struct {
+	entries []xliffData
+	ids     map[string]string // placeholder text -> data id
+}
+
+func newInlineData() *inlineData {
+	return &inlineData{ids: make(map[string]string)}
+}
+
+// protect escapes text for XML and replaces placeholders with <ph/> codes
+// Every occurrence gets its own ph id; the same placeholder text shares one data entry
+func (d *inlineData) protect(text string) string {
+	var inner strings.Builder
+	last := 0
+	for occurrence, match := range placeholderPattern.FindAllStringIndex(text, -1) {
+		xml.EscapeText(&inner, []byte(text[last:match[0]]))
+		placeholder := text[match[0]:match[1]]
+		id, exists := d.ids[placeholder]
+		if !exists {
+			id = fmt.Sprintf("d%d", len(d.entries)+1)
+			d.ids[placeholder] = id
+			d.entries = append(d.entries, xliffData{ID: id, Text: placeholder})
+		}
+		fmt.Fprintf(&inner, `<ph id="ph%d" dataRef="%s"/>`, occurrence+1, id)
+		last = match[1]
+	}
+	xml.EscapeText(&inner, []byte(text[last:]))
+	return inner.String()
+}
+
+// restoreInline turns source or target content back into text, replacing
+// <ph dataRef="..."/> with the original placeholder
+func restoreInline(inner string, data map[string]string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(inner))
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return text.String(), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		switch element := token.(type) {
+		case xml.CharData:
+			text.Write(element)
+		case xml.StartElement:
+			if element.Name.Local != "ph" {
+				return "", fmt.Errorf("unsupported inline element <%s>", element.Name.Local)
+			}
+			ref := attribute(element, "dataRef")
+			original, exists := data[ref]
+			if !exists {
+				return "", fmt.Errorf("placeholder %q has no original data", ref)
+			}
+			text.WriteString(original)
+		}
+	}
+}
+
+func attribute(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
@@ -12,6 +12,9 @@ type Config struct {
 	Log            LogConfig            `mapstructure:"log"`             // Logging configuration
 	Database       DatabaseConfig       `mapstructure:"database"`        // Database configuration
 	MessageCatalog MessageCatalogConfig `mapstructure:"message_catalog"` // Message catalog configuration
+	Products       ProductsConfig       `mapstructure:"products"`        // Product registration domain configuration
+	Audit          AuditConfig          `mapstructure:"audit"`           // Audit domain configuration
+	Alert          AlertConfig          `mapstructure:"alert"`           // Alert domain configuration
 }
 
 // ServerConfig contains server-specific settings
@@ -21,6 +24,12 @@ type ServerConfig struct {
 
 	// SSL/TLS Configuration
 	SSL SSLConfig `mapstructure:"ssl"` // SSL/TLS configuration
+
+	// HTTP access log configuration
+	AccessLog AccessLogConfig `mapstructure:"accessLog"` // Access log configuration
+
+	// Authentication for administrative endpoints
+	Auth AuthConfig `mapstructure:"auth"` // API key authentication configuration
 }
 
 // SSLConfig contains SSL/TLS configuration settings
@@ -32,6 +41,18 @@ type SSLConfig struct {
 	RedirectHTTP bool   `mapstructure:"redirectHTTP"` // Redirect HTTP to HTTPS
 }
 
+// AccessLogConfig contains HTTP access log settings
+type AccessLogConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`       // Emit one log line per completed request
+	SkipPaths     []string      `mapstructure:"skipPaths"`     // Paths excluded from access logging (e.g. health probes)
+	SlowThreshold time.Duration `mapstructure:"slowThreshold"` // Requests slower than this are logged at Warn level
+}
+
+// AuthConfig contains API key authentication settings for administrative endpoints
+type AuthConfig struct {
+	APIKeys map[string]string `mapstructure:"apiKeys"` // Principal name -> API key (empty disables admin endpoints)
+}
+
 // LogConfig contains logging configuration settings
 type LogConfig struct {
 	Level      string `mapstructure:"level"`      // Log level (debug, info, warn, error, fatal)
@@ -212,7 +233,11 @@ func Load() (*Config, error) {
 	viper.AddConfigPath(".")         // Look in current directory
 
 	// Set production-ready defaults
+	setServerDefaults()
 	setDatabaseDefaults()
+	setProductsDefaults()
+	setAuditDefaults()
+	setAlertDefaults()
 
 	// Read the configuration file
 	if err := viper.ReadInConfig(); err != nil {
@@ -228,6 +253,18 @@ func Load() (*Config, error) {
 	return &config, nil
 }
 
+// setServerDefaults sets production-ready defaults for the HTTP server
+func setServerDefaults() {
+	// Access log defaults
+	viper.SetDefault("server.accessLog.enabled", true)
+	viper.SetDefault("server.accessLog.skipPaths", []string{
+		"/api/v1/health",
+		"/api/v1/health/ready",
+		"/api/v1/health/live",
+	})
+	viper.SetDefault("server.accessLog.slowThreshold", "1s")
+}
+
 // setDatabaseDefaults sets production-ready defaults for all database types
 func setDatabaseDefaults() {
 	// PostgreSQL defaults
@@ -276,16 +313,30 @@ func setDatabaseDefaults() {
 	viper.SetDefault("message_catalog.default_language", "en-US")
 	viper.SetDefault("message_catalog.cache_enabled", true)
 	viper.SetDefault("message_catalog.cache_ttl_seconds", 3600)
+	viper.SetDefault("message_catalog.cache_max_entries", 256)
+	viper.SetDefault("message_catalog.cache_max_bytes", 0)
 	viper.SetDefault("message_catalog.reload_interval_seconds", 300)
+	viper.SetDefault("message_catalog.watch_enabled", false)
+	viper.SetDefault("message_catalog.watch_debounce_ms", 500)
+	viper.SetDefault("message_catalog.strict_parameters", false)
+	viper.SetDefault("message_catalog.validation_mode", "off")
+	viper.SetDefault("message_catalog.render_batch_limit", 100)
 }
 
 // MessageCatalogConfig contains message catalog configuration
 type MessageCatalogConfig struct {
-	DefaultLanguage string          `mapstructure:"default_language"`        // Default language (e.g., "en-US")
-	CacheEnabled    bool            `mapstructure:"cache_enabled"`           // Enable caching
-	CacheTTL        int             `mapstructure:"cache_ttl_seconds"`       // Cache TTL in seconds
-	ReloadInterval  int             `mapstructure:"reload_interval_seconds"` // Reload interval in seconds
-	Catalogs        []CatalogConfig `mapstructure:"catalogs"`                // Catalog configurations
+	DefaultLanguage  string          `mapstructure:"default_language"`        // Default language (e.g., "en-US")
+	CacheEnabled     bool            `mapstructure:"cache_enabled"`           // Enable caching
+	CacheTTL         int             `mapstructure:"cache_ttl_seconds"`       // Cache TTL in seconds
+	CacheMaxEntries  int             `mapstructure:"cache_max_entries"`       // Catalog languages kept in the LRU cache before eviction
+	CacheMaxBytes    int64           `mapstructure:"cache_max_bytes"`         // Memory budget of the LRU cache in bytes (0 is unbounded)
+	ReloadInterval   int             `mapstructure:"reload_interval_seconds"` // Reload interval in seconds
+	WatchEnabled     bool            `mapstructure:"watch_enabled"`           // Reload catalogs when their files change
+	WatchDebounce    int             `mapstructure:"watch_debounce_ms"`       // Quiet period before applying file changes, in milliseconds
+	StrictParameters bool            `mapstructure:"strict_parameters"`       // Reject messages with missing or invalid parameters instead of keeping the placeholder
+	ValidationMode   string          `mapstructure:"validation_mode"`         // Lint catalogs at startup: "off", "warn" (log issues) or "strict" (refuse to start on errors)
+	RenderBatchLimit int             `mapstructure:"render_batch_limit"`      // Most messages rendered in one batch request (0 is unlimited)
+	Catalogs         []CatalogConfig `mapstructure:"catalogs"`                // Catalog configurations
 }
 
 // GetMessageCatalog returns the message catalog configuration
@@ -295,9 +346,90 @@ func (c *Config) GetMessageCatalog() MessageCatalogConfig {
 
 // CatalogConfig represents configuration for a specific catalog
 type CatalogConfig struct {
-	Name                string `mapstructure:"name"`                  // Catalog name (e.g., "alert", "audit")
-	Path                string `mapstructure:"path"`                  // Path to catalog files
-	Enabled             bool   `mapstructure:"enabled"`               // Whether catalog is enabled
-	StructureFile       string `mapstructure:"structure_file"`        // Structure file name (e.g., "messagecatelog.json")
-	LanguageFilePattern string `mapstructure:"language_file_pattern"` // Language file pattern (e.g., "messagecatelog-{lang}.json")
+	Name                string   `mapstructure:"name"`                  // Catalog name (e.g., "alert", "audit")
+	Path                string   `mapstructure:"path"`                  // Path to catalog files
+	Enabled             bool     `mapstructure:"enabled"`               // Whether catalog is enabled
+	StructureFile       string   `mapstructure:"structure_file"`        // Structure file name (e.g., "messagecatelog.json")
+	LanguageFilePattern string   `mapstructure:"language_file_pattern"` // Language file pattern (e.g., "messagecatelog-{lang}.json")
+	Source              string   `mapstructure:"source"`                // Where the catalog is loaded from: "filesystem" (default), "embed" or "database"
+	PreloadLanguages    []string `mapstructure:"preload_languages"`     // Languages loaded at startup besides the default; "*" loads every available language
+}
+
+// ProductsConfig contains product registration domain configuration
+type ProductsConfig struct {
+	DeletedRetentionDays int           `mapstructure:"deleted_retention_days"` // Days a soft-deleted product can be restored before it is purged (0 keeps forever)
+	PurgeInterval        time.Duration `mapstructure:"purge_interval"`         // How often expired deleted products are purged
+	RequireIfMatch       bool          `mapstructure:"require_if_match"`       // Reject updates and deletes without an If-Match header (428)
+
+	// Stock reservations
+	ReservationTTL           time.Duration `mapstructure:"reservation_ttl"`            // How long a reservation holds stock when the request gives no TTL
+	MaxReservationTTL        time.Duration `mapstructure:"max_reservation_ttl"`        // Longest TTL a reservation may ask for
+	ReservationSweepInterval time.Duration `mapstructure:"reservation_sweep_interval"` // How often expired reservations return their stock
+}
+
+// setProductsDefaults sets production-ready defaults for the product registration domain
+func setProductsDefaults() {
+	viper.SetDefault("products.deleted_retention_days", 30)
+	viper.SetDefault("products.purge_interval", "24h")
+	viper.SetDefault("products.require_if_match", false)
+	viper.SetDefault("products.reservation_ttl", "15m")
+	viper.SetDefault("products.max_reservation_ttl", "24h")
+	viper.SetDefault("products.reservation_sweep_interval", "1m")
+}
+
+// AuditConfig contains audit domain configuration
+type AuditConfig struct {
+	RetentionDays int           `mapstructure:"retention_days"` // Days to keep audit events (0 keeps forever)
+	PurgeInterval time.Duration `mapstructure:"purge_interval"` // How often expired events are purged
+}
+
+// setAuditDefaults sets production-ready defaults for the audit domain
+func setAuditDefaults() {
+	viper.SetDefault("audit.retention_days", 365)
+	viper.SetDefault("audit.purge_interval", "24h")
+}
+
+// AlertConfig contains alert domain configuration
+type AlertConfig struct {
+	DedupWindow time.Duration       `mapstructure:"dedup_window"` // Repeats of an open alert within this window are folded into it
+	Routes      map[string][]string `mapstructure:"routes"`       // Severity -> notifier names (keys are case-insensitive)
+	Webhook     WebhookConfig       `mapstructure:"webhook"`      // Webhook notifier settings
+	SMTP        SMTPConfig          `mapstructure:"smtp"`         // SMTP notifier settings
+}
+
+// WebhookConfig contains settings for the webhook alert notifier
+type WebhookConfig struct {
+	Enabled bool              `mapstructure:"enabled"` // Enable webhook notifications
+	URL     string            `mapstructure:"url"`     // Endpoint receiving the JSON alert payload
+	Timeout time.Duration     `mapstructure:"timeout"` // HTTP request timeout
+	Headers map[string]string `mapstructure:"headers"` // Extra request headers (e.g. authorization)
+}
+
+// SMTPConfig contains settings for the SMTP alert notifier
+type SMTPConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`  // Enable email notifications
+	Host     string        `mapstructure:"host"`     // SMTP server host
+	Port     int           `mapstructure:"port"`     // SMTP server port
+	Username string        `mapstructure:"username"` // SMTP username (empty disables auth)
+	Password string        `mapstructure:"password"` // SMTP password
+	From     string        `mapstructure:"from"`     // Sender address
+	To       []string      `mapstructure:"to"`       // Recipient addresses
+	Timeout  time.Duration `mapstructure:"timeout"`  // Limit for the whole SMTP conversation
+}
+
+// setAlertDefaults sets production-ready defaults for the alert domain
+func setAlertDefaults() {
+	viper.SetDefault("alert.dedup_window", "15m")
+	// Lower-case keys match how viper stores map keys read from config files
+	viper.SetDefault("alert.routes", map[string][]string{
+		"critical": {"log", "webhook", "smtp"},
+		"high":     {"log", "webhook"},
+		"medium":   {"log"},
+		"low":      {"log"},
+	})
+	viper.SetDefault("alert.webhook.enabled", false)
+	viper.SetDefault("alert.webhook.timeout", "5s")
+	viper.SetDefault("alert.smtp.enabled", false)
+	viper.SetDefault("alert.smtp.port", 587)
+	viper.SetDefault("alert.smtp.timeout", "10s")
 }
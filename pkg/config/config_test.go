@@ -524,6 +524,16 @@ func TestSetDatabaseDefaults(t *testing.T) {
 	assert.Equal(t, 3, viper.GetInt("database.mysql.maxRetries"))
 	assert.Equal(t, "1s", viper.GetString("database.mysql.retryDelay"))
 	assert.Equal(t, "30s", viper.GetString("database.mysql.healthCheckInterval"))
+
+	// Test message catalog defaults
+	assert.Equal(t, 3600, viper.GetInt("message_catalog.cache_ttl_seconds"))
+	assert.Equal(t, 256, viper.GetInt("message_catalog.cache_max_entries"))
+	assert.Equal(t, int64(0), viper.GetInt64("message_catalog.cache_max_bytes"))
+	assert.False(t, viper.GetBool("message_catalog.watch_enabled"))
+	assert.Equal(t, 500, viper.GetInt("message_catalog.watch_debounce_ms"))
+	assert.False(t, viper.GetBool("message_catalog.strict_parameters"))
+	assert.Equal(t, "off", viper.GetString("message_catalog.validation_mode"))
+	assert.Equal(t, 100, viper.GetInt("message_catalog.render_batch_limit"))
 }
 
 func TestConfig_Load_WithEnvironmentVariables(t *testing.T) {
@@ -711,3 +721,61 @@ func TestConfig_EdgeCases(t *testing.T) {
 		assert.Equal(t, "localhost", config.Database.Postgres.Host) // Default applied
 	})
 }
+
+func TestSetServerDefaults(t *testing.T) {
+	// Reset viper to ensure clean state
+	viper.Reset()
+
+	// Call the function
+	setServerDefaults()
+
+	// Test access log defaults
+	assert.Equal(t, true, viper.GetBool("server.accessLog.enabled"))
+	assert.Equal(t, []string{"/api/v1/health", "/api/v1/health/ready", "/api/v1/health/live"}, viper.GetStringSlice("server.accessLog.skipPaths"))
+	assert.Equal(t, time.Second, viper.GetDuration("server.accessLog.slowThreshold"))
+}
+
+func TestSetProductsDefaults(t *testing.T) {
+	// Reset viper to ensure clean state
+	viper.Reset()
+
+	// Call the function
+	setProductsDefaults()
+
+	// Test product defaults
+	assert.Equal(t, 30, viper.GetInt("products.deleted_retention_days"))
+	assert.Equal(t, 24*time.Hour, viper.GetDuration("products.purge_interval"))
+	assert.False(t, viper.GetBool("products.require_if_match"))
+	assert.Equal(t, 15*time.Minute, viper.GetDuration("products.reservation_ttl"))
+	assert.Equal(t, 24*time.Hour, viper.GetDuration("products.max_reservation_ttl"))
+	assert.Equal(t, time.Minute, viper.GetDuration("products.reservation_sweep_interval"))
+}
+
+func TestSetAuditDefaults(t *testing.T) {
+	// Reset viper to ensure clean state
+	viper.Reset()
+
+	// Call the function
+	setAuditDefaults()
+
+	// Test audit defaults
+	assert.Equal(t, 365, viper.GetInt("audit.retention_days"))
+	assert.Equal(t, 24*time.Hour, viper.GetDuration("audit.purge_interval"))
+}
+
+func TestSetAlertDefaults(t *testing.T) {
+	// Reset viper to ensure clean state
+	viper.Reset()
+
+	// Call the function
+	setAlertDefaults()
+
+	// Test alert defaults
+	assert.Equal(t, 15*time.Minute, viper.GetDuration("alert.dedup_window"))
+	assert.Equal(t, 5*time.Second, viper.GetDuration("alert.webhook.timeout"))
+	assert.Equal(t, 587, viper.GetInt("alert.smtp.port"))
+	assert.Equal(t, 10*time.Second, viper.GetDuration("alert.smtp.timeout"))
+	assert.False(t, viper.GetBool("alert.webhook.enabled"))
+	assert.False(t, viper.GetBool("alert.smtp.enabled"))
+	assert.Equal(t, []string{"log"}, viper.GetStringMapStringSlice("alert.routes")["low"])
+}
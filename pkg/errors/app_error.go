@@ -11,23 +11,33 @@ type ErrorCode string
 // Predefined error codes
 const (
 	// General errors
-	ErrCodeInternalServer      ErrorCode = "INTERNAL_SERVER_ERROR"
-	ErrCodeBadRequest          ErrorCode = "BAD_REQUEST"
-	ErrCodeUnauthorized        ErrorCode = "UNAUTHORIZED"
-	ErrCodeForbidden           ErrorCode = "FORBIDDEN"
-	ErrCodeNotFound            ErrorCode = "NOT_FOUND"
-	ErrCodeConflict            ErrorCode = "CONFLICT"
-	ErrCodeUnprocessableEntity ErrorCode = "UNPROCESSABLE_ENTITY"
-	ErrCodeTooManyRequests     ErrorCode = "TOO_MANY_REQUESTS"
-	ErrCodeServiceUnavailable  ErrorCode = "SERVICE_UNAVAILABLE"
+	ErrCodeInternalServer       ErrorCode = "INTERNAL_SERVER_ERROR"
+	ErrCodeBadRequest           ErrorCode = "BAD_REQUEST"
+	ErrCodeUnauthorized         ErrorCode = "UNAUTHORIZED"
+	ErrCodeForbidden            ErrorCode = "FORBIDDEN"
+	ErrCodeNotFound             ErrorCode = "NOT_FOUND"
+	ErrCodeConflict             ErrorCode = "CONFLICT"
+	ErrCodeUnprocessableEntity  ErrorCode = "UNPROCESSABLE_ENTITY"
+	ErrCodeTooManyRequests      ErrorCode = "TOO_MANY_REQUESTS"
+	ErrCodeServiceUnavailable   ErrorCode = "SERVICE_UNAVAILABLE"
+	ErrCodePreconditionRequired ErrorCode = "PRECONDITION_REQUIRED"
 
 	// Business logic errors
-	ErrCodeProductNotFound     ErrorCode = "PRODUCT_NOT_FOUND"
-	ErrCodeProductSKUExists    ErrorCode = "PRODUCT_SKU_EXISTS"
-	ErrCodeProductCreateFailed ErrorCode = "PRODUCT_CREATE_FAILED"
-	ErrCodeProductUpdateFailed ErrorCode = "PRODUCT_UPDATE_FAILED"
-	ErrCodeProductDeleteFailed ErrorCode = "PRODUCT_DELETE_FAILED"
-	ErrCodeInvalidStock        ErrorCode = "INVALID_STOCK"
+	ErrCodeProductNotFound         ErrorCode = "PRODUCT_NOT_FOUND"
+	ErrCodeProductSKUExists        ErrorCode = "PRODUCT_SKU_EXISTS"
+	ErrCodeProductCreateFailed     ErrorCode = "PRODUCT_CREATE_FAILED"
+	ErrCodeProductUpdateFailed     ErrorCode = "PRODUCT_UPDATE_FAILED"
+	ErrCodeProductDeleteFailed     ErrorCode = "PRODUCT_DELETE_FAILED"
+	ErrCodeInvalidStock            ErrorCode = "INVALID_STOCK"
+	ErrCodeProductVersionConflict  ErrorCode = "PRODUCT_VERSION_CONFLICT"
+	ErrCodeInsufficientStock       ErrorCode = "INSUFFICIENT_STOCK"
+	ErrCodeReservationNotFound     ErrorCode = "RESERVATION_NOT_FOUND"
+	ErrCodeInvalidReservationState ErrorCode = "INVALID_RESERVATION_STATE"
+	ErrCodeAuditEventNotFound      ErrorCode = "AUDIT_EVENT_NOT_FOUND"
+	ErrCodeUnknownAuditEvent       ErrorCode = "UNKNOWN_AUDIT_EVENT"
+	ErrCodeAlertNotFound           ErrorCode = "ALERT_NOT_FOUND"
+	ErrCodeUnknownAlert            ErrorCode = "UNKNOWN_ALERT"
+	ErrCodeInvalidAlertState       ErrorCode = "INVALID_ALERT_STATE"
 
 	// Database errors
 	ErrCodeDatabaseConnection  ErrorCode = "DATABASE_CONNECTION_ERROR"
@@ -0,0 +1,20 @@
+package errors
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed catalog/*.json
+var catalogFiles embed.FS
+
+// CatalogFS returns the error message catalog compiled into the binary
+// Messages are keyed by ErrorCode; add an entry for every new code
+func CatalogFS() fs.FS {
+	catalog, err := fs.Sub(catalogFiles, "catalog")
+	if err != nil {
+		// The directory is embedded at build time, so this cannot fail
+		panic(err)
+	}
+	return catalog
+}
@@ -11,6 +11,9 @@ type Logger interface {
 	Warn(ctx context.Context, msg string, fields Fields)
 	Error(ctx context.Context, msg string, fields Fields)
 	Fatal(ctx context.Context, msg string, err error, fields Fields)
+
+	// With returns a child logger that adds the given fields to every entry
+	With(fields Fields) Logger
 }
 
 // Fields represents key-value pairs for structured logging
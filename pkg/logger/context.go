@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"context"
+
+	"tushartemplategin/pkg/interfaces"
+)
+
+// loggerContextKey is the private context key for the request-scoped logger
+type loggerContextKey struct{}
+
+// NewContext returns a copy of ctx that carries the given logger
+// Used by middleware to attach a request-scoped logger with bound fields
+func NewContext(ctx context.Context, log interfaces.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, log)
+}
+
+// FromContext returns the request-scoped logger stored in ctx
+// Falls back to the given logger when no request-scoped logger is present
+func FromContext(ctx context.Context, fallback interfaces.Logger) interfaces.Logger {
+	if ctx != nil {
+		if log, ok := ctx.Value(loggerContextKey{}).(interfaces.Logger); ok && log != nil {
+			return log
+		}
+	}
+	return fallback
+}
@@ -21,6 +21,7 @@ type Logger interface {
 	Warn(ctx context.Context, msg string, fields interfaces.Fields)             // Log warning message
 	Error(ctx context.Context, msg string, fields interfaces.Fields)            // Log error message
 	Fatal(ctx context.Context, msg string, err error, fields interfaces.Fields) // Log fatal message and exit
+	With(fields interfaces.Fields) interfaces.Logger                            // Create child logger with bound fields
 }
 
 // logger implements the Logger interface using zap
@@ -184,6 +185,15 @@ func (l *logger) Fatal(ctx context.Context, msg string, err error, fields interf
 	l.zapLogger.Fatal(msg, convertFields(enhancedFields)...)
 }
 
+// With returns a child logger that includes the given fields in every entry
+// The parent logger is not modified, so children can be created per request
+func (l *logger) With(fields interfaces.Fields) interfaces.Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	return &logger{zapLogger: l.zapLogger.With(convertFields(fields)...)}
+}
+
 // getCorrelationIDFromContext extracts correlation ID from context
 func getCorrelationIDFromContext(ctx context.Context) string {
 	if correlationID, ok := ctx.Value("correlation_id").(string); ok {
@@ -0,0 +1,363 @@
+package messageformat
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// reasonMissing is the ParameterProblem reason for an absent argument
+const reasonMissing = "missing"
+
+// Number and date styles accepted after the argument type
+const (
+	styleInteger  = "integer"
+	stylePercent  = "percent"
+	styleCurrency = "currency"
+	styleShort    = "short"
+	styleMedium   = "medium"
+	styleLong     = "long"
+	styleFull     = "full"
+
+	// skeletonPrefix starts an ICU number skeleton such as ::currency/EUR
+	skeletonPrefix = "::"
+)
+
+// defaultFractionDigits is the maximum number of decimals for plain numbers
+const defaultFractionDigits = 3
+
+// formatter renders nodes for one locale and one set of parameters
+type formatter struct {
+	tag        language.Tag
+	printer    *message.Printer
+	names      *localeNames
+	parameters map[string]interface{}
+	problems   []ParameterProblem
+	reported   map[string]bool
+}
+
+func newFormatter(locale string, parameters map[string]interface{}) *formatter {
+	tag, err := language.Parse(strings.ReplaceAll(locale, "_", "-"))
+	if err != nil || locale == "" {
+		tag = language.English
+	}
+	return &formatter{
+		tag:        tag,
+		printer:    message.NewPrinter(tag),
+		names:      namesFor(tag),
+		parameters: parameters,
+		reported:   make(map[string]bool),
+	}
+}
+
+// formatNodes writes nodes to out; pound is the value of # in the innermost plural
+func (f *formatter) formatNodes(out *strings.Builder, nodes []node, pound *float64) {
+	for _, n := range nodes {
+		switch n.kind {
+		case textNode:
+			out.WriteString(n.text)
+		case poundNode:
+			if pound == nil {
+				out.WriteString("#")
+				continue
+			}
+			out.WriteString(f.decimal(*pound, defaultFractionDigits))
+		case argumentNode:
+			out.WriteString(f.formatArgument(n))
+		case pluralNode:
+			f.formatPlural(out, n)
+		case selectNode:
+			f.formatSelect(out, n, pound)
+		}
+	}
+}
+
+// formatArgument renders {name}, {name, number, ...}, {name, date, ...} and {name, time, ...}
+func (f *formatter) formatArgument(n node) string {
+	value, ok := f.parameter(n.name)
+	if !ok {
+		return placeholder(n.name)
+	}
+
+	switch n.argType {
+	case TypeNumber:
+		amount, ok := toNumber(value)
+		if !ok {
+			f.report(n.name, "not a number")
+			return fmt.Sprint(value)
+		}
+		return f.number(amount, n.style)
+	case TypeDate, TypeTime:
+		moment, ok := toTime(value)
+		if !ok {
+			f.report(n.name, "not a date")
+			return fmt.Sprint(value)
+		}
+		if n.argType == TypeDate {
+			return f.names.formatDate(moment, n.style)
+		}
+		return f.names.formatTime(moment, n.style)
+	}
+
+	// Untyped arguments keep strings as they are and localize numbers and times
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case time.Time, *time.Time:
+		moment, _ := toTime(typed)
+		return f.names.formatDateTime(moment)
+	case json.Number:
+		return typed.String()
+	}
+	if amount, ok := toNumber(value); ok {
+		return f.decimal(amount, defaultFractionDigits)
+	}
+	return fmt.Sprint(value)
+}
+
+// formatPlural picks an explicit =N case, then the CLDR plural category, then other
+func (f *formatter) formatPlural(out *strings.Builder, n node) {
+	value, ok := f.parameter(n.name)
+	if !ok {
+		out.WriteString(placeholder(n.name))
+		return
+	}
+	amount, ok := toNumber(value)
+	if !ok {
+		f.report(n.name, "not a number")
+		out.WriteString(fmt.Sprint(value))
+		return
+	}
+
+	for selector, message := range n.cases {
+		if strings.HasPrefix(selector, "=") {
+			if exact, err := strconv.ParseFloat(selector[1:], 64); err == nil && exact == amount {
+				f.formatNodes(out, message, &amount)
+				return
+			}
+		}
+	}
+
+	shifted := amount - n.offset
+	rules := plural.Cardinal
+	if n.argType == TypeSelectOrdinal {
+		rules = plural.Ordinal
+	}
+	message, exists := n.cases[pluralCategory(rules, f.tag, shifted)]
+	if !exists {
+		message = n.cases["other"]
+	}
+	f.formatNodes(out, message, &shifted)
+}
+
+// formatSelect picks the case named by the argument value, or other
+// A missing argument also selects other; # keeps the enclosing plural value
+func (f *formatter) formatSelect(out *strings.Builder, n node, pound *float64) {
+	message := n.cases["other"]
+	if value, ok := f.parameter(n.name); ok {
+		if selected, exists := n.cases[fmt.Sprint(value)]; exists {
+			message = selected
+		}
+	}
+	f.formatNodes(out, message, pound)
+}
+
+// parameter returns a non-nil argument value, recording a missing one
+func (f *formatter) parameter(name string) (interface{}, bool) {
+	value, ok := f.parameters[name]
+	if !ok || value == nil {
+		f.report(name, reasonMissing)
+		return nil, false
+	}
+	return value, true
+}
+
+// report records a problem once per argument
+func (f *formatter) report(name, reason string) {
+	if f.reported[name] {
+		return
+	}
+	f.reported[name] = true
+	f.problems = append(f.problems, ParameterProblem{Name: name, Reason: reason})
+}
+
+// number formats a value for a number style
+func (f *formatter) number(amount float64, style string) string {
+	switch {
+	case style == styleInteger || style == skeletonPrefix+styleInteger:
+		return f.decimal(amount, 0)
+	case style == stylePercent || style == skeletonPrefix+stylePercent:
+		return f.printer.Sprint(number.Percent(amount))
+	case style == styleCurrency:
+		unit, _ := currency.FromTag(f.tag)
+		return f.currency(amount, unit)
+	case strings.HasPrefix(style, skeletonPrefix+styleCurrency+"/"):
+		unit, err := currency.ParseISO(strings.TrimPrefix(style, skeletonPrefix+styleCurrency+"/"))
+		if err != nil {
+			return f.decimal(amount, defaultFractionDigits)
+		}
+		return f.currency(amount, unit)
+	default:
+		return f.decimal(amount, defaultFractionDigits)
+	}
+}
+
+// decimal formats a number with locale separators and at most maxFraction decimals
+func (f *formatter) decimal(amount float64, maxFraction int) string {
+	return f.printer.Sprint(number.Decimal(amount, number.MaxFractionDigits(maxFraction)))
+}
+
+// currency formats an amount with the currency's decimals and the symbol placed
+// the way the locale writes it ("$1,234.50", "1 234,50 €")
+func (f *formatter) currency(amount float64, unit currency.Unit) string {
+	scale, _ := currency.Standard.Rounding(unit)
+	digits := f.printer.Sprint(number.Decimal(math.Abs(amount),
+		number.MinFractionDigits(scale), number.MaxFractionDigits(scale)))
+	symbol := f.printer.Sprint(currency.Symbol(unit))
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+	if f.names.currencyAfter {
+		return sign + digits + "\u00a0" + symbol
+	}
+	return sign + symbol + digits
+}
+
+// pluralCategory returns the CLDR plural keyword for a number
+func pluralCategory(rules *plural.Rules, tag language.Tag, amount float64) string {
+	i, v, w, fraction, trimmed := pluralOperands(amount)
+	switch rules.MatchPlural(tag, i, v, w, fraction, trimmed) {
+	case plural.Zero:
+		return "zero"
+	case plural.One:
+		return "one"
+	case plural.Two:
+		return "two"
+	case plural.Few:
+		return "few"
+	case plural.Many:
+		return "many"
+	default:
+		return "other"
+	}
+}
+
+// pluralOperands computes the CLDR operands i, v, w, f and t of a number
+func pluralOperands(amount float64) (i, v, w, f, t int) {
+	text := strconv.FormatFloat(math.Abs(amount), 'f', -1, 64)
+	integer, fraction, _ := strings.Cut(text, ".")
+	i, _ = strconv.Atoi(integer)
+	if fraction == "" {
+		return i, 0, 0, 0, 0
+	}
+	trimmed := strings.TrimRight(fraction, "0")
+	v, w = len(fraction), len(trimmed)
+	f, _ = strconv.Atoi(fraction)
+	t, _ = strconv.Atoi(trimmed)
+	return i, v, w, f, t
+}
+
+// validStyle reports whether a style is supported for an argument type
+func validStyle(argType, style string) bool {
+	switch argType {
+	case TypeNumber:
+		switch style {
+		case "", styleInteger, stylePercent, styleCurrency,
+			skeletonPrefix + styleInteger, skeletonPrefix + stylePercent:
+			return true
+		}
+		if code, ok := strings.CutPrefix(style, skeletonPrefix+styleCurrency+"/"); ok {
+			_, err := currency.ParseISO(code)
+			return err == nil
+		}
+		return false
+	case TypeDate, TypeTime:
+		switch style {
+		case "", styleShort, styleMedium, styleLong, styleFull:
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+// toNumber converts numeric parameters, including numeric strings from query parameters
+func toNumber(value interface{}) (float64, bool) {
+	switch typed := value.(type) {
+	case int:
+		return float64(typed), true
+	case int8:
+		return float64(typed), true
+	case int16:
+		return float64(typed), true
+	case int32:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	case uint:
+		return float64(typed), true
+	case uint8:
+		return float64(typed), true
+	case uint16:
+		return float64(typed), true
+	case uint32:
+		return float64(typed), true
+	case uint64:
+		return float64(typed), true
+	case float32:
+		return float64(typed), true
+	case float64:
+		return typed, true
+	case json.Number:
+		amount, err := typed.Float64()
+		return amount, err == nil
+	case string:
+		amount, err := strconv.ParseFloat(strings.TrimSpace(typed), 64)
+		return amount, err == nil
+	}
+	return 0, false
+}
+
+// timeLayouts are the string forms accepted for date and time arguments
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// toTime converts time values and ISO-8601 strings
+func toTime(value interface{}) (time.Time, bool) {
+	switch typed := value.(type) {
+	case time.Time:
+		return typed, true
+	case *time.Time:
+		if typed == nil {
+			return time.Time{}, false
+		}
+		return *typed, true
+	case string:
+		for _, layout := range timeLayouts {
+			if moment, err := time.Parse(layout, strings.TrimSpace(typed)); err == nil {
+				return moment, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// placeholder is how a missing argument is rendered
+func placeholder(name string) string {
+	return "{" + name + "}"
+}
@@ -0,0 +1,194 @@
+package messageformat
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// localeNames holds the date/time patterns and names of one language
+// Patterns use CLDR letters: y yy M MM MMM MMMM d dd EEEE H HH h mm ss a z,
+// with literal text in single quotes
+type localeNames struct {
+	months        [12]string
+	monthsShort   [12]string
+	weekdays      [7]string // Sunday first
+	dates         map[string]string
+	times         map[string]string
+	dateTimeJoin  string // Between date and time for untyped time arguments
+	currencyAfter bool   // "1 234,50 €" rather than "€1,234.50"
+}
+
+// locales maps primary language subtags to their names; others use English
+var locales = map[string]*localeNames{
+	"en": {
+		months:       [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		monthsShort:  [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		weekdays:     [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		dates:        map[string]string{styleShort: "M/d/yy", styleMedium: "MMM d, y", styleLong: "MMMM d, y", styleFull: "EEEE, MMMM d, y"},
+		times:        map[string]string{styleShort: "h:mm a", styleMedium: "h:mm:ss a", styleLong: "h:mm:ss a z"},
+		dateTimeJoin: ", ",
+	},
+	"fr": {
+		months:        [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		monthsShort:   [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		dates:         map[string]string{styleShort: "dd/MM/y", styleMedium: "d MMM y", styleLong: "d MMMM y", styleFull: "EEEE d MMMM y"},
+		times:         map[string]string{styleShort: "HH:mm", styleMedium: "HH:mm:ss", styleLong: "HH:mm:ss z"},
+		dateTimeJoin:  " ",
+		currencyAfter: true,
+	},
+	"de": {
+		months:        [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		monthsShort:   [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		dates:         map[string]string{styleShort: "dd.MM.yy", styleMedium: "dd.MM.y", styleLong: "d. MMMM y", styleFull: "EEEE, d. MMMM y"},
+		times:         map[string]string{styleShort: "HH:mm", styleMedium: "HH:mm:ss", styleLong: "HH:mm:ss z"},
+		dateTimeJoin:  ", ",
+		currencyAfter: true,
+	},
+	"es": {
+		months:        [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		monthsShort:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		dates:         map[string]string{styleShort: "d/M/yy", styleMedium: "d MMM y", styleLong: "d 'de' MMMM 'de' y", styleFull: "EEEE, d 'de' MMMM 'de' y"},
+		times:         map[string]string{styleShort: "H:mm", styleMedium: "H:mm:ss", styleLong: "H:mm:ss z"},
+		dateTimeJoin:  ", ",
+		currencyAfter: true,
+	},
+}
+
+// currencyAfterLanguages place the currency symbol after the amount; their
+// dates fall back to English
+var currencyAfterLanguages = map[string]bool{
+	"it": true, "pt": true, "ru": true, "pl": true, "cs": true,
+	"sv": true, "fi": true, "nb": true, "da": true,
+}
+
+// namesFor returns the names for a tag's primary language
+func namesFor(tag language.Tag) *localeNames {
+	base, _ := tag.Base()
+	if names, ok := locales[base.String()]; ok {
+		return names
+	}
+	if currencyAfterLanguages[base.String()] {
+		names := *locales["en"]
+		names.currencyAfter = true
+		return &names
+	}
+	return locales["en"]
+}
+
+// formatDate renders a date style ("" is medium)
+func (l *localeNames) formatDate(t time.Time, style string) string {
+	return l.formatPattern(t, l.pattern(l.dates, style))
+}
+
+// formatTime renders a time style ("" is medium; full is long)
+func (l *localeNames) formatTime(t time.Time, style string) string {
+	if style == styleFull {
+		style = styleLong
+	}
+	return l.formatPattern(t, l.pattern(l.times, style))
+}
+
+// formatDateTime renders a short date and time, as ICU does for untyped time arguments
+func (l *localeNames) formatDateTime(t time.Time) string {
+	return l.formatDate(t, styleShort) + l.dateTimeJoin + l.formatTime(t, styleShort)
+}
+
+func (l *localeNames) pattern(patterns map[string]string, style string) string {
+	if pattern, ok := patterns[style]; ok {
+		return pattern
+	}
+	return patterns[styleMedium]
+}
+
+// formatPattern expands a CLDR date pattern
+func (l *localeNames) formatPattern(t time.Time, pattern string) string {
+	var out strings.Builder
+	runes := []rune(pattern)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		if r == '\'' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			out.WriteString(string(runes[i+1 : end]))
+			i = end + 1
+			continue
+		}
+
+		count := 1
+		for i+count < len(runes) && runes[i+count] == r {
+			count++
+		}
+		out.WriteString(l.field(t, r, count))
+		i += count
+	}
+	return out.String()
+}
+
+// field renders one pattern letter repeated count times
+func (l *localeNames) field(t time.Time, letter rune, count int) string {
+	switch letter {
+	case 'y':
+		if count == 2 {
+			return twoDigits(t.Year() % 100)
+		}
+		return strconv.Itoa(t.Year())
+	case 'M':
+		switch count {
+		case 1:
+			return strconv.Itoa(int(t.Month()))
+		case 2:
+			return twoDigits(int(t.Month()))
+		case 3:
+			return l.monthsShort[t.Month()-1]
+		default:
+			return l.months[t.Month()-1]
+		}
+	case 'd':
+		if count == 2 {
+			return twoDigits(t.Day())
+		}
+		return strconv.Itoa(t.Day())
+	case 'E':
+		return l.weekdays[t.Weekday()]
+	case 'H':
+		if count == 2 {
+			return twoDigits(t.Hour())
+		}
+		return strconv.Itoa(t.Hour())
+	case 'h':
+		hour := t.Hour() % 12
+		if hour == 0 {
+			hour = 12
+		}
+		return strconv.Itoa(hour)
+	case 'm':
+		return twoDigits(t.Minute())
+	case 's':
+		return twoDigits(t.Second())
+	case 'a':
+		if t.Hour() < 12 {
+			return "AM"
+		}
+		return "PM"
+	case 'z':
+		zone, _ := t.Zone()
+		return zone
+	}
+	return strings.Repeat(string(letter), count)
+}
+
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
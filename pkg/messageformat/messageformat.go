@@ -0,0 +1,145 @@
+// Package messageformat formats ICU MessageFormat patterns
+//
+// Supported syntax:
+//   - {name}                                   argument (numbers and times are formatted for the locale)
+//   - {n, number} {n, number, integer|percent|currency|::currency/EUR}
+//   - {d, date, short|medium|long|full} {d, time, short|medium|long}
+//   - {n, plural, offset:1 =0 {none} one {# item} other {# items}}   (CLDR plural rules)
+//   - {n, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}
+//   - {g, select, female {elle} male {il} other {iel}}
+//   - a doubled apostrophe for a literal one, and '{...}' to quote syntax characters
+//
+// Formatting never stops at the first problem: missing or mistyped arguments
+// are rendered as "{name}" and reported in a *ParameterError, so callers can
+// choose between lenient and strict behaviour.
+package messageformat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Message is a parsed pattern that can be formatted many times
+type Message struct {
+	pattern string
+	nodes   []node
+}
+
+// Parse parses an ICU MessageFormat pattern
+func Parse(pattern string) (*Message, error) {
+	nodes, err := parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{pattern: pattern, nodes: nodes}, nil
+}
+
+// Format parses and formats a pattern in one call
+// A syntax error returns the pattern unchanged together with the error
+func Format(pattern, locale string, parameters map[string]interface{}) (string, error) {
+	message, err := Parse(pattern)
+	if err != nil {
+		return pattern, err
+	}
+	return message.Format(locale, parameters)
+}
+
+// Format renders the message for a BCP-47 locale
+// The result is always usable; a non-nil error is a *ParameterError listing
+// the arguments that were missing or could not be formatted
+func (m *Message) Format(locale string, parameters map[string]interface{}) (string, error) {
+	f := newFormatter(locale, parameters)
+	var out strings.Builder
+	f.formatNodes(&out, m.nodes, nil)
+
+	if len(f.problems) > 0 {
+		return out.String(), &ParameterError{Problems: f.problems}
+	}
+	return out.String(), nil
+}
+
+// Parameters returns the names of all arguments used by the message, sorted
+func (m *Message) Parameters() []string {
+	arguments := m.Arguments()
+	names := make([]string, 0, len(arguments))
+	for _, argument := range arguments {
+		names = append(names, argument.Name)
+	}
+	return names
+}
+
+// Argument is an argument of a message and the type it is formatted as
+type Argument struct {
+	Name string
+	Type string // "" for {name}, or one of the Type constants
+}
+
+// Arguments returns the arguments used by the message, sorted by name
+// An argument used both plainly and with a type reports its first type
+func (m *Message) Arguments() []Argument {
+	types := make(map[string]string)
+	collectArguments(m.nodes, types)
+
+	result := make([]Argument, 0, len(types))
+	for name, argType := range types {
+		result = append(result, Argument{Name: name, Type: argType})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// collectArguments walks nodes and nested cases
+func collectArguments(nodes []node, types map[string]string) {
+	for _, n := range nodes {
+		if n.kind == argumentNode || n.kind == pluralNode || n.kind == selectNode {
+			if types[n.name] == "" {
+				types[n.name] = n.argType
+			}
+		}
+		for _, selector := range sortedSelectors(n.cases) {
+			collectArguments(n.cases[selector], types)
+		}
+	}
+}
+
+// sortedSelectors returns case selectors in a stable order
+func sortedSelectors(cases map[string][]node) []string {
+	selectors := make([]string, 0, len(cases))
+	for selector := range cases {
+		selectors = append(selectors, selector)
+	}
+	sort.Strings(selectors)
+	return selectors
+}
+
+// ParameterProblem describes one argument that could not be formatted
+type ParameterProblem struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"` // "missing" or a description of the type error
+}
+
+// ParameterError reports the arguments that were missing or invalid
+type ParameterError struct {
+	Problems []ParameterProblem
+}
+
+// Error implements the error interface
+func (e *ParameterError) Error() string {
+	parts := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		parts = append(parts, fmt.Sprintf("%s: %s", problem.Name, problem.Reason))
+	}
+	return "messageformat: invalid parameters (" + strings.Join(parts, ", ") + ")"
+}
+
+// Missing returns the names of missing arguments
+func (e *ParameterError) Missing() []string {
+	var names []string
+	for _, problem := range e.Problems {
+		if problem.Reason == reasonMissing {
+			names = append(names, problem.Name)
+		}
+	}
+	return names
+}
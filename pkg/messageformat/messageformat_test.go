@@ -0,0 +1,127 @@
+package messageformat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nbsp = "\u00a0"
+
+func TestFormat(t *testing.T) {
+	moment := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		pattern    string
+		locale     string
+		parameters map[string]interface{}
+		expected   string
+	}{
+		{"simple", "Hello {name}!", "en-US", map[string]interface{}{"name": "Ada"}, "Hello Ada!"},
+		{"apostrophe", "L'alerte {id} est '{'active'}'", "fr-FR", map[string]interface{}{"id": "A1"}, "L'alerte A1 est {active}"},
+		{"doubled apostrophe", "it''s '#' {n}", "en", map[string]interface{}{"n": 1}, "it's '#' 1"},
+		{"untyped number", "{n} items", "de-DE", map[string]interface{}{"n": 1234.5}, "1.234,5 items"},
+		{"number", "{n, number}", "fr-FR", map[string]interface{}{"n": 1234567.891}, "1" + nbsp + "234" + nbsp + "567,891"},
+		{"integer", "{n, number, integer}", "en-US", map[string]interface{}{"n": "1234"}, "1,234"},
+		{"percent", "{n, number, percent}", "en-US", map[string]interface{}{"n": 0.256}, "26%"},
+		{"currency default", "{n, number, currency}", "en-US", map[string]interface{}{"n": 1234.5}, "$1,234.50"},
+		{"currency suffix", "{n, number, ::currency/EUR}", "fr-FR", map[string]interface{}{"n": 1234.5}, "1" + nbsp + "234,50" + nbsp + "€"},
+		{"currency no decimals", "{n, number, ::currency/JPY}", "en-US", map[string]interface{}{"n": -1500}, "-¥1,500"},
+		{"date medium", "{d, date}", "en-US", map[string]interface{}{"d": moment}, "Mar 5, 2024"},
+		{"date long fr", "{d, date, long}", "fr-FR", map[string]interface{}{"d": "2024-03-05"}, "5 mars 2024"},
+		{"date full es", "{d, date, full}", "es-ES", map[string]interface{}{"d": moment}, "martes, 5 de marzo de 2024"},
+		{"time short", "{d, time, short}", "en-US", map[string]interface{}{"d": "2024-03-05T14:07:09Z"}, "2:07 PM"},
+		{"untyped time", "{d}", "de-DE", map[string]interface{}{"d": moment}, "05.03.24, 14:07"},
+		{"plural exact", "{n, plural, =0 {no alerts} one {# alert} other {# alerts}}", "en", map[string]interface{}{"n": 0}, "no alerts"},
+		{"plural one", "{n, plural, =0 {no alerts} one {# alert} other {# alerts}}", "en", map[string]interface{}{"n": 1}, "1 alert"},
+		{"plural other", "{n, plural, =0 {no alerts} one {# alert} other {# alerts}}", "en", map[string]interface{}{"n": 1200}, "1,200 alerts"},
+		{"plural fraction", "{n, plural, one {# alert} other {# alerts}}", "en", map[string]interface{}{"n": 1.5}, "1.5 alerts"},
+		{"plural french zero", "{n, plural, one {# alerte} other {# alertes}}", "fr", map[string]interface{}{"n": 0}, "0 alerte"},
+		{"plural russian few", "{n, plural, one {# файл} few {# файла} many {# файлов} other {# файла}}", "ru", map[string]interface{}{"n": 3}, "3 файла"},
+		{"plural offset", "{n, plural, offset:1 =0 {nobody} =1 {{host}} one {{host} and # other} other {{host} and # others}}", "en",
+			map[string]interface{}{"n": 3, "host": "Ada"}, "Ada and 2 others"},
+		{"selectordinal", "{n, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}", "en", map[string]interface{}{"n": 23}, "23rd"},
+		{"select", "{g, select, female {elle} male {il} other {iel}} a validé", "fr", map[string]interface{}{"g": "female"}, "elle a validé"},
+		{"select other", "{g, select, female {elle} male {il} other {iel}}", "fr", map[string]interface{}{"g": "x"}, "iel"},
+		{"select in plural", "{n, plural, one {{g, select, female {une} other {un}} de #} other {#}}", "fr", map[string]interface{}{"n": 1, "g": "female"}, "une de 1"},
+		{"unknown locale", "{n, number}", "", map[string]interface{}{"n": 1000}, "1,000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Format(tt.pattern, tt.locale, tt.parameters)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestFormat_ReportsParameterProblems(t *testing.T) {
+	result, err := Format("{user} has {n, plural, one {# alert} other {# alerts}} due {d, date} ({g, select, a {A} other {?}})", "en",
+		map[string]interface{}{"d": "tomorrow", "user": nil})
+
+	assert.Equal(t, "{user} has {n} due tomorrow (?)", result)
+
+	var parameterErr *ParameterError
+	require.ErrorAs(t, err, &parameterErr)
+	assert.Equal(t, []ParameterProblem{
+		{Name: "user", Reason: "missing"},
+		{Name: "n", Reason: "missing"},
+		{Name: "d", Reason: "not a date"},
+		{Name: "g", Reason: "missing"},
+	}, parameterErr.Problems)
+	assert.Equal(t, []string{"user", "n", "g"}, parameterErr.Missing())
+}
+
+func TestParse_SyntaxErrors(t *testing.T) {
+	patterns := []string{
+		"Hello {name",
+		"Hello name}",
+		"{}",
+		"{n, plural, one {# item}}",
+		"{n, plural, one {a} one {b} other {c}}",
+		"{n, plural, =x {a} other {b}}",
+		"{n, spellout}",
+		"{n, number, ::compact-short}",
+		"{d, date, yyyy}",
+		"{g, select, male {il} other {iel}",
+	}
+
+	for _, pattern := range patterns {
+		t.Run(pattern, func(t *testing.T) {
+			_, err := Parse(pattern)
+			assert.Error(t, err)
+
+			result, err := Format(pattern, "en", nil)
+			assert.Error(t, err)
+			assert.Equal(t, pattern, result)
+		})
+	}
+}
+
+func TestMessage_Parameters(t *testing.T) {
+	message, err := Parse("{user} {n, plural, one {{g, select, a {{x}} other {#}}} other {{d, date}}} {user}")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"d", "g", "n", "user", "x"}, message.Parameters())
+
+	result, err := message.Format("en", map[string]interface{}{"user": "Ada", "n": 2, "d": "2024-01-31"})
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Jan 31, 2024 Ada", result)
+}
+
+func TestMessage_Arguments(t *testing.T) {
+	message, err := Parse("{user} {n, plural, other {{d, date} {amount, number, currency}}} {g, select, other {{user, select, other {x}}}}")
+	require.NoError(t, err)
+
+	assert.Equal(t, []Argument{
+		{Name: "amount", Type: "number"},
+		{Name: "d", Type: "date"},
+		{Name: "g", Type: "select"},
+		{Name: "n", Type: "plural"},
+		{Name: "user", Type: "select"},
+	}, message.Arguments())
+}
@@ -0,0 +1,320 @@
+package messageformat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// nodeKind identifies a piece of a parsed pattern
+type nodeKind int
+
+const (
+	textNode     nodeKind = iota // Literal text
+	argumentNode                 // {name} or {name, type, style}
+	pluralNode                   // {name, plural, ...} and {name, selectordinal, ...}
+	selectNode                   // {name, select, ...}
+	poundNode                    // # inside a plural case
+)
+
+// node is one element of a parsed pattern
+type node struct {
+	kind    nodeKind
+	text    string            // textNode
+	name    string            // Argument name
+	argType string            // "", number, date, time, plural, selectordinal, select
+	style   string            // number/date/time style or skeleton
+	offset  float64           // plural offset
+	cases   map[string][]node // plural/select cases: "=0", "one", "male", "other", ...
+}
+
+// Argument types, as reported by Message.Arguments
+const (
+	TypeNumber        = "number"
+	TypeDate          = "date"
+	TypeTime          = "time"
+	TypePlural        = "plural"
+	TypeSelectOrdinal = "selectordinal"
+	TypeSelect        = "select"
+)
+
+// parser is a recursive descent parser over ICU MessageFormat patterns
+type parser struct {
+	pattern     []rune
+	pos         int
+	pluralDepth int // # is the plural value inside plural cases, including nested selects
+}
+
+// parse parses a complete pattern
+func parse(pattern string) ([]node, error) {
+	p := &parser{pattern: []rune(pattern)}
+	nodes, err := p.parseMessage()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.pattern) {
+		return nil, p.errorf("unmatched '}'")
+	}
+	return nodes, nil
+}
+
+// parseMessage parses text and arguments until the end of the pattern or,
+// when nested, until the '}' closing the current case
+func (p *parser) parseMessage() ([]node, error) {
+	inPlural := p.pluralDepth > 0
+	var nodes []node
+	var text strings.Builder
+
+	flushText := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, node{kind: textNode, text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.pattern) {
+		r := p.pattern[p.pos]
+		switch {
+		case r == '\'':
+			p.parseApostrophe(&text, inPlural)
+		case r == '{':
+			flushText()
+			argument, err := p.parseArgument()
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, argument)
+		case r == '}':
+			flushText()
+			return nodes, nil
+		case r == '#' && inPlural:
+			flushText()
+			nodes = append(nodes, node{kind: poundNode})
+			p.pos++
+		default:
+			text.WriteRune(r)
+			p.pos++
+		}
+	}
+
+	flushText()
+	return nodes, nil
+}
+
+// parseApostrophe applies ICU quoting: ” is an apostrophe, a quote before a
+// syntax character starts literal text up to the next quote, and any other
+// apostrophe is kept as is (so "L'alerte" needs no escaping)
+func (p *parser) parseApostrophe(text *strings.Builder, inPlural bool) {
+	next := p.peek(1)
+	switch {
+	case next == '\'':
+		text.WriteRune('\'')
+		p.pos += 2
+	case next == '{' || next == '}' || next == '|' || (next == '#' && inPlural):
+		p.pos++
+		for p.pos < len(p.pattern) {
+			if p.pattern[p.pos] == '\'' {
+				if p.peek(1) == '\'' {
+					text.WriteRune('\'')
+					p.pos += 2
+					continue
+				}
+				p.pos++
+				return
+			}
+			text.WriteRune(p.pattern[p.pos])
+			p.pos++
+		}
+	default:
+		text.WriteRune('\'')
+		p.pos++
+	}
+}
+
+// parseArgument parses {name[, type[, style | cases]]}
+func (p *parser) parseArgument() (node, error) {
+	p.pos++ // '{'
+
+	p.skipSpace()
+	name := p.readIdentifier()
+	if name == "" {
+		return node{}, p.errorf("expected argument name")
+	}
+	argument := node{kind: argumentNode, name: name}
+
+	p.skipSpace()
+	if p.consume('}') {
+		return argument, nil
+	}
+	if !p.consume(',') {
+		return node{}, p.errorf("expected ',' or '}' after argument %q", name)
+	}
+
+	p.skipSpace()
+	argument.argType = strings.ToLower(p.readIdentifier())
+	p.skipSpace()
+
+	switch argument.argType {
+	case TypeNumber, TypeDate, TypeTime:
+		if p.consume(',') {
+			style, err := p.readStyle()
+			if err != nil {
+				return node{}, err
+			}
+			argument.style = style
+		}
+		if !validStyle(argument.argType, argument.style) {
+			return node{}, p.errorf("unsupported %s style %q", argument.argType, argument.style)
+		}
+		if !p.consume('}') {
+			return node{}, p.errorf("expected '}' after %s argument %q", argument.argType, name)
+		}
+		return argument, nil
+	case TypePlural, TypeSelectOrdinal, TypeSelect:
+		if !p.consume(',') {
+			return node{}, p.errorf("expected ',' after %s argument %q", argument.argType, name)
+		}
+		return p.parseCases(argument)
+	case "":
+		return node{}, p.errorf("expected argument type for %q", name)
+	default:
+		return node{}, p.errorf("unsupported argument type %q", argument.argType)
+	}
+}
+
+// parseCases parses the cases of a plural, selectordinal or select argument
+func (p *parser) parseCases(argument node) (node, error) {
+	plural := argument.argType != TypeSelect
+	if plural {
+		argument.kind = pluralNode
+	} else {
+		argument.kind = selectNode
+	}
+	argument.cases = make(map[string][]node)
+
+	p.skipSpace()
+	if plural && p.hasPrefix("offset:") {
+		p.pos += len("offset:")
+		p.skipSpace()
+		offset, err := strconv.ParseFloat(p.readIdentifier(), 64)
+		if err != nil {
+			return node{}, p.errorf("invalid plural offset")
+		}
+		argument.offset = offset
+	}
+
+	for {
+		p.skipSpace()
+		if p.consume('}') {
+			break
+		}
+		if p.pos >= len(p.pattern) {
+			return node{}, p.errorf("unterminated %s argument %q", argument.argType, argument.name)
+		}
+
+		selector := p.readSelector()
+		if selector == "" {
+			return node{}, p.errorf("expected case selector in %q", argument.name)
+		}
+		if plural && strings.HasPrefix(selector, "=") {
+			if _, err := strconv.ParseFloat(selector[1:], 64); err != nil {
+				return node{}, p.errorf("invalid explicit value %q", selector)
+			}
+		}
+		if _, exists := argument.cases[selector]; exists {
+			return node{}, p.errorf("duplicate case %q in %q", selector, argument.name)
+		}
+
+		p.skipSpace()
+		if !p.consume('{') {
+			return node{}, p.errorf("expected '{' after case %q", selector)
+		}
+		if plural {
+			p.pluralDepth++
+		}
+		message, err := p.parseMessage()
+		if plural {
+			p.pluralDepth--
+		}
+		if err != nil {
+			return node{}, err
+		}
+		if !p.consume('}') {
+			return node{}, p.errorf("unterminated case %q", selector)
+		}
+		argument.cases[selector] = message
+	}
+
+	if _, exists := argument.cases["other"]; !exists {
+		return node{}, fmt.Errorf("messageformat: %s argument %q needs an 'other' case", argument.argType, argument.name)
+	}
+	return argument, nil
+}
+
+// readStyle reads a number/date/time style up to the closing '}'
+func (p *parser) readStyle() (string, error) {
+	start := p.pos
+	for p.pos < len(p.pattern) && p.pattern[p.pos] != '}' {
+		if p.pattern[p.pos] == '{' {
+			return "", p.errorf("unexpected '{' in argument style")
+		}
+		p.pos++
+	}
+	return strings.TrimSpace(string(p.pattern[start:p.pos])), nil
+}
+
+// readIdentifier reads an argument name, type or number
+func (p *parser) readIdentifier() string {
+	start := p.pos
+	for p.pos < len(p.pattern) {
+		r := p.pattern[p.pos]
+		if !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.') {
+			break
+		}
+		p.pos++
+	}
+	return string(p.pattern[start:p.pos])
+}
+
+// readSelector reads a case selector such as "one", "=0" or "female"
+func (p *parser) readSelector() string {
+	start := p.pos
+	for p.pos < len(p.pattern) {
+		r := p.pattern[p.pos]
+		if unicode.IsSpace(r) || r == '{' || r == '}' {
+			break
+		}
+		p.pos++
+	}
+	return string(p.pattern[start:p.pos])
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.pattern) && unicode.IsSpace(p.pattern[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) consume(r rune) bool {
+	if p.pos < len(p.pattern) && p.pattern[p.pos] == r {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) peek(offset int) rune {
+	if p.pos+offset < len(p.pattern) {
+		return p.pattern[p.pos+offset]
+	}
+	return 0
+}
+
+func (p *parser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(string(p.pattern[p.pos:]), prefix)
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("messageformat: %s at offset %d", fmt.Sprintf(format, args...), p.pos)
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"tushartemplategin/pkg/interfaces"
+)
+
+// AccessLogConfig contains settings for the HTTP access log middleware
+type AccessLogConfig struct {
+	SkipPaths     []string      // Paths that are never logged (e.g. health probes)
+	SlowThreshold time.Duration // Requests slower than this are logged at Warn level (0 disables)
+}
+
+// AccessLogMiddleware emits one structured log line per completed request
+//
+// Fields logged:
+// - method, route (template such as /api/v1/products/:id), path, status
+// - latency_ms, bytes, client_ip, user_agent
+// - correlation_id and trace_id (added by the logger from the request context)
+// - principal (when set by authentication middleware)
+//
+// Production Considerations:
+// - Register before ErrorHandlerMiddleware so the final status and size are observed
+// - Skip high-frequency probe paths to keep log volume under control
+// - Slow requests and server errors are escalated to Warn for alerting
+func AccessLogMiddleware(logger interfaces.Logger, cfg AccessLogConfig) gin.HandlerFunc {
+	// Build lookup set once instead of scanning the slice per request
+	skipPaths := make(map[string]struct{}, len(cfg.SkipPaths))
+	for _, path := range cfg.SkipPaths {
+		skipPaths[path] = struct{}{}
+	}
+
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if _, skip := skipPaths[c.Request.URL.Path]; skip {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+
+		// Continue to next middleware/handler
+		c.Next()
+
+		latency := time.Since(start)
+		status := c.Writer.Status()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		bytes := c.Writer.Size()
+		if bytes < 0 {
+			bytes = 0
+		}
+
+		fields := interfaces.Fields{
+			"method":     c.Request.Method,
+			"route":      route,
+			"path":       c.Request.URL.Path,
+			"status":     status,
+			"latency_ms": float64(latency.Microseconds()) / 1000.0,
+			"bytes":      bytes,
+			"client_ip":  c.ClientIP(),
+			"user_agent": c.Request.UserAgent(),
+		}
+
+		if principal, exists := c.Get(PrincipalKey); exists {
+			fields["principal"] = principal
+		}
+
+		// Use the request context so the logger picks up correlation and trace IDs
+		ctx := c.Request.Context()
+
+		isSlow := cfg.SlowThreshold > 0 && latency >= cfg.SlowThreshold
+		switch {
+		case isSlow:
+			fields["slow_threshold_ms"] = cfg.SlowThreshold.Milliseconds()
+			logger.Warn(ctx, "Slow HTTP request", fields)
+		case status >= 500:
+			logger.Warn(ctx, "HTTP request", fields)
+		default:
+			logger.Info(ctx, "HTTP request", fields)
+		}
+	})
+}
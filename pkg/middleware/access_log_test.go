@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"tushartemplategin/mocks"
+	"tushartemplategin/pkg/interfaces"
+)
+
+func newAccessLogRouter(logger interfaces.Logger, cfg AccessLogConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(AccessLogMiddleware(logger, cfg))
+	router.GET("/products/:id", func(c *gin.Context) {
+		c.Set(PrincipalKey, "user-42")
+		c.String(http.StatusOK, "ok")
+	})
+	router.GET("/slow", func(c *gin.Context) {
+		time.Sleep(5 * time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+	router.GET("/health", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestAccessLogMiddleware_LogsCompletedRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	router := newAccessLogRouter(mockLogger, AccessLogConfig{})
+
+	var logged interfaces.Fields
+	mockLogger.EXPECT().Info(gomock.Any(), "HTTP request", gomock.Any()).
+		Do(func(_ interface{}, _ string, fields interfaces.Fields) { logged = fields })
+
+	req := httptest.NewRequest(http.MethodGet, "/products/7", nil)
+	req.Header.Set("User-Agent", "test-agent")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, http.MethodGet, logged["method"])
+	assert.Equal(t, "/products/:id", logged["route"])
+	assert.Equal(t, "/products/7", logged["path"])
+	assert.Equal(t, http.StatusOK, logged["status"])
+	assert.Equal(t, 2, logged["bytes"])
+	assert.Equal(t, "test-agent", logged["user_agent"])
+	assert.Equal(t, "user-42", logged["principal"])
+}
+
+func TestAccessLogMiddleware_SkipsConfiguredPaths(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No logger expectations: any call fails the test
+	mockLogger := mocks.NewMockLogger(ctrl)
+	router := newAccessLogRouter(mockLogger, AccessLogConfig{SkipPaths: []string{"/health"}})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+}
+
+func TestAccessLogMiddleware_EscalatesSlowRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	router := newAccessLogRouter(mockLogger, AccessLogConfig{SlowThreshold: time.Millisecond})
+
+	mockLogger.EXPECT().Warn(gomock.Any(), "Slow HTTP request", gomock.Any())
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+}
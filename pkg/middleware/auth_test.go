@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"tushartemplategin/mocks"
+)
+
+func newAuthRouter(t *testing.T) *gin.Engine {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandlerMiddleware(mockLogger))
+	router.POST("/admin", APIKeyAuth(map[string]string{"ops": "s3cret"}, mockLogger), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(PrincipalKey))
+	})
+	return router
+}
+
+func TestAPIKeyAuth_AcceptsBearerAndHeaderKeys(t *testing.T) {
+	router := newAuthRouter(t)
+
+	bearer := httptest.NewRequest(http.MethodPost, "/admin", nil)
+	bearer.Header.Set("Authorization", "Bearer s3cret")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, bearer)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "ops", recorder.Body.String())
+
+	header := httptest.NewRequest(http.MethodPost, "/admin", nil)
+	header.Header.Set(APIKeyHeader, "s3cret")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, header)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestAPIKeyAuth_RejectsMissingOrWrongKey(t *testing.T) {
+	router := newAuthRouter(t)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/admin", nil))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	wrong := httptest.NewRequest(http.MethodPost, "/admin", nil)
+	wrong.Header.Set("Authorization", "Bearer nope")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, wrong)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "UNAUTHORIZED")
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"tushartemplategin/mocks"
+	"tushartemplategin/pkg/interfaces"
+	"tushartemplategin/pkg/logger"
+)
+
+func TestCorrelationIDMiddleware_AttachesRequestLogger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	baseLogger := mocks.NewMockLogger(ctrl)
+	routeLogger := mocks.NewMockLogger(ctrl)
+	principalLogger := mocks.NewMockLogger(ctrl)
+
+	baseLogger.EXPECT().With(interfaces.Fields{
+		"method": http.MethodGet,
+		"route":  "/products/:id",
+	}).Return(routeLogger)
+	routeLogger.EXPECT().With(interfaces.Fields{"principal": "user-42"}).Return(principalLogger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CorrelationIDMiddleware(baseLogger))
+
+	var afterRoute, afterPrincipal interfaces.Logger
+	router.GET("/products/:id", func(c *gin.Context) {
+		afterRoute = logger.FromContext(c.Request.Context(), nil)
+		SetPrincipal(c, "user-42")
+		afterPrincipal = logger.FromContext(c.Request.Context(), nil)
+		c.Status(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/products/1", nil))
+
+	assert.Equal(t, routeLogger, afterRoute)
+	assert.Equal(t, principalLogger, afterPrincipal)
+	assert.NotEmpty(t, recorder.Header().Get(CorrelationIDHeader))
+}
+
+func TestCorrelationIDMiddleware_KeepsValidCorrelationID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CorrelationIDMiddleware(nil))
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, c.Request.Context().Value(CorrelationIDKey).(string))
+	})
+
+	const correlationID = "123e4567-e89b-12d3-a456-426614174000"
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(CorrelationIDHeader, correlationID)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, correlationID, recorder.Body.String())
+	assert.Equal(t, correlationID, recorder.Header().Get(CorrelationIDHeader))
+}
@@ -69,14 +69,27 @@ func convertToAppError(err error) *errors.AppError {
 }
 
 // respondWithError sends a structured error response
+// With ErrorLocalizationMiddleware the message comes from the error catalog in
+// the client's language; the code stays the same in every language
 func respondWithError(c *gin.Context, appErr *errors.AppError) {
-	// Set the appropriate HTTP status code
-	c.JSON(appErr.HTTPStatus, gin.H{
+	response := gin.H{
 		"error":   appErr.Code,
 		"message": appErr.Message,
 		"details": appErr.Details,
 		"fields":  appErr.Fields,
-	})
+	}
+
+	if localized := localizeError(c, appErr); localized != nil {
+		response["message"] = localized.Message
+		response["detailed_description"] = localized.DetailedDescription
+		response["response_action"] = localized.ResponseAction
+		response["language"] = localized.Language
+		c.Header("Content-Language", localized.Language)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+	}
+
+	// Set the appropriate HTTP status code
+	c.JSON(appErr.HTTPStatus, response)
 }
 
 // HandleError is a helper function for handlers to set errors
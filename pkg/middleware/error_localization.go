@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"tushartemplategin/pkg/errors"
+)
+
+// ErrorLocalizerKey is the gin context key holding the ErrorLocalizer
+const ErrorLocalizerKey = "errorLocalizer"
+
+// LocalizedError holds the catalog texts of an error code in one language
+type LocalizedError struct {
+	Message             string
+	DetailedDescription string
+	ResponseAction      string
+	Language            string
+}
+
+// ErrorLocalizer resolves error codes to localized texts
+// languages are the client's languages, most preferred first; ok is false when
+// the code has no catalog entry and the AppError's own message should be used
+type ErrorLocalizer interface {
+	LocalizeError(ctx context.Context, code string, parameters map[string]interface{}, languages []string) (localized *LocalizedError, ok bool)
+}
+
+// ErrorLocalizationMiddleware makes error responses use localized catalog texts
+// Error responses written by ErrorHandlerMiddleware, NotFoundHandler and
+// MethodNotAllowedHandler keep the stable code in "error" and take "message",
+// "detailed_description" and "response_action" from the localizer in the language
+// negotiated from ?language= and Accept-Language
+func ErrorLocalizationMiddleware(localizer ErrorLocalizer) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		c.Set(ErrorLocalizerKey, localizer)
+		c.Next()
+	})
+}
+
+// localizeError returns the localized texts for an error, or nil
+func localizeError(c *gin.Context, appErr *errors.AppError) *LocalizedError {
+	value, exists := c.Get(ErrorLocalizerKey)
+	if !exists {
+		return nil
+	}
+	localizer, ok := value.(ErrorLocalizer)
+	if !ok || localizer == nil {
+		return nil
+	}
+
+	localized, ok := localizer.LocalizeError(c.Request.Context(), string(appErr.Code), appErr.Fields, PreferredLanguages(c))
+	if !ok {
+		return nil
+	}
+	return localized
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"tushartemplategin/mocks"
+	"tushartemplategin/pkg/errors"
+)
+
+// stubLocalizer translates PRODUCT_NOT_FOUND only and records the languages it was given
+type stubLocalizer struct {
+	languages []string
+}
+
+func (s *stubLocalizer) LocalizeError(ctx context.Context, code string, parameters map[string]interface{}, languages []string) (*LocalizedError, bool) {
+	s.languages = languages
+	if code != string(errors.ErrCodeProductNotFound) {
+		return nil, false
+	}
+	return &LocalizedError{
+		Message:             "Produit introuvable",
+		DetailedDescription: "Aucun produit n'existe avec cet identifiant.",
+		ResponseAction:      "Vérifiez l'identifiant ou le SKU du produit",
+		Language:            "fr-FR",
+	}, true
+}
+
+func newLocalizedErrorRouter(t *testing.T, localizer ErrorLocalizer) *gin.Engine {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	mockLogger := mocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandlerMiddleware(mockLogger))
+	router.Use(ErrorLocalizationMiddleware(localizer))
+	router.GET("/products/:id", func(c *gin.Context) {
+		HandleAppError(c, errors.New(errors.ErrCodeProductNotFound, "Product not found", http.StatusNotFound))
+	})
+	router.GET("/conflict", func(c *gin.Context) {
+		HandleAppError(c, errors.New(errors.ErrCodeConflict, "Conflict", http.StatusConflict))
+	})
+	return router
+}
+
+func TestErrorLocalization_UsesLocalizedTexts(t *testing.T) {
+	localizer := &stubLocalizer{}
+	router := newLocalizedErrorRouter(t, localizer)
+
+	req := httptest.NewRequest(http.MethodGet, "/products/7", nil)
+	req.Header.Set("Accept-Language", "en;q=0.5, fr-CA")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, "PRODUCT_NOT_FOUND", body["error"])
+	assert.Equal(t, "Produit introuvable", body["message"])
+	assert.Equal(t, "Vérifiez l'identifiant ou le SKU du produit", body["response_action"])
+	assert.Equal(t, "fr-FR", body["language"])
+	assert.Equal(t, "fr-FR", recorder.Header().Get("Content-Language"))
+	assert.Equal(t, []string{"fr-CA", "en"}, localizer.languages)
+}
+
+func TestErrorLocalization_KeepsMessageForUnknownCodes(t *testing.T) {
+	router := newLocalizedErrorRouter(t, &stubLocalizer{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/conflict", nil))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+
+	assert.Equal(t, "Conflict", body["message"])
+	assert.NotContains(t, body, "language")
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LanguageQueryParam is the query parameter that overrides Accept-Language
+const LanguageQueryParam = "language"
+
+// PreferredLanguage returns the language requested by the client
+// The ?language= query parameter wins over the highest-quality Accept-Language tag;
+// an empty result means the caller should use its default language
+func PreferredLanguage(c *gin.Context) string {
+	if languages := PreferredLanguages(c); len(languages) > 0 {
+		return languages[0]
+	}
+	return ""
+}
+
+// PreferredLanguages returns every language the client accepts, most preferred first:
+// the ?language= query parameter, then the Accept-Language tags by quality
+func PreferredLanguages(c *gin.Context) []string {
+	languages := ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+	if language := c.Query(LanguageQueryParam); language != "" {
+		languages = append([]string{language}, languages...)
+	}
+	return languages
+}
+
+// ParseAcceptLanguage parses an Accept-Language header (RFC 9110) into tags
+// ordered by quality value; equal qualities keep header order
+// Tags with q=0, invalid quality values and the "*" wildcard are left out
+func ParseAcceptLanguage(header string) []string {
+	type weightedTag struct {
+		tag     string
+		quality float64
+	}
+
+	var weighted []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		tag := strings.TrimSpace(params[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || !strings.EqualFold(strings.TrimSpace(name), "q") {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				quality = 0
+			} else {
+				quality = parsed
+			}
+		}
+
+		if quality > 0 {
+			weighted = append(weighted, weightedTag{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool { return weighted[i].quality > weighted[j].quality })
+
+	tags := make([]string, 0, len(weighted))
+	for _, w := range weighted {
+		tags = append(tags, w.tag)
+	}
+	return tags
+}
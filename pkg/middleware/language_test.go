@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected []string
+	}{
+		{"", []string{}},
+		{"fr-CA", []string{"fr-CA"}},
+		{"en;q=0.5, fr-CA, fr;q=0.8", []string{"fr-CA", "fr", "en"}},
+		{"de;q=0, *;q=0.5, it", []string{"it"}},
+		{"es;q=abc, pt;Q=0.7, en;q=0.7", []string{"pt", "en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestPreferredLanguages_QueryOverridesHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/?language=de-DE", nil)
+	c.Request.Header.Set("Accept-Language", "en;q=0.4, fr")
+
+	assert.Equal(t, []string{"de-DE", "fr", "en"}, PreferredLanguages(c))
+	assert.Equal(t, "de-DE", PreferredLanguage(c))
+}
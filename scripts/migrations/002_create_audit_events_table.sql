@@ -0,0 +1,21 @@
+-- Migration: Create audit_events table
+-- Description: Stores the audit trail (who changed what) for domain entities
+-- Version: 002
+-- Date: 2026-10-18
+
+-- Create audit_events table
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(100) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    correlation_id VARCHAR(64) NOT NULL DEFAULT '',
+    changes JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for history lookups and investigations
+CREATE INDEX IF NOT EXISTS idx_audit_events_entity ON audit_events(entity_type, entity_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events(actor);
+CREATE INDEX IF NOT EXISTS idx_audit_events_correlation_id ON audit_events(correlation_id);
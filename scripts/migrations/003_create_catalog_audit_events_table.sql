@@ -0,0 +1,23 @@
+-- Migration: Create catalog_audit_events table
+-- Description: Stores audit events raised from the audit message catalog
+-- Version: 003
+-- Date: 2026-10-18
+
+-- Create catalog_audit_events table
+CREATE TABLE IF NOT EXISTS catalog_audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_code VARCHAR(50) NOT NULL,
+    event_category VARCHAR(100) NOT NULL,
+    risk_level VARCHAR(20) NOT NULL,
+    component VARCHAR(100) NOT NULL DEFAULT '',
+    parameters JSONB NOT NULL DEFAULT '{}'::jsonb,
+    actor VARCHAR(255) NOT NULL,
+    correlation_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for the filter endpoints and retention purge
+CREATE INDEX IF NOT EXISTS idx_catalog_audit_events_created_at ON catalog_audit_events(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_catalog_audit_events_code ON catalog_audit_events(event_code, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_catalog_audit_events_category ON catalog_audit_events(event_category, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_catalog_audit_events_risk_level ON catalog_audit_events(risk_level, created_at DESC);
@@ -0,0 +1,30 @@
+-- Migration: Create alerts table
+-- Description: Stores alerts raised from the alert message catalog and their lifecycle
+-- Version: 004
+-- Date: 2026-10-18
+
+-- Create alerts table
+CREATE TABLE IF NOT EXISTS alerts (
+    id BIGSERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,
+    category VARCHAR(100) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    component VARCHAR(100) NOT NULL DEFAULT '',
+    parameters JSONB NOT NULL DEFAULT '{}'::jsonb,
+    fingerprint CHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'acknowledged', 'resolved')),
+    occurrences INTEGER NOT NULL DEFAULT 1 CHECK (occurrences >= 1),
+    raised_by VARCHAR(255) NOT NULL,
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    acknowledged_by VARCHAR(255) NOT NULL DEFAULT '',
+    acknowledged_at TIMESTAMP WITH TIME ZONE,
+    resolved_by VARCHAR(255) NOT NULL DEFAULT '',
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create indexes for deduplication lookups and the list filters
+CREATE INDEX IF NOT EXISTS idx_alerts_fingerprint_active ON alerts(fingerprint, last_seen_at DESC) WHERE status <> 'resolved';
+CREATE INDEX IF NOT EXISTS idx_alerts_status ON alerts(status, last_seen_at DESC);
+CREATE INDEX IF NOT EXISTS idx_alerts_severity ON alerts(severity, last_seen_at DESC);
+CREATE INDEX IF NOT EXISTS idx_alerts_code ON alerts(code);
@@ -0,0 +1,31 @@
+-- Migration: Create message catalog tables
+-- Description: Stores message catalogs for catalogs configured with "source": "database"
+-- Version: 005
+-- Date: 2026-10-18
+
+-- Message structure (same fields as messagecatelog.json)
+CREATE TABLE IF NOT EXISTS message_catalog_messages (
+    catalog_name VARCHAR(100) NOT NULL,
+    message_code VARCHAR(50) NOT NULL,
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    severity VARCHAR(20) NOT NULL DEFAULT '',
+    component VARCHAR(100) NOT NULL DEFAULT '',
+    PRIMARY KEY (catalog_name, message_code)
+);
+
+-- Translations (same fields as messagecatelog-{lang}.json)
+CREATE TABLE IF NOT EXISTS message_catalog_translations (
+    catalog_name VARCHAR(100) NOT NULL,
+    message_code VARCHAR(50) NOT NULL,
+    language VARCHAR(35) NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    detailed_description TEXT NOT NULL DEFAULT '',
+    response_action TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (catalog_name, message_code, language),
+    FOREIGN KEY (catalog_name, message_code)
+        REFERENCES message_catalog_messages(catalog_name, message_code) ON DELETE CASCADE
+);
+
+-- Language listing per catalog
+CREATE INDEX IF NOT EXISTS idx_message_catalog_translations_language ON message_catalog_translations(catalog_name, language);
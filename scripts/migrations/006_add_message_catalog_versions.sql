@@ -0,0 +1,23 @@
+-- Migration: Add message catalog versions
+-- Description: Versions message catalog entries edited through the catalog management API
+-- Version: 006
+-- Date: 2026-10-18
+
+-- Current version of each message, checked by every edit
+ALTER TABLE message_catalog_messages
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+-- One snapshot per version of a message (structure and every translation as JSON);
+-- kept after the message is deleted so it can be restored
+CREATE TABLE IF NOT EXISTS message_catalog_versions (
+    catalog_name VARCHAR(100) NOT NULL,
+    message_code VARCHAR(50) NOT NULL,
+    version INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    author VARCHAR(255) NOT NULL DEFAULT '',
+    snapshot JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (catalog_name, message_code, version)
+);
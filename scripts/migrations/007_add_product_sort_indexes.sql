@@ -0,0 +1,12 @@
+-- Migration: Add product sort indexes
+-- Description: Supports keyset pagination of the product list on every sort field
+-- Version: 007
+-- Date: 2026-10-18
+
+-- Each sort column is paired with id, the tiebreaker of the keyset;
+-- the same index serves both sort directions
+CREATE INDEX IF NOT EXISTS idx_products_name_id ON products(name, id);
+CREATE INDEX IF NOT EXISTS idx_products_price_id ON products(price, id);
+CREATE INDEX IF NOT EXISTS idx_products_stock_id ON products(stock, id);
+CREATE INDEX IF NOT EXISTS idx_products_updated_at_id ON products(updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_products_created_at_id ON products(created_at, id);
@@ -0,0 +1,11 @@
+-- Migration: Add product filter indexes
+-- Description: Supports the SKU prefix filter of the product list
+-- Version: 008
+-- Date: 2026-10-18
+
+-- The created/updated date range filters need no index of their own: they are
+-- range scans on the leading column of idx_products_created_at_id and
+-- idx_products_updated_at_id from migration 007
+
+-- A pattern-ops index lets sku LIKE 'prefix%' use an index under any collation
+CREATE INDEX IF NOT EXISTS idx_products_sku_pattern ON products(sku varchar_pattern_ops);
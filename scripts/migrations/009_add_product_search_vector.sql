@@ -0,0 +1,16 @@
+-- Migration: Add product search vector
+-- Description: Full-text search over product names, SKUs and descriptions
+-- Version: 009
+-- Date: 2026-10-18
+
+-- Kept up to date by PostgreSQL (12+) on every insert and update; names and SKUs
+-- rank above descriptions. The 'english' configuration must match the one the
+-- repository queries with (textSearchConfig)
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(name, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(sku, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector);
@@ -0,0 +1,10 @@
+-- Migration: Add product soft delete
+-- Description: Deleted products are kept, restorable, until purged after the retention period
+-- Version: 010
+-- Date: 2026-10-18
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Only deleted rows are indexed: the purge and the deleted listing read them,
+-- every other query filters on deleted_at IS NULL
+CREATE INDEX IF NOT EXISTS idx_products_deleted_at ON products(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Migration: Add product version
+-- Description: Version counter for optimistic concurrency; served as the ETag of a product
+-- Version: 011
+-- Date: 2026-10-18
+
+-- Existing products start at version 1; every write increments it
+ALTER TABLE products ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
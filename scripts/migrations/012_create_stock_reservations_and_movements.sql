@@ -0,0 +1,38 @@
+-- Migration: Create stock_reservations and stock_movements tables
+-- Description: Stock held for checkouts until committed, released or expired, and the ledger of every stock change
+-- Version: 012
+-- Date: 2026-10-18
+
+-- A reservation takes its quantity out of products.stock while it is active;
+-- releasing or expiring it puts the quantity back, committing it makes it final
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    reference VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Only active reservations can expire; the sweep reads them by expiry
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_expires_at ON stock_reservations(expires_at) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_product_id ON stock_reservations(product_id);
+
+-- One row per change of products.stock, written in the transaction of the change
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    delta INTEGER NOT NULL,
+    stock_after INTEGER NOT NULL CHECK (stock_after >= 0),
+    reason VARCHAR(20) NOT NULL,
+    reservation_id BIGINT REFERENCES stock_reservations(id) ON DELETE SET NULL,
+    reference VARCHAR(255) NOT NULL DEFAULT '',
+    actor VARCHAR(255) NOT NULL,
+    correlation_id VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product_id ON stock_movements(product_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_reservation_id ON stock_movements(reservation_id) WHERE reservation_id IS NOT NULL;